@@ -35,6 +35,14 @@ func Dump(db *genji.DB, w io.Writer, tables ...string) error {
 
 		return dumpTable(tx, w, query, name)
 	})
+	if err == nil {
+		err = dumpViews(tx, w, tables, i > 0)
+	}
+	if err == nil {
+		// triggers are created after the data is inserted
+		// so that restoring the dump doesn't fire them
+		err = dumpTriggers(tx, w, tables, i > 0)
+	}
 	if err != nil {
 		_, er := fmt.Fprintln(w, "ROLLBACK;")
 		return multierr.Append(err, er)
@@ -84,7 +92,7 @@ func DumpSchema(db *genji.DB, w io.Writer, tables ...string) error {
 	defer tx.Rollback()
 
 	i := 0
-	return QueryTables(tx, tables, func(name, query string) error {
+	err = QueryTables(tx, tables, func(name, query string) error {
 		// Blank separation between tables.
 		if i > 0 {
 			if _, err := fmt.Fprintln(w, ""); err != nil {
@@ -95,6 +103,16 @@ func DumpSchema(db *genji.DB, w io.Writer, tables ...string) error {
 
 		return dumpSchema(tx, w, query, name)
 	})
+	if err != nil {
+		return err
+	}
+
+	err = dumpViews(tx, w, tables, i > 0)
+	if err != nil {
+		return err
+	}
+
+	return dumpTriggers(tx, w, tables, i > 0)
 }
 
 // dumpSchema displays the schema of the given table as SQL statements.
@@ -105,9 +123,10 @@ func dumpSchema(tx *genji.Tx, w io.Writer, query string, tableName string) error
 	}
 
 	// Indexes statements.
+	// Indexes created by table constraints are recreated with the table.
 	res, err := tx.Query(`
 		SELECT sql FROM __genji_catalog WHERE 
-			type = 'index' AND owner.table_name = ? OR
+			type = 'index' AND owner.table_name = ? AND owner.paths IS NULL OR
 			type = 'sequence' AND owner IS NULL
 	`, tableName)
 	if err != nil {
@@ -127,3 +146,37 @@ func dumpSchema(tx *genji.Tx, w io.Writer, query string, tableName string) error
 		return err
 	})
 }
+
+// dumpViews displays the CREATE VIEW statements of the selected views,
+// or of all views if none is selected.
+// If separate is true, the views are separated from what precedes them by a blank line.
+func dumpViews(tx *genji.Tx, w io.Writer, views []string, separate bool) error {
+	return QueryViews(tx, views, func(name, query string) error {
+		if separate {
+			if _, err := fmt.Fprintln(w, ""); err != nil {
+				return err
+			}
+			separate = false
+		}
+
+		_, err := fmt.Fprintf(w, "%s;\n", query)
+		return err
+	})
+}
+
+// dumpTriggers displays the CREATE TRIGGER statements of the triggers of the selected tables,
+// or of all triggers if no table is selected.
+// If separate is true, the triggers are separated from what precedes them by a blank line.
+func dumpTriggers(tx *genji.Tx, w io.Writer, tables []string, separate bool) error {
+	return QueryTriggers(tx, tables, func(name, query string) error {
+		if separate {
+			if _, err := fmt.Fprintln(w, ""); err != nil {
+				return err
+			}
+			separate = false
+		}
+
+		_, err := fmt.Fprintf(w, "%s;\n", query)
+		return err
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -93,3 +94,152 @@ func TestDump(t *testing.T) {
 		})
 	}
 }
+
+func TestDumpViews(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test (a INTEGER);
+		INSERT INTO test (a) VALUES (1);
+		CREATE VIEW b AS SELECT a FROM test WHERE a > 0;
+		CREATE VIEW a AS SELECT a FROM b;
+	`)
+	assert.NoError(t, err)
+
+	// views are listed after the views they depend on
+	want := `BEGIN TRANSACTION;
+CREATE TABLE test (a INTEGER);
+INSERT INTO test VALUES {"a": 1};
+
+CREATE VIEW b AS SELECT a FROM test WHERE a > 0;
+CREATE VIEW a AS SELECT a FROM b;
+COMMIT;
+`
+
+	var got bytes.Buffer
+	err = Dump(db, &got)
+	assert.NoError(t, err)
+	require.Equal(t, want, got.String())
+
+	got.Reset()
+	err = DumpSchema(db, &got, "a")
+	assert.NoError(t, err)
+	require.Equal(t, "CREATE VIEW a AS SELECT a FROM b;\n", got.String())
+
+	// the dump can be restored
+	other, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer other.Close()
+
+	err = other.Exec(want)
+	assert.NoError(t, err)
+
+	d, err := other.QueryDocument("SELECT * FROM a")
+	assert.NoError(t, err)
+	var a int
+	err = document.Scan(d, &a)
+	assert.NoError(t, err)
+	require.Equal(t, 1, a)
+}
+
+func TestDumpForeignKeys(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE b (id INTEGER PRIMARY KEY, code TEXT UNIQUE);
+		CREATE TABLE a (id INTEGER REFERENCES b ON DELETE CASCADE);
+		INSERT INTO b (id, code) VALUES (1, "foo");
+		INSERT INTO a (id) VALUES (1);
+	`)
+	assert.NoError(t, err)
+
+	// tables are listed after the tables they reference
+	// and indexes created by constraints are not dumped
+	want := `BEGIN TRANSACTION;
+CREATE TABLE b (id INTEGER NOT NULL, code TEXT, CONSTRAINT b_pk PRIMARY KEY (id), CONSTRAINT b_code_unique UNIQUE (code));
+INSERT INTO b VALUES {"id": 1, "code": "foo"};
+
+CREATE TABLE a (id INTEGER, CONSTRAINT a_id_fkey FOREIGN KEY (id) REFERENCES b (id) ON DELETE CASCADE);
+INSERT INTO a VALUES {"id": 1};
+COMMIT;
+`
+
+	var got bytes.Buffer
+	err = Dump(db, &got)
+	assert.NoError(t, err)
+	require.Equal(t, want, got.String())
+
+	// the dump can be restored
+	other, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer other.Close()
+
+	err = other.Exec(want)
+	assert.NoError(t, err)
+
+	err = other.Exec("DELETE FROM b")
+	assert.NoError(t, err)
+
+	d, err := other.QueryDocument("SELECT COUNT(*) FROM a")
+	assert.NoError(t, err)
+	var count int
+	err = document.Scan(d, &count)
+	assert.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestDumpTriggers(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test (a INTEGER);
+		CREATE TABLE counter (n INTEGER);
+		INSERT INTO counter (n) VALUES (0);
+		CREATE TRIGGER t AFTER INSERT ON test UPDATE counter SET n = n + 1;
+		INSERT INTO test (a) VALUES (1);
+	`)
+	assert.NoError(t, err)
+
+	// triggers are listed after the data
+	want := `BEGIN TRANSACTION;
+CREATE TABLE counter (n INTEGER);
+INSERT INTO counter VALUES {"n": 1};
+
+CREATE TABLE test (a INTEGER);
+INSERT INTO test VALUES {"a": 1};
+
+CREATE TRIGGER t AFTER INSERT ON test FOR EACH ROW BEGIN UPDATE counter SET n = n + 1; END;
+COMMIT;
+`
+
+	var got bytes.Buffer
+	err = Dump(db, &got)
+	assert.NoError(t, err)
+	require.Equal(t, want, got.String())
+
+	got.Reset()
+	err = DumpSchema(db, &got, "counter")
+	assert.NoError(t, err)
+	require.Equal(t, "CREATE TABLE counter (n INTEGER);\n", got.String())
+
+	// the dump can be restored without firing the trigger
+	other, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer other.Close()
+
+	err = other.Exec(want)
+	assert.NoError(t, err)
+
+	d, err := other.QueryDocument("SELECT n FROM counter")
+	assert.NoError(t, err)
+	var n int
+	err = document.Scan(d, &n)
+	assert.NoError(t, err)
+	require.Equal(t, 1, n)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/genjidb/genji/types"
 )
 
+// QueryTables calls fn for every table, ordered so that tables are listed after
+// the tables their foreign keys reference. If tables is provided, only selected tables are returned.
 func QueryTables(tx *genji.Tx, tables []string, fn func(name, query string) error) error {
 	query := "SELECT name, sql FROM __genji_catalog WHERE type = 'table' AND name NOT LIKE '__genji_%'"
 	if len(tables) > 0 {
@@ -20,13 +22,160 @@ func QueryTables(tx *genji.Tx, tables []string, fn func(name, query string) erro
 	}
 	defer res.Close()
 
-	return res.Iterate(func(d types.Document) error {
+	var names []string
+	queries := make(map[string]string)
+	references := make(map[string][]string)
+	err = res.Iterate(func(d types.Document) error {
 		// Get table name.
 		var name, query string
 		if err := document.Scan(d, &name, &query); err != nil {
 			return err
 		}
 
+		q, err := parser.ParseQuery(query)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, name)
+		queries[name] = query
+		for _, tc := range q.Statements[0].(*statement.CreateTableStmt).Info.TableConstraints {
+			if tc.ForeignKey != nil {
+				references[name] = append(references[name], tc.ForeignKey.Table)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	visited := make(map[string]bool)
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		visited[name] = true
+
+		for _, r := range references[name] {
+			if _, ok := queries[r]; ok {
+				if err := visit(r); err != nil {
+					return err
+				}
+			}
+		}
+
+		return fn(name, queries[name])
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QueryViews calls fn for every view, ordered so that views are listed after
+// the views they depend on. If views is provided, only selected views are returned.
+func QueryViews(tx *genji.Tx, views []string, fn func(name, query string) error) error {
+	res, err := tx.Query("SELECT name, sql FROM __genji_catalog WHERE type = 'view'")
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	var names []string
+	queries := make(map[string]string)
+	relations := make(map[string][]string)
+	err = res.Iterate(func(d types.Document) error {
+		var name, query string
+		if err := document.Scan(d, &name, &query); err != nil {
+			return err
+		}
+
+		q, err := parser.ParseQuery(query)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, name)
+		queries[name] = query
+		relations[name] = q.Statements[0].(*statement.CreateViewStmt).Info.Query.Relations()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	selected := func(name string) bool {
+		if len(views) == 0 {
+			return true
+		}
+
+		for _, v := range views {
+			if v == name {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	visited := make(map[string]bool)
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		visited[name] = true
+
+		for _, r := range relations[name] {
+			if _, ok := queries[r]; ok {
+				if err := visit(r); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !selected(name) {
+			return nil
+		}
+
+		return fn(name, queries[name])
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QueryTriggers calls fn for every trigger. If tables is provided,
+// only the triggers of the selected tables are returned.
+func QueryTriggers(tx *genji.Tx, tables []string, fn func(name, query string) error) error {
+	query := "SELECT name, sql FROM __genji_catalog WHERE type = 'trigger'"
+	if len(tables) > 0 {
+		query += " AND owner.table_name IN ?"
+	}
+
+	res, err := tx.Query(query, tables)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	return res.Iterate(func(d types.Document) error {
+		var name, query string
+		if err := document.Scan(d, &name, &query); err != nil {
+			return err
+		}
+
 		return fn(name, query)
 	})
 }
@@ -47,7 +47,10 @@ func scanFuncDocString(s *scanner.Scanner) (string, error) {
 	if tok2 != scanner.EOF && tok2 == scanner.DOT {
 		// tok1 is a package because tok2 is a "."
 		tok3, _, lit3 := s.Scan()
-		if tok3 != scanner.IDENT {
+		// keywords are valid function names within a package
+		if tok3.IsKeyword() {
+			lit3 = strings.ToLower(tok3.String())
+		} else if tok3 != scanner.IDENT {
 			return "", ErrInvalid
 		}
 		return funcDocString(lit1, lit3)
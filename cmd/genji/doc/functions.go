@@ -3,19 +3,45 @@ package doc
 type functionDocs map[string]string
 
 var packageDocs = map[string]functionDocs{
-	"math": mathDocs,
-	"":     builtinDocs,
+	"math":    mathDocs,
+	"strings": stringsDocs,
+	"time":    timeDocs,
+	"":        builtinDocs,
 }
 
 var builtinDocs = functionDocs{
-	"pk":     "The pk() function returns the primary key for the current document",
-	"count":  "Returns a count of the number of times that arg1 is not NULL in a group. The count(*) function (with no arguments) returns the total number of rows in the group.",
-	"min":    "Returns the minimum value of the arg1 expression in a group.",
-	"max":    "Returns the maximum value of the arg1 expressein in a group.",
-	"sum":    "The sum function returns the sum of all values taken by the arg1 expression in a group.",
-	"avg":    "The avg function returns the average of all values taken by the arg1 expression in a group.",
-	"typeof": "The typeof function returns the type of arg1.",
-	"len":    "Then len function returns length of the arg1 expression if arg1 evals to string, array or document, either returns NULL.",
+	"pk":          "The pk() function returns the primary key for the current document",
+	"count":       "Returns a count of the number of times that arg1 is not NULL in a group. The count(*) function (with no arguments) returns the total number of rows in the group.",
+	"min":         "Returns the minimum value of the arg1 expression in a group.",
+	"max":         "Returns the maximum value of the arg1 expressein in a group.",
+	"sum":         "The sum function returns the sum of all values taken by the arg1 expression in a group.",
+	"avg":         "The avg function returns the average of all values taken by the arg1 expression in a group.",
+	"typeof":      "The typeof function returns the type of arg1.",
+	"len":         "Then len function returns length of the arg1 expression if arg1 evals to string, array or document, either returns NULL.",
+	"row_number":  "The row_number function returns the number of the current row within its partition, starting at 1. It requires an OVER clause.",
+	"rank":        "The rank function returns the rank of the current row within its partition, with gaps. It requires an OVER clause.",
+	"dense_rank":  "The dense_rank function returns the rank of the current row within its partition, without gaps. It requires an OVER clause.",
+	"lag":         "The lag function returns the value of arg1 evaluated on the row that is arg2 rows before the current one within the partition, or arg3 if there is none. arg2 defaults to 1 and arg3 to NULL. It requires an OVER clause.",
+	"lead":        "The lead function returns the value of arg1 evaluated on the row that is arg2 rows after the current one within the partition, or arg3 if there is none. arg2 defaults to 1 and arg3 to NULL. It requires an OVER clause.",
+	"first_value": "The first_value function returns the value of arg1 evaluated on the first row of the partition. It requires an OVER clause.",
+	"coalesce":    "The coalesce function returns the first of its arguments, starting from arg1, that is not NULL, or NULL if they are all NULL.",
+	"nullif":      "The nullif function returns NULL if arg1 is equal to arg2, otherwise it returns arg1.",
+	"ifnull":      "The ifnull function returns arg1 if it is not NULL, otherwise it returns arg2.",
+
+	"array_contains":  "The array_contains function returns true if the arg1 array contains a value equal to arg2.",
+	"array_append":    "The array_append function returns a copy of the arg1 array with arg2 added at the end.",
+	"array_remove":    "The array_remove function returns a copy of the arg1 array without the values equal to arg2.",
+	"array_slice":     "The array_slice function returns the values of the arg1 array from index arg2 included to index arg3 excluded. Negative indexes are relative to the end of the array.",
+	"array_concat":    "The array_concat function returns an array containing the values of the arg1 array followed by the values of the arg2 array.",
+	"array_distinct":  "The array_distinct function returns a copy of the arg1 array without duplicate values.",
+	"document_keys":   "The document_keys function returns an array containing the field names of the arg1 document.",
+	"document_values": "The document_values function returns an array containing the values of the fields of the arg1 document.",
+	"document_merge":  "The document_merge function returns a document containing the fields of the arg1 and arg2 documents. Fields of arg2 replace the fields of arg1 with the same name.",
+	"document_unset":  "The document_unset function returns a copy of the arg1 document without the arg2 field.",
+	"uuid":            "The uuid function returns a random version 4 UUID, as a 16-byte blob.",
+	"ulid":            "The ulid function returns a ULID, as a 16-byte blob. ULIDs start with the time of their creation, which makes them sortable.",
+	"uuid_text":       "The uuid_text function returns the canonical text representation of the arg1 16-byte blob, e.g. f81d4fae-7dec-11d0-a765-00a0c91e6bf6.",
+	"ulid_text":       "The ulid_text function returns the base32 text representation of the arg1 16-byte blob, e.g. 01ARZ3NDEKTSV4RRFFQ69G5FAV.",
 }
 
 var mathDocs = functionDocs{
@@ -28,3 +54,33 @@ var mathDocs = functionDocs{
 	"atan2": "Returns the arctangent of arg1/arg2, using the signs of the two to determine the quadrant of the return value.",
 	"floor": "Returns the greatest integer value less than or equal to arg1.",
 }
+
+var stringsDocs = functionDocs{
+	"lower":          "Returns arg1 converted to lower case.",
+	"upper":          "Returns arg1 converted to upper case.",
+	"trim":           "Returns arg1 without the leading and trailing characters contained in arg2, or whitespace if arg2 is omitted.",
+	"ltrim":          "Returns arg1 without the leading characters contained in arg2, or whitespace if arg2 is omitted.",
+	"rtrim":          "Returns arg1 without the trailing characters contained in arg2, or whitespace if arg2 is omitted.",
+	"substr":         "Returns the substring of arg1 starting at position arg2, with a length of arg3 characters, or up to the end of arg1 if arg3 is omitted. Positions start at 1.",
+	"replace":        "Returns arg1 with all the occurrences of arg2 replaced by arg3.",
+	"split":          "Returns an array containing the substrings of arg1 separated by arg2.",
+	"starts_with":    "Returns true if arg1 starts with arg2.",
+	"ends_with":      "Returns true if arg1 ends with arg2.",
+	"position":       "Returns the position of the first occurrence of arg2 in arg1, starting at 1, or 0 if arg1 doesn't contain arg2.",
+	"lpad":           "Returns arg1 padded on the left up to arg2 characters with arg3, or spaces if arg3 is omitted. arg1 is truncated if it is longer than arg2.",
+	"rpad":           "Returns arg1 padded on the right up to arg2 characters with arg3, or spaces if arg3 is omitted. arg1 is truncated if it is longer than arg2.",
+	"repeat":         "Returns arg1 repeated arg2 times.",
+	"reverse":        "Returns arg1 with its characters in reverse order.",
+	"format":         "Returns arg1 with every %s replaced by the next argument and %% replaced by %.",
+	"regexp_replace": "Returns arg1 with all the matches of the regular expression arg2 replaced by arg3. arg3 can reference capture groups using $1, $2, etc.",
+	"regexp_match":   "Returns true if arg1 matches the regular expression arg2.",
+}
+
+var timeDocs = functionDocs{
+	"now":        "Returns the current timestamp.",
+	"date_trunc": "Returns arg2 truncated to the unit arg1: microsecond, millisecond, second, minute, hour, day, week, month, quarter or year.",
+	"extract":    "Returns the field arg1 of the timestamp arg2 as an integer: microsecond, millisecond, second, minute, hour, day, dow, doy, week, month, quarter, year or epoch.",
+	"add":        "Returns the timestamp arg1 plus the interval arg2. The interval is either a duration such as '1h30m' or a list of quantities and units such as '1 day 2 hours'.",
+	"sub":        "Returns the timestamp arg1 minus the interval arg2. The interval is either a duration such as '1h30m' or a list of quantities and units such as '1 day 2 hours'.",
+	"diff":       "Returns the number of seconds elapsed between the timestamps arg2 and arg1.",
+}
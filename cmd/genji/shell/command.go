@@ -77,7 +77,7 @@ var commands = []command{
 		Name:        ".schema",
 		Options:     "[table_name]",
 		DisplayName: ".schema",
-		Description: "Show the CREATE statements of all tables or of the selected ones.",
+		Description: "Show the CREATE statements of all tables, views and triggers or of the selected ones.",
 	},
 	{
 		Name:        ".import",
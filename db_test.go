@@ -88,9 +88,12 @@ func TestOpen(t *testing.T) {
 		CREATE TABLE tableC (a INTEGER, b BOOL);
 		CREATE INDEX tableC_a_b_idx ON tableC(a, b);
 		CREATE SEQUENCE seqD INCREMENT BY 10 CYCLE MINVALUE 100 NO MAXVALUE START 500;
+		CREATE VIEW viewE AS SELECT a FROM tableC WHERE a > 0;
 
 		INSERT INTO tableB (a) VALUES (1);
 		INSERT INTO tableC (a, b) VALUES (1, NEXT VALUE FOR seqD);
+
+		CREATE TRIGGER triggerF AFTER INSERT ON tableB INSERT INTO tableA (a, b) VALUES (10, {c: {d: 1.5}});
 	`)
 	assert.NoError(t, err)
 
@@ -118,6 +121,8 @@ func TestOpen(t *testing.T) {
 		`{"name":"tableC", "docid_sequence_name":"tableC_seq", "sql":"CREATE TABLE tableC (a INTEGER, b BOOLEAN)", "namespace":13, "type":"table"}`,
 		`{"name":"tableC_a_b_idx", "owner":{"table_name":"tableC"}, "sql":"CREATE INDEX tableC_a_b_idx ON tableC (a, b)", "namespace":14, "type":"index"}`,
 		`{"name":"tableC_seq", "owner":{"table_name":"tableC"}, "sql":"CREATE SEQUENCE tableC_seq CACHE 64", "type":"sequence"}`,
+		`{"name":"triggerF", "owner":{"table_name":"tableB"}, "sql":"CREATE TRIGGER triggerF AFTER INSERT ON tableB FOR EACH ROW BEGIN INSERT INTO tableA (a, b) VALUES (10, {c: {d: 1.5}}); END", "type":"trigger"}`,
+		`{"name":"viewE", "sql":"CREATE VIEW viewE AS SELECT a FROM tableC WHERE a > 0", "type":"view"}`,
 	}
 	err = res1.Iterate(func(d types.Document) error {
 		count++
@@ -134,6 +139,16 @@ func TestOpen(t *testing.T) {
 	assert.NoError(t, err)
 	testutil.RequireDocJSONEq(t, d, `{"a": "1"}`)
 
+	d, err = db.QueryDocument("SELECT * FROM viewE")
+	assert.NoError(t, err)
+	testutil.RequireDocJSONEq(t, d, `{"a": 1}`)
+
+	err = db.Exec("INSERT INTO tableB (a) VALUES (2)")
+	assert.NoError(t, err)
+	d, err = db.QueryDocument("SELECT a FROM tableA")
+	assert.NoError(t, err)
+	testutil.RequireDocJSONEq(t, d, `{"a": 10}`)
+
 	d, err = db.QueryDocument("SELECT * FROM __genji_sequence")
 	assert.NoError(t, err)
 	testutil.RequireDocJSONEq(t, d, `{"name":"__genji_store_seq", "seq":14}`)
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/genjidb/genji/types"
 )
@@ -21,6 +22,10 @@ func CastAs(v types.Value, t types.ValueType) (types.Value, error) {
 		return CastAsInteger(v)
 	case types.DoubleValue:
 		return CastAsDouble(v)
+	case types.DecimalValue:
+		return CastAsDecimal(v)
+	case types.TimestampValue:
+		return CastAsTimestamp(v)
 	case types.BlobValue:
 		return CastAsBlob(v)
 	case types.TextValue:
@@ -64,6 +69,7 @@ func CastAsBool(v types.Value) (types.Value, error) {
 // CastAsInteger casts according to the following rules:
 // Bool: returns 1 if true, 0 if false.
 // Double: cuts off the decimal and remaining numbers.
+// Decimal: cuts off the decimal and remaining numbers.
 // Text: uses strconv.ParseInt to determine the integer value,
 // then casts it to an integer. If it fails uses strconv.ParseFloat
 // to determine the double value, then casts it to an integer
@@ -89,6 +95,12 @@ func CastAsInteger(v types.Value) (types.Value, error) {
 			return nil, fmt.Errorf("integer out of range")
 		}
 		return types.NewIntegerValue(int64(f)), nil
+	case types.DecimalValue:
+		i, ok := types.As[types.Decimal](v).Int64()
+		if !ok {
+			return nil, fmt.Errorf("integer out of range")
+		}
+		return types.NewIntegerValue(i), nil
 	case types.TextValue:
 		i, err := strconv.ParseInt(types.As[string](v), 10, 64)
 		if err != nil {
@@ -107,6 +119,7 @@ func CastAsInteger(v types.Value) (types.Value, error) {
 
 // CastAsDouble casts according to the following rules:
 // Integer: returns a double version of the integer.
+// Decimal: returns the nearest double.
 // Text: uses strconv.ParseFloat to determine the double value,
 // it fails if the text doesn't contain a valid float value.
 // Any other type is considered an invalid cast.
@@ -121,6 +134,8 @@ func CastAsDouble(v types.Value) (types.Value, error) {
 		return v, nil
 	case types.IntegerValue:
 		return types.NewDoubleValue(float64(types.As[int64](v))), nil
+	case types.DecimalValue:
+		return types.NewDoubleValue(types.As[types.Decimal](v).Float64()), nil
 	case types.TextValue:
 		f, err := strconv.ParseFloat(types.As[string](v), 64)
 		if err != nil {
@@ -132,6 +147,118 @@ func CastAsDouble(v types.Value) (types.Value, error) {
 	return nil, fmt.Errorf("cannot cast %s as double", v.Type())
 }
 
+// CastAsDecimal casts according to the following rules:
+// Integer: returns an exact decimal version of the integer.
+// Double: returns the shortest decimal representation of the double,
+// it fails if the double is NaN or infinite.
+// Text: uses types.ParseDecimal to determine the decimal value,
+// it fails if the text doesn't contain a valid decimal value.
+// Any other type is considered an invalid cast.
+func CastAsDecimal(v types.Value) (types.Value, error) {
+	// Null values always remain null.
+	if v.Type() == types.NullValue {
+		return v, nil
+	}
+
+	switch v.Type() {
+	case types.DecimalValue:
+		return v, nil
+	case types.IntegerValue:
+		return types.NewDecimalValue(types.NewDecimalFromInt(types.As[int64](v))), nil
+	case types.DoubleValue:
+		d, err := types.NewDecimalFromFloat(types.As[float64](v))
+		if err != nil {
+			return nil, err
+		}
+		return types.NewDecimalValue(d), nil
+	case types.TextValue:
+		d, err := types.ParseDecimal(types.As[string](v))
+		if err != nil {
+			return nil, fmt.Errorf(`cannot cast %q as decimal: %w`, v.V(), err)
+		}
+		return types.NewDecimalValue(d), nil
+	}
+
+	return nil, fmt.Errorf("cannot cast %s as decimal", v.Type())
+}
+
+// timestampLayouts lists the text formats accepted when casting a text as a timestamp.
+// Texts without time zone are considered to be in UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// CastAsTimestamp casts according to the following rules:
+// Text: parses an RFC 3339 date-time, or a date-time in the
+// "YYYY-MM-DD[ HH:MM:SS[.fraction]]" format, otherwise fails.
+// Any other type is considered an invalid cast.
+func CastAsTimestamp(v types.Value) (types.Value, error) {
+	// Null values always remain null.
+	if v.Type() == types.NullValue {
+		return v, nil
+	}
+
+	switch v.Type() {
+	case types.TimestampValue:
+		return v, nil
+	case types.TextValue:
+		t, err := ParseTimestamp(types.As[string](v))
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTimestampValue(t), nil
+	}
+
+	return nil, fmt.Errorf("cannot cast %s as timestamp", v.Type())
+}
+
+// CastAsDate casts v as a timestamp truncated to midnight UTC.
+// The date of texts is the one they represent, regardless of their time zone.
+func CastAsDate(v types.Value) (types.Value, error) {
+	// Null values always remain null.
+	if v.Type() == types.NullValue {
+		return v, nil
+	}
+
+	var t time.Time
+	switch v.Type() {
+	case types.TimestampValue:
+		t = types.As[time.Time](v)
+	case types.TextValue:
+		var err error
+		t, err = ParseTimestamp(types.As[string](v))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cannot cast %s as date", v.Type())
+	}
+
+	return types.NewTimestampValue(TruncateToDate(t)), nil
+}
+
+// TruncateToDate returns midnight UTC of the date of t.
+func TruncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ParseTimestamp parses s using one of the formats accepted
+// when casting a text as a timestamp.
+func ParseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf(`cannot cast %q as timestamp`, s)
+}
+
 // CastAsText returns a JSON representation of v.
 // If the representation is a string, it gets unquoted.
 func CastAsText(v types.Value) (types.Value, error) {
@@ -143,6 +270,8 @@ func CastAsText(v types.Value) (types.Value, error) {
 	switch v.Type() {
 	case types.TextValue:
 		return v, nil
+	case types.TimestampValue:
+		return types.NewTextValue(types.As[time.Time](v).Format(time.RFC3339Nano)), nil
 	case types.BlobValue:
 		return types.NewTextValue(base64.StdEncoding.EncodeToString(types.As[[]byte](v))), nil
 	}
@@ -158,7 +287,7 @@ func CastAsText(v types.Value) (types.Value, error) {
 }
 
 // CastAsBlob casts according to the following rules:
-// Text: decodes a base64 string, otherwise fails.
+// Text: decodes a UUID, a ULID or a base64 string, otherwise fails.
 // Any other type is considered an invalid cast.
 func CastAsBlob(v types.Value) (types.Value, error) {
 	// Null values always remain null.
@@ -171,8 +300,12 @@ func CastAsBlob(v types.Value) (types.Value, error) {
 	}
 
 	if v.Type() == types.TextValue {
-		// if the string starts with \x, read it as hex
 		s := types.As[string](v)
+		if b, ok := ParseUUID(s); ok {
+			return types.NewBlobValue(b), nil
+		}
+
+		// if the string starts with \x, read it as hex
 		b, err := base64.StdEncoding.DecodeString(s)
 		if err != nil {
 			return nil, err
@@ -20,6 +20,7 @@ func TestCastAs(t *testing.T) {
 	doubleV := types.NewDoubleValue(10.5)
 	textV := types.NewTextValue("foo")
 	blobV := types.NewBlobValue([]byte("asdine"))
+	uuidV := types.NewBlobValue([]byte{0x01, 0x56, 0x3e, 0x3a, 0xb5, 0xd3, 0xd6, 0x76, 0x4c, 0x61, 0xef, 0xb9, 0x93, 0x02, 0xbd, 0x5b})
 	arrayV := types.NewArrayValue(NewValueBuffer().
 		Append(types.NewTextValue("bar")).
 		Append(integerV))
@@ -97,6 +98,7 @@ func TestCastAs(t *testing.T) {
 			{doubleV, types.NewTextValue("10.5"), false},
 			{textV, textV, false},
 			{blobV, types.NewTextValue(`YXNkaW5l`), false},
+			{uuidV, types.NewTextValue(`AVY+OrXT1nZMYe+5kwK9Ww==`), false},
 			{arrayV, types.NewTextValue(`["bar", 10]`), false},
 			{docV,
 				types.NewTextValue(`{"a": 10, "b": "foo"}`),
@@ -111,6 +113,12 @@ func TestCastAs(t *testing.T) {
 			{doubleV, nil, true},
 			{types.NewTextValue("YXNkaW5l"), types.NewBlobValue([]byte{0x61, 0x73, 0x64, 0x69, 0x6e, 0x65}), false},
 			{types.NewTextValue("not base64"), nil, true},
+			{types.NewTextValue("01563e3a-b5d3-d676-4c61-efb99302bd5b"), uuidV, false},
+			{types.NewTextValue("01563E3A-B5D3-D676-4C61-EFB99302BD5B"), uuidV, false},
+			{types.NewTextValue("01ARZ3NDEKTSV4RRFFQ69G5FAV"), uuidV, false},
+			{types.NewTextValue("01arz3ndektsv4rrffq69g5fav"), uuidV, false},
+			{types.NewTextValue("01563e3a-b5d3-d676-4c61-efb99302bd5z"), nil, true},
+			{types.NewTextValue("81ARZ3NDEKTSV4RRFFQ69G5FAV"), nil, true},
 			{blobV, blobV, false},
 			{arrayV, nil, true},
 			{docV, nil, true},
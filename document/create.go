@@ -3,6 +3,7 @@ package document
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"strings"
 	"time"
@@ -200,13 +201,25 @@ func newFromStruct(ref reflect.Value) (types.Document, error) {
 }
 
 // NewValue creates a value whose type is infered from x.
+// time.Time values are converted to timestamps, which are stored in UTC
+// with a microsecond precision: nanoseconds are truncated.
+// Earlier versions stored time.Time values as RFC 3339 texts: such texts remain
+// equal to the timestamps they represent, since texts are converted when compared
+// to timestamps.
 func NewValue(x interface{}) (types.Value, error) {
 	// Attempt exact matches first:
 	switch v := x.(type) {
 	case time.Duration:
 		return types.NewIntegerValue(v.Nanoseconds()), nil
 	case time.Time:
-		return types.NewTextValue(v.Format(time.RFC3339Nano)), nil
+		return types.NewTimestampValue(v), nil
+	case types.Decimal:
+		return types.NewDecimalValue(v), nil
+	case *big.Int:
+		if v == nil {
+			return types.NewNullValue(), nil
+		}
+		return types.NewDecimalValue(types.NewDecimal(v, 0)), nil
 	case nil:
 		return types.NewNullValue(), nil
 	case types.Document:
@@ -249,6 +262,12 @@ func NewValue(x interface{}) (types.Value, error) {
 		}
 		return types.NewDocumentValue(doc), nil
 	case reflect.Array:
+		// UUIDs are stored as blobs
+		if v.Type().Elem().Kind() == reflect.Uint8 && v.Len() == uuidLen {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return types.NewBlobValue(b), nil
+		}
 		return types.NewArrayValue(&sliceArray{v}), nil
 	case reflect.Slice:
 		if reflect.TypeOf(v.Interface()).Elem().Kind() == reflect.Uint8 {
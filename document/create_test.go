@@ -34,7 +34,7 @@ func TestNewValue(t *testing.T) {
 		"b": 2,
 	}
 
-	now := time.Now()
+	now := time.Now().UTC().Truncate(time.Microsecond)
 
 	tests := []struct {
 		name            string
@@ -57,7 +57,9 @@ func TestNewValue(t *testing.T) {
 		{"null", nil, nil},
 		{"document", document.NewFieldBuffer().Add("a", types.NewIntegerValue(10)), document.NewFieldBuffer().Add("a", types.NewIntegerValue(10))},
 		{"array", document.NewValueBuffer(types.NewIntegerValue(10)), document.NewValueBuffer(types.NewIntegerValue(10))},
-		{"time", now, now.Format(time.RFC3339Nano)},
+		{"time", now, now},
+		{"time/nanoseconds", time.Date(2020, 11, 15, 16, 37, 10, 20, time.UTC), time.Date(2020, 11, 15, 16, 37, 10, 0, time.UTC)},
+		{"time/location", time.Date(2020, 11, 15, 16, 37, 10, 0, time.FixedZone("", 3600)), time.Date(2020, 11, 15, 15, 37, 10, 0, time.UTC)},
 		{"bytes", myBytes("bar"), []byte("bar")},
 		{"string", myString("bar"), "bar"},
 		{"myUint", myUint(10), int64(10)},
@@ -426,7 +426,7 @@ func TestNewFromStruct(t *testing.T) {
 			case 25:
 				require.EqualValues(t, types.IntegerValue, v.Type())
 			case 26:
-				require.EqualValues(t, types.TextValue, v.Type())
+				require.EqualValues(t, types.TimestampValue, v.Type())
 			default:
 				require.FailNowf(t, "", "unknown field %q", f)
 			}
@@ -522,7 +522,8 @@ func TestNewFromStruct(t *testing.T) {
 		assert.NoError(t, document.ScanValue(v, &timeStr))
 		parsedTime, err := time.Parse(time.RFC3339Nano, timeStr)
 		assert.NoError(t, err)
-		require.Equal(t, u.BB, parsedTime)
+		// timestamps are truncated to the microsecond
+		require.Equal(t, u.BB.Truncate(time.Microsecond), parsedTime)
 	})
 
 	t.Run("pointers", func(t *testing.T) {
@@ -35,6 +35,10 @@ type PathFragment struct {
 	ArrayIndex int
 }
 
+// AnyIndex is the array index of a fragment referring to every
+// element of an array, represented as [*].
+const AnyIndex = -1
+
 // String representation of all the fragments of the path.
 // It implements the Stringer interface.
 func (p Path) String() string {
@@ -46,6 +50,8 @@ func (p Path) String() string {
 				b.WriteRune('.')
 			}
 			b.WriteString(p[i].FieldName)
+		} else if p[i].ArrayIndex == AnyIndex {
+			b.WriteString("[*]")
 		} else {
 			b.WriteString("[" + strconv.Itoa(p[i].ArrayIndex) + "]")
 		}
@@ -114,6 +120,30 @@ func (p Path) GetValueFromArray(a types.Array) (types.Value, error) {
 	return p[1:].getValueFromValue(v)
 }
 
+// HasPrefix returns whether p starts with the fragments of prefix.
+func (p Path) HasPrefix(prefix Path) bool {
+	if len(prefix) > len(p) {
+		return false
+	}
+
+	return p[:len(prefix)].IsEqual(prefix)
+}
+
+// ElementsOf returns the path of the array whose elements are referred to
+// by p, if the last fragment of p is [*].
+func (p Path) ElementsOf() (Path, bool) {
+	if len(p) == 0 {
+		return nil, false
+	}
+
+	last := p[len(p)-1]
+	if last.FieldName != "" || last.ArrayIndex != AnyIndex {
+		return nil, false
+	}
+
+	return p[:len(p)-1], true
+}
+
 func (p Path) Clone() Path {
 	c := make(Path, len(p))
 	copy(c, p)
@@ -47,3 +47,28 @@ func TestPath(t *testing.T) {
 		})
 	}
 }
+
+func TestPathHasPrefix(t *testing.T) {
+	tests := []struct {
+		path, prefix string
+		want         bool
+	}{
+		{"a", "a", true},
+		{"a.b", "a", true},
+		{"a.b[0]", "a.b", true},
+		{"a", "a.b", false},
+		{"ab", "a", false},
+		{"a.b", "b", false},
+		{"a[0]", "a[1]", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path+"/"+test.prefix, func(t *testing.T) {
+			p, err := parser.ParsePath(test.path)
+			assert.NoError(t, err)
+			prefix, err := parser.ParsePath(test.prefix)
+			assert.NoError(t, err)
+			require.Equal(t, test.want, p.HasPrefix(prefix))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package document
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 	"strings"
 	"time"
@@ -336,8 +337,14 @@ func scanValue(v types.Value, ref reflect.Value) error {
 
 	// test with supported stdlib types
 	switch ref.Type().String() {
+	case "types.Decimal", "big.Rat", "big.Float", "big.Int":
+		return scanDecimal(v, ref)
 	case "time.Time":
-		if v.Type() == types.TextValue {
+		switch v.Type() {
+		case types.TimestampValue:
+			ref.Set(reflect.ValueOf(types.As[time.Time](v)))
+			return nil
+		case types.TextValue:
 			parsed, err := time.Parse(time.RFC3339Nano, types.As[string](v))
 			if err != nil {
 				return err
@@ -379,6 +386,13 @@ func scanValue(v types.Value, ref reflect.Value) error {
 			if v.Type() != types.TextValue && v.Type() != types.BlobValue {
 				return fmt.Errorf("cannot scan value of type %s to byte slice", v.Type())
 			}
+			// texts representing UUIDs are scanned in their binary form
+			if v.Type() == types.TextValue && ref.Len() == uuidLen {
+				if b, ok := ParseUUID(types.As[string](v)); ok {
+					reflect.Copy(ref, reflect.ValueOf(b))
+					return nil
+				}
+			}
 			reflect.Copy(ref, reflect.ValueOf(v.V()))
 			return nil
 		}
@@ -400,6 +414,31 @@ func scanValue(v types.Value, ref reflect.Value) error {
 	return &ErrUnsupportedType{ref, "Invalid type"}
 }
 
+// scanDecimal scans v into a types.Decimal, a big.Rat, a big.Float or a big.Int.
+// Conversions to big.Int truncate the fractional part of the decimal.
+func scanDecimal(v types.Value, ref reflect.Value) error {
+	v, err := CastAsDecimal(v)
+	if err != nil {
+		return err
+	}
+	d := types.As[types.Decimal](v)
+
+	switch x := ref.Addr().Interface().(type) {
+	case *types.Decimal:
+		*x = d
+	case *big.Rat:
+		x.Set(d.Rat())
+	case *big.Float:
+		x.SetRat(d.Rat())
+	case *big.Int:
+		x.Set(d.Truncate(0).Unscaled())
+	default:
+		return &ErrUnsupportedType{ref, "Invalid type"}
+	}
+
+	return nil
+}
+
 // ScanDocument scans a document into dest which must be either a struct pointer, a map or a map pointer.
 func ScanDocument(d types.Document, t interface{}) error {
 	ref := reflect.ValueOf(t)
@@ -1,6 +1,7 @@
 package document_test
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
@@ -292,6 +293,63 @@ func TestScan(t *testing.T) {
 		assert.NoError(t, err)
 		require.Equal(t, &foo{A: &bar{B: 10}}, &f)
 	})
+
+	t.Run("UUIDs", func(t *testing.T) {
+		type bar struct {
+			A [16]byte
+			B string
+			C [16]byte
+		}
+
+		id := []byte{0x01, 0x56, 0x3e, 0x3a, 0xb5, 0xd3, 0xd6, 0x76, 0x4c, 0x61, 0xef, 0xb9, 0x93, 0x02, 0xbd, 0x5b}
+		d := document.NewFieldBuffer().
+			Add("a", types.NewBlobValue(id)).
+			Add("b", types.NewTextValue("01563e3a-b5d3-d676-4c61-efb99302bd5b")).
+			Add("c", types.NewTextValue("01563e3a-b5d3-d676-4c61-efb99302bd5b"))
+
+		var b bar
+		err := document.StructScan(d, &b)
+		assert.NoError(t, err)
+		require.Equal(t, id, b.A[:])
+		require.Equal(t, "01563e3a-b5d3-d676-4c61-efb99302bd5b", b.B)
+		require.Equal(t, id, b.C[:])
+
+		// UUIDs are stored as blobs
+		v, err := document.NewValue(b.A)
+		assert.NoError(t, err)
+		require.Equal(t, types.NewBlobValue(id), v)
+
+		// other byte arrays remain arrays
+		v, err = document.NewValue([2]byte{1, 2})
+		assert.NoError(t, err)
+		require.Equal(t, types.ArrayValue, v.Type())
+	})
+
+	t.Run("Decimals", func(t *testing.T) {
+		type bar struct {
+			A types.Decimal
+			B *big.Rat
+			C big.Int
+			D types.Decimal
+		}
+
+		x, err := types.ParseDecimal("12.50")
+		assert.NoError(t, err)
+
+		d := document.NewFieldBuffer().
+			Add("a", types.NewDecimalValue(x)).
+			Add("b", types.NewDecimalValue(x)).
+			Add("c", types.NewDecimalValue(x)).
+			Add("d", types.NewTextValue("-0.5"))
+
+		var b bar
+		err = document.StructScan(d, &b)
+		assert.NoError(t, err)
+		require.Equal(t, "12.50", b.A.String())
+		require.Equal(t, "25/2", b.B.String())
+		require.Equal(t, "12", b.C.String())
+		require.Equal(t, "-0.5", b.D.String())
+	})
 }
 
 type documentScanner struct {
@@ -0,0 +1,115 @@
+package document
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// UUIDs and ULIDs are stored as 16-byte blobs.
+const uuidLen = 16
+
+// crockford is the base32 alphabet used to represent ULIDs as text.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// FormatUUID returns the canonical text representation of a 16-byte blob:
+// 32 hexadecimal digits displayed in five groups separated by hyphens,
+// e.g. "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func FormatUUID(b []byte) string {
+	var buf [36]byte
+
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:16])
+
+	return string(buf[:])
+}
+
+// FormatULID returns the text representation of a 16-byte blob as a ULID:
+// 26 characters using Crockford's base32 alphabet,
+// e.g. "01ARZ3NDEKTSV4RRFFQ69G5FAV".
+func FormatULID(b []byte) string {
+	var n [uuidLen]byte
+	copy(n[:], b)
+
+	var buf [26]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockford[n[uuidLen-1]&0x1f]
+
+		// shift the 128-bit number 5 bits to the right
+		var carry byte
+		for j := 0; j < uuidLen; j++ {
+			x := n[j]
+			n[j] = x>>5 | carry
+			carry = x << 3
+		}
+	}
+
+	return string(buf[:])
+}
+
+// ParseUUID parses a UUID in its canonical text representation,
+// or a ULID in its 26 characters base32 representation,
+// and returns its 16-byte binary form.
+func ParseUUID(s string) ([]byte, bool) {
+	switch len(s) {
+	case 36:
+		return parseCanonicalUUID(s)
+	case 26:
+		return parseULID(s)
+	}
+
+	return nil, false
+}
+
+func parseCanonicalUUID(s string) ([]byte, bool) {
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return nil, false
+	}
+
+	b, err := hex.DecodeString(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:])
+	if err != nil {
+		return nil, false
+	}
+
+	return b, true
+}
+
+// parseULID decodes the 130 bits of the 26 base32 characters of a ULID.
+// Since a ULID is 128 bits long, the first character must not exceed '7'.
+func parseULID(s string) ([]byte, bool) {
+	if s[0] > '7' {
+		return nil, false
+	}
+
+	b := make([]byte, uuidLen)
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(crockford, upper(s[i]))
+		if v < 0 {
+			return nil, false
+		}
+
+		// shift the 128-bit number 5 bits to the left and add the new digit
+		carry := byte(v)
+		for j := uuidLen - 1; j >= 0; j-- {
+			x := uint16(b[j])<<5 | uint16(carry)
+			b[j] = byte(x)
+			carry = byte(x >> 8)
+		}
+	}
+
+	return b, true
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+
+	return c
+}
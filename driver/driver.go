@@ -356,6 +356,13 @@ func (rs *documentStream) Next(dest []driver.Value) error {
 			return err
 		}
 
+		// decimals are not valid driver values,
+		// they are returned as strings to preserve their precision
+		if f.Type() == types.DecimalValue {
+			dest[i] = types.As[types.Decimal](f).String()
+			continue
+		}
+
 		dest[i] = f.V()
 	}
 
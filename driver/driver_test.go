@@ -269,7 +269,7 @@ func TestDriverWithTimeValues(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	now := time.Now().UTC()
+	now := time.Now().UTC().Truncate(time.Microsecond)
 	_, err = db.Exec("CREATE TABLE test; INSERT INTO test (a) VALUES (?)", now)
 	assert.NoError(t, err)
 
@@ -281,4 +281,30 @@ func TestDriverWithTimeValues(t *testing.T) {
 	err = tx.QueryRow(`SELECT a FROM test`).Scan(Scanner(&tt))
 	require.NoError(t, err)
 	require.Equal(t, now, tt)
+
+	var typ string
+	err = tx.QueryRow(`SELECT typeof(a) FROM test`).Scan(&typ)
+	require.NoError(t, err)
+	require.Equal(t, "timestamp", typ)
+}
+
+func TestDriverWithTimeValuesStoredAsText(t *testing.T) {
+	db, err := sql.Open("genji", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// time values used to be stored as RFC 3339 texts,
+	// they must still be comparable to time parameters
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	_, err = db.Exec("CREATE TABLE test; INSERT INTO test (a) VALUES (?)", now.Format(time.RFC3339Nano))
+	assert.NoError(t, err)
+
+	var n int
+	err = db.QueryRow(`SELECT COUNT(*) FROM test WHERE a = ?`, now).Scan(&n)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	err = db.QueryRow(`SELECT COUNT(*) FROM test WHERE a < ?`, now).Scan(&n)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
 }
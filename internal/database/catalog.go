@@ -27,6 +27,8 @@ const (
 	RelationTableType    = "table"
 	RelationIndexType    = "index"
 	RelationSequenceType = "sequence"
+	RelationViewType     = "view"
+	RelationTriggerType  = "trigger"
 )
 
 // System sequences
@@ -43,7 +45,7 @@ const (
 	MaxTransientNamespace    tree.Namespace = math.MaxInt64
 )
 
-// Catalog manages all database objects such as tables, indexes and sequences.
+// Catalog manages all database objects such as tables, indexes, sequences, views and triggers.
 // It stores all these objects in memory for fast access. Any modification
 // is persisted into the __genji_catalog table.
 type Catalog struct {
@@ -198,6 +200,11 @@ func (c *Catalog) CreateTable(tx *Transaction, tableName string, info *TableInfo
 		return errors.WithStack(errs.AlreadyExistsError{Name: tableName})
 	}
 
+	err = c.checkForeignKeys(info)
+	if err != nil {
+		return err
+	}
+
 	if info.StoreNamespace == 0 {
 		info.StoreNamespace, err = c.generateStoreName(tx)
 		if err != nil {
@@ -241,6 +248,16 @@ func (c *Catalog) DropTable(tx *Transaction, tableName string) error {
 		return errors.New("cannot write to read-only table")
 	}
 
+	err = c.checkNoDependentViews("drop table", tableName)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkNoReferencingTables("drop table", tableName)
+	if err != nil {
+		return err
+	}
+
 	for _, idx := range c.Cache.GetTableIndexes(tableName) {
 		_, err = c.Cache.Delete(tx, RelationIndexType, idx.IndexName)
 		if err != nil {
@@ -253,6 +270,13 @@ func (c *Catalog) DropTable(tx *Transaction, tableName string) error {
 		}
 	}
 
+	for _, trg := range c.Cache.GetTableTriggers(tableName) {
+		err = c.dropTrigger(tx, trg.TriggerName)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = c.Cache.Delete(tx, RelationTableType, tableName)
 	if err != nil {
 		return err
@@ -281,11 +305,24 @@ func (c *Catalog) CreateIndex(tx *Transaction, info *IndexInfo) error {
 	}
 
 	// check if the indexed fields exist
-	for _, p := range info.Paths {
+	for i, p := range info.Paths {
+		if info.ExprAt(i) != nil {
+			continue
+		}
+
+		// multikey indexes require the path to refer to an array
+		ap, multikey := p.ElementsOf()
+		if multikey {
+			p = ap
+		}
+
 		fc := ti.GetFieldConstraintForPath(p)
 		if fc == nil {
 			return errors.Errorf("field %q does not exist for table %q", p, ti.TableName)
 		}
+		if multikey && !fc.Type.IsAny() && fc.Type != types.ArrayValue {
+			return errors.Errorf("cannot index the elements of field %q of type %s", p, fc.Type)
+		}
 	}
 
 	info.StoreNamespace, err = c.generateStoreName(tx)
@@ -293,6 +330,15 @@ func (c *Catalog) CreateIndex(tx *Transaction, info *IndexInfo) error {
 		return err
 	}
 
+	for _, e := range info.Exprs {
+		if e != nil {
+			e.Bind(c)
+		}
+	}
+	if info.Predicate != nil {
+		info.Predicate.Bind(c)
+	}
+
 	rel := IndexInfoRelation{Info: info}
 	err = c.Cache.Add(tx, &rel)
 	if err != nil {
@@ -396,20 +442,599 @@ func (c *Catalog) AddFieldConstraint(tx *Transaction, tableName string, fc *Fiel
 		}
 	}
 
-	for _, tc := range tcs {
-		err = clone.AddTableConstraint(tc)
-		if err != nil {
-			return err
+	for _, tc := range tcs {
+		err = clone.AddTableConstraint(tc)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.checkForeignKeys(clone)
+	if err != nil {
+		return err
+	}
+
+	t, err := c.GetTable(tx, tableName)
+	if err != nil {
+		return err
+	}
+
+	// documents are encoded with the constrained fields first, in order,
+	// followed by the extra fields. Existing documents must be encoded again,
+	// otherwise their extra fields would be decoded as the new field
+	if fc != nil {
+		err = c.rewriteDocuments(t, clone, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	// existing documents must satisfy the new constraints
+	t.Info = clone
+	err = c.validateDocuments(t, tcs)
+	if err != nil {
+		return err
+	}
+
+	cloneRel := &TableInfoRelation{Info: clone}
+	err = c.Cache.Replace(tx, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Replace(tx, tableName, cloneRel)
+}
+
+// DropTableConstraint removes the table constraint with the given name from the table.
+// The index created for a UNIQUE or FOREIGN KEY constraint is dropped as well.
+func (c *Catalog) DropTableConstraint(tx *Transaction, tableName, name string) error {
+	err := c.LockTable(tx, tableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.Cache.Get(RelationTableType, tableName)
+	if err != nil {
+		return err
+	}
+	ti := r.(*TableInfoRelation).Info
+
+	clone := ti.Clone()
+	clone.TableConstraints = nil
+
+	var tc *TableConstraint
+	for _, cur := range ti.TableConstraints {
+		if cur.Name == name {
+			tc = cur
+			continue
+		}
+		clone.TableConstraints = append(clone.TableConstraints, cur)
+	}
+	if tc == nil {
+		return fmt.Errorf("constraint %q of table %q not found", name, tableName)
+	}
+	if tc.PrimaryKey {
+		return fmt.Errorf("cannot drop PRIMARY KEY constraint %q", name)
+	}
+
+	// foreign keys must reference the primary key or a UNIQUE constraint
+	if tc.Unique && !clone.HasUniqueConstraint(tc.Paths) {
+		for _, ref := range c.Cache.GetReferencingConstraints(tableName) {
+			if ref.Constraint.ForeignKey.Paths.IsEqual(tc.Paths) {
+				return fmt.Errorf("cannot drop constraint %s because foreign key %s of table %s references it", name, ref.Constraint.Name, ref.Table.TableName)
+			}
+		}
+	}
+
+	// drop the index created for the constraint, unless another foreign key uses it
+	if tc.Unique || (tc.ForeignKey != nil && !clone.HasUniqueConstraint(tc.Paths) && !clone.HasForeignKey(tc.Paths)) {
+		for _, info := range c.Cache.GetTableIndexes(tableName) {
+			if info.Unique != tc.Unique || !info.Owner.Paths.IsEqual(tc.Paths) {
+				continue
+			}
+
+			_, err = c.Cache.Delete(tx, RelationIndexType, info.IndexName)
+			if err != nil {
+				return err
+			}
+
+			err = c.dropIndex(tx, info)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return c.replaceTableInfo(tx, clone, nil)
+}
+
+// AlterFieldType changes the type of the field at the given path.
+// The value of the field is converted to the new type in every document using CastConversion,
+// then the documents are validated against the constraints of the table.
+func (c *Catalog) AlterFieldType(tx *Transaction, tableName string, path document.Path, tp types.ValueType) error {
+	err := c.LockTable(tx, tableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	t, err := c.GetTable(tx, tableName)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAlterField(t.Info, path)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range c.Cache.GetReferencingConstraints(tableName) {
+		if pathsHavePrefix(ref.Constraint.ForeignKey.Paths, path) {
+			return fmt.Errorf("cannot alter field %s because foreign key %s of table %s references it", path, ref.Constraint.Name, ref.Table.TableName)
+		}
+	}
+
+	clone := t.Info.Clone()
+	clone.FieldConstraints, err = t.Info.FieldConstraints.SetFieldType(path, tp)
+	if err != nil {
+		return err
+	}
+
+	// constraints and indexes of the field and of its parent documents
+	// must be checked again
+	var tcs TableConstraints
+	for _, tc := range clone.TableConstraints {
+		if pathsHavePrefix(tc.Paths, path) || pathsArePrefixOf(tc.Paths, path) {
+			tcs = append(tcs, tc)
+		}
+	}
+
+	var rebuild []*IndexInfo
+	for _, info := range c.Cache.GetTableIndexes(tableName) {
+		if pathsHavePrefix(info.Paths, path) || pathsArePrefixOf(info.Paths, path) ||
+			pathsHavePrefix(info.ExprPaths, path) || pathsArePrefixOf(info.ExprPaths, path) {
+			rebuild = append(rebuild, info)
+		}
+	}
+
+	err = c.rewriteDocuments(t, clone, func(fb *document.FieldBuffer) error {
+		v, err := path.GetValueFromDocument(fb)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if v.Type() == types.NullValue {
+			return nil
+		}
+
+		v, err = CastConversion(v, path, tp)
+		if err != nil {
+			return err
+		}
+
+		return fb.Set(path, v)
+	})
+	if err != nil {
+		return err
+	}
+
+	t.Info = clone
+	err = c.validateDocuments(t, tcs)
+	if err != nil {
+		return err
+	}
+
+	return c.replaceTableInfo(tx, clone, rebuild)
+}
+
+// DropField removes the field at the given path from the table and from all of its documents.
+// The indexes and the table constraints using the field are dropped as well.
+func (c *Catalog) DropField(tx *Transaction, tableName string, path document.Path) error {
+	err := c.LockTable(tx, tableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	t, err := c.GetTable(tx, tableName)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAlterField(t.Info, path)
+	if err != nil {
+		return err
+	}
+
+	clone := t.Info.Clone()
+	clone.FieldConstraints, err = t.Info.FieldConstraints.DropField(path)
+	if err != nil {
+		return err
+	}
+
+	clone.TableConstraints = nil
+	for _, tc := range t.Info.TableConstraints {
+		if !pathsHavePrefix(tc.Paths, path) {
+			clone.TableConstraints = append(clone.TableConstraints, tc)
+		}
+	}
+
+	for _, ref := range c.Cache.GetReferencingConstraints(tableName) {
+		if ref.Table.TableName == tableName && pathsHavePrefix(ref.Constraint.Paths, path) {
+			// the foreign key is dropped with the field
+			continue
+		}
+
+		if pathsHavePrefix(ref.Constraint.ForeignKey.Paths, path) {
+			return fmt.Errorf("cannot drop field %s because foreign key %s of table %s references it", path, ref.Constraint.Name, ref.Table.TableName)
+		}
+	}
+
+	// indexes of the field are dropped, indexes of its parent documents are rebuilt
+	var rebuild []*IndexInfo
+	for _, info := range c.Cache.GetTableIndexes(tableName) {
+		switch {
+		case pathsHavePrefix(info.Paths, path), pathsHavePrefix(info.ExprPaths, path):
+			_, err = c.Cache.Delete(tx, RelationIndexType, info.IndexName)
+			if err != nil {
+				return err
+			}
+
+			err = c.dropIndex(tx, info)
+			if err != nil {
+				return err
+			}
+		case pathsArePrefixOf(info.Paths, path), pathsArePrefixOf(info.ExprPaths, path):
+			rebuild = append(rebuild, info)
+		}
+	}
+
+	err = c.rewriteDocuments(t, clone, func(fb *document.FieldBuffer) error {
+		err := fb.Delete(path)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.replaceTableInfo(tx, clone, rebuild)
+}
+
+// RenameField renames the field at the given path in the table and in all of its documents.
+// The paths of the indexes and the constraints using the field are renamed as well.
+// Since CHECK constraints, indexed expressions and index predicates are expressions the catalog cannot modify,
+// renameCheck must return a copy of the given expression using the renamed field.
+func (c *Catalog) RenameField(tx *Transaction, tableName string, path document.Path, newName string, renameCheck func(e TableExpression) (TableExpression, error)) error {
+	err := c.LockTable(tx, tableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	t, err := c.GetTable(tx, tableName)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAlterField(t.Info, path)
+	if err != nil {
+		return err
+	}
+
+	newPath := path.Clone()
+	newPath[len(newPath)-1].FieldName = newName
+
+	clone := t.Info.Clone()
+	clone.FieldConstraints, err = t.Info.FieldConstraints.RenameField(path, newName)
+	if err != nil {
+		return err
+	}
+
+	for i, tc := range clone.TableConstraints {
+		fk := tc.ForeignKey
+		selfReference := fk != nil && fk.Table == tableName && pathsHavePrefix(fk.Paths, path)
+		if !pathsHavePrefix(tc.Paths, path) && !selfReference {
+			continue
+		}
+
+		tcClone := *tc
+		tcClone.Paths = renamePaths(tc.Paths, path, newName)
+		if tc.Check != nil {
+			tcClone.Check, err = renameCheck(tc.Check)
+			if err != nil {
+				return err
+			}
+		}
+		if selfReference {
+			fkClone := *fk
+			fkClone.Paths = renamePaths(fk.Paths, path, newName)
+			tcClone.ForeignKey = &fkClone
+		}
+		clone.TableConstraints[i] = &tcClone
+	}
+
+	// indexes of the field are renamed, indexes of its parent documents are rebuilt
+	var rebuild []*IndexInfo
+	for _, info := range c.Cache.GetTableIndexes(tableName) {
+		switch {
+		case pathsHavePrefix(info.Paths, path), pathsHavePrefix(info.ExprPaths, path):
+			idxClone := info.Clone()
+			idxClone.Paths = renamePaths(info.Paths, path, newName)
+			if len(info.Owner.Paths) > 0 {
+				idxClone.Owner.Paths = renamePaths(info.Owner.Paths, path, newName)
+			}
+			idxClone.ExprPaths = renamePaths(info.ExprPaths, path, newName)
+			for i, e := range info.Exprs {
+				if e == nil {
+					continue
+				}
+
+				idxClone.Exprs[i], err = renameCheck(e)
+				if err != nil {
+					return err
+				}
+				idxClone.Exprs[i].Bind(c)
+			}
+			if info.Predicate != nil {
+				idxClone.Predicate, err = renameCheck(info.Predicate)
+				if err != nil {
+					return err
+				}
+				idxClone.Predicate.Bind(c)
+			}
+
+			cloneRel := &IndexInfoRelation{Info: idxClone}
+			err = c.Cache.Replace(tx, cloneRel)
+			if err != nil {
+				return err
+			}
+
+			err = c.CatalogTable.Replace(tx, info.IndexName, cloneRel)
+			if err != nil {
+				return err
+			}
+		case pathsArePrefixOf(info.Paths, path), pathsArePrefixOf(info.ExprPaths, path):
+			rebuild = append(rebuild, info)
+		}
+	}
+
+	err = c.rewriteDocuments(t, clone, func(fb *document.FieldBuffer) error {
+		v, err := path.GetValueFromDocument(fb)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = newPath.GetValueFromDocument(fb)
+		if err == nil {
+			return fmt.Errorf("field %q already exists", newPath)
+		}
+
+		err = fb.Delete(path)
+		if err != nil {
+			return err
+		}
+
+		return fb.Set(newPath, v)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.replaceTableInfo(tx, clone, rebuild)
+	if err != nil {
+		return err
+	}
+
+	// update the foreign keys of the other tables referencing the field
+	for _, ref := range c.Cache.GetReferencingConstraints(tableName) {
+		if ref.Table.TableName == tableName || !pathsHavePrefix(ref.Constraint.ForeignKey.Paths, path) {
+			continue
+		}
+
+		refClone := ref.Table.Clone()
+		for i, tc := range refClone.TableConstraints {
+			if tc != ref.Constraint {
+				continue
+			}
+
+			tcClone := *tc
+			fkClone := *tc.ForeignKey
+			fkClone.Paths = renamePaths(fkClone.Paths, path, newName)
+			tcClone.ForeignKey = &fkClone
+			refClone.TableConstraints[i] = &tcClone
+		}
+
+		err = c.replaceTableInfo(tx, refClone, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkAlterField returns an error if the field at the given path cannot be dropped or renamed.
+func (c *Catalog) checkAlterField(info *TableInfo, path document.Path) error {
+	if info.ReadOnly {
+		return errors.New("cannot write to read-only table")
+	}
+
+	if pk := info.GetPrimaryKey(); pk != nil {
+		if pathsHavePrefix(pk.Paths, path) || pathsArePrefixOf(pk.Paths, path) {
+			return fmt.Errorf("cannot alter field %s because it is part of the primary key", path)
+		}
+	}
+
+	return nil
+}
+
+// rewriteDocuments modifies every document of the table with fn, if not nil,
+// and stores it encoded with the given table information.
+func (c *Catalog) rewriteDocuments(t *Table, info *TableInfo, fn func(fb *document.FieldBuffer) error) error {
+	return t.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
+		var fb document.FieldBuffer
+		err := fb.Copy(d)
+		if err != nil {
+			return err
+		}
+
+		if fn != nil {
+			err = fn(&fb)
+			if err != nil {
+				return err
+			}
+		}
+
+		enc, err := info.EncodeDocument(t.Tx, nil, &fb)
+		if err != nil {
+			return err
+		}
+
+		return t.Tree.Put(key, enc)
+	})
+}
+
+// validateDocuments ensures every document of the table satisfies
+// the given CHECK and FOREIGN KEY constraints.
+func (c *Catalog) validateDocuments(t *Table, tcs TableConstraints) error {
+	var hasConstraint bool
+	for _, tc := range tcs {
+		if tc.Check != nil || tc.ForeignKey != nil {
+			hasConstraint = true
+		}
+	}
+	if !hasConstraint {
+		return nil
+	}
+
+	info := TableInfo{TableName: t.Info.TableName, TableConstraints: tcs}
+	return t.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
+		err := tcs.ValidateDocument(t.Tx, d)
+		if err != nil {
+			return err
+		}
+
+		return c.ValidateForeignKeys(t.Tx, &info, d)
+	})
+}
+
+// replaceTableInfo stores the new information of the table
+// and rebuilds the given indexes.
+func (c *Catalog) replaceTableInfo(tx *Transaction, info *TableInfo, rebuild []*IndexInfo) error {
+	rel := &TableInfoRelation{Info: info}
+	err := c.Cache.Replace(tx, rel)
+	if err != nil {
+		return err
+	}
+
+	err = c.CatalogTable.Replace(tx, info.TableName, rel)
+	if err != nil {
+		return err
+	}
+
+	if len(rebuild) == 0 {
+		return nil
+	}
+
+	t, err := c.GetTable(tx, info.TableName)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range rebuild {
+		err = c.rebuildIndex(t, info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rebuildIndex deletes all the entries of the index and indexes every document of the table again.
+func (c *Catalog) rebuildIndex(t *Table, info *IndexInfo) error {
+	idx, err := c.GetIndex(t.Tx, info.IndexName)
+	if err != nil {
+		return err
+	}
+
+	err = idx.Truncate()
+	if err != nil {
+		return err
+	}
+
+	return t.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
+		ok, err := info.Matches(t.Tx, d)
+		if err != nil || !ok {
+			return err
+		}
+
+		entries, err := info.Entries(t.Tx, d)
+		if err != nil {
+			return err
+		}
+
+		for _, vs := range entries {
+			if info.Unique && !valuesHaveNull(vs) {
+				duplicate, dKey, err := idx.Exists(vs)
+				if err != nil {
+					return err
+				}
+				if duplicate {
+					return info.UniqueViolation(dKey)
+				}
+			}
+
+			err = idx.Set(vs, key.Encoded)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// pathsHavePrefix returns whether any of the paths starts with prefix.
+func pathsHavePrefix(paths []document.Path, prefix document.Path) bool {
+	for _, p := range paths {
+		if p.HasPrefix(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathsArePrefixOf returns whether any of the paths is a prefix of path.
+func pathsArePrefixOf(paths []document.Path, path document.Path) bool {
+	for _, p := range paths {
+		// the paths of indexed expressions are empty
+		if len(p) > 0 && path.HasPrefix(p) {
+			return true
 		}
 	}
 
-	cloneRel := &TableInfoRelation{Info: clone}
-	err = c.Cache.Replace(tx, cloneRel)
-	if err != nil {
-		return err
+	return false
+}
+
+// renamePaths returns a copy of the paths where the field at the given path is renamed.
+func renamePaths(paths []document.Path, path document.Path, newName string) []document.Path {
+	renamed := make([]document.Path, len(paths))
+	for i, p := range paths {
+		renamed[i] = p
+		if p.HasPrefix(path) {
+			renamed[i] = p.Clone()
+			renamed[i][len(path)-1].FieldName = newName
+		}
 	}
 
-	return c.CatalogTable.Replace(tx, tableName, cloneRel)
+	return renamed
 }
 
 // RenameTable renames a table.
@@ -420,6 +1045,11 @@ func (c *Catalog) RenameTable(tx *Transaction, oldName, newName string) error {
 		return err
 	}
 
+	err = c.checkNoDependentViews("rename table", oldName)
+	if err != nil {
+		return err
+	}
+
 	// Delete the old table info.
 	err = c.CatalogTable.Delete(tx, oldName)
 	if errs.IsNotFoundError(err) {
@@ -502,9 +1132,232 @@ func (c *Catalog) RenameTable(tx *Transaction, oldName, newName string) error {
 		}
 	}
 
+	err = c.renameForeignKeyReferences(tx, oldName, newName)
+	if err != nil {
+		return err
+	}
+
+	for _, trg := range c.Cache.GetTableTriggers(oldName) {
+		trgClone := trg.Clone()
+		trgClone.TableName = newName
+
+		cloneRel := &TriggerInfoRelation{Info: trgClone}
+		err = c.Cache.Replace(tx, cloneRel)
+		if err != nil {
+			return err
+		}
+
+		err = c.CatalogTable.Replace(tx, trg.TriggerName, cloneRel)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateView creates a view with the given name.
+// Every relation read by the query of the view must exist.
+// If it already exists, returns errs.AlreadyExistsError.
+func (c *Catalog) CreateView(tx *Transaction, info *ViewInfo) error {
+	err := c.LockTable(tx, info.ViewName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	if info.ViewName == "" {
+		return errors.New("view name required")
+	}
+
+	for _, name := range info.Query.Relations() {
+		if !c.Cache.relationExists(name) {
+			return errors.Wrapf(errs.NotFoundError{Name: name}, "cannot create view %s", info.ViewName)
+		}
+	}
+
+	rel := ViewInfoRelation{Info: info}
+	err = c.CatalogTable.Insert(tx, &rel)
+	if err != nil {
+		return err
+	}
+
+	return c.Cache.Add(tx, &rel)
+}
+
+// GetViewInfo returns the view info for the given view name.
+func (c *Catalog) GetViewInfo(viewName string) (*ViewInfo, error) {
+	r, err := c.Cache.Get(RelationViewType, viewName)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.(*ViewInfoRelation).Info, nil
+}
+
+// ListViews returns all view names sorted lexicographically.
+func (c *Catalog) ListViews() []string {
+	return c.Cache.ListObjects(RelationViewType)
+}
+
+// DropView deletes a view from the catalog.
+// It fails if other views depend on it.
+func (c *Catalog) DropView(tx *Transaction, viewName string) error {
+	err := c.LockTable(tx, viewName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.GetViewInfo(viewName)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkNoDependentViews("drop view", viewName)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Cache.Delete(tx, RelationViewType, viewName)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Delete(tx, viewName)
+}
+
+// RenameView renames a view.
+// It fails if other views depend on it.
+func (c *Catalog) RenameView(tx *Transaction, oldName, newName string) error {
+	err := c.LockTable(tx, oldName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.GetViewInfo(oldName)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkNoDependentViews("rename view", oldName)
+	if err != nil {
+		return err
+	}
+
+	o, err := c.Cache.Delete(tx, RelationViewType, oldName)
+	if err != nil {
+		return err
+	}
+
+	err = c.CatalogTable.Delete(tx, oldName)
+	if err != nil {
+		return err
+	}
+
+	clone := o.(*ViewInfoRelation).Info.Clone()
+	clone.ViewName = newName
+
+	cloneRel := &ViewInfoRelation{Info: clone}
+	err = c.CatalogTable.Insert(tx, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	return c.Cache.Add(tx, cloneRel)
+}
+
+// checkNoDependentViews returns an error if any view reads from the relation
+// with the given name.
+func (c *Catalog) checkNoDependentViews(action, name string) error {
+	views := c.Cache.GetDependentViews(name)
+	if len(views) > 0 {
+		return fmt.Errorf("cannot %s %s because view %s depends on it", action, name, views[0].ViewName)
+	}
+
 	return nil
 }
 
+// CreateTrigger creates a trigger on an existing table.
+// If it already exists, returns errs.AlreadyExistsError.
+func (c *Catalog) CreateTrigger(tx *Transaction, info *TriggerInfo) error {
+	err := c.LockTable(tx, info.TableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	if info.TriggerName == "" {
+		return errors.New("trigger name required")
+	}
+
+	ti, err := c.GetTableInfo(info.TableName)
+	if err != nil {
+		return err
+	}
+
+	if ti.ReadOnly {
+		return errors.New("cannot create trigger on read-only table")
+	}
+
+	rel := TriggerInfoRelation{Info: info}
+	err = c.CatalogTable.Insert(tx, &rel)
+	if err != nil {
+		return err
+	}
+
+	return c.Cache.Add(tx, &rel)
+}
+
+// GetTriggerInfo returns the trigger info for the given trigger name.
+func (c *Catalog) GetTriggerInfo(triggerName string) (*TriggerInfo, error) {
+	r, err := c.Cache.Get(RelationTriggerType, triggerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.(*TriggerInfoRelation).Info, nil
+}
+
+// ListTriggers returns all trigger names sorted lexicographically.
+func (c *Catalog) ListTriggers() []string {
+	return c.Cache.ListObjects(RelationTriggerType)
+}
+
+// GetTableTriggers returns the triggers of the table fired at the given timing
+// by the given event, sorted by name.
+func (c *Catalog) GetTableTriggers(tableName, timing, event string) []*TriggerInfo {
+	var triggers []*TriggerInfo
+	for _, t := range c.Cache.GetTableTriggers(tableName) {
+		if t.Timing == timing && t.Event == event {
+			triggers = append(triggers, t)
+		}
+	}
+
+	return triggers
+}
+
+// DropTrigger deletes a trigger from the catalog.
+func (c *Catalog) DropTrigger(tx *Transaction, name string) error {
+	info, err := c.GetTriggerInfo(name)
+	if err != nil {
+		return err
+	}
+
+	err = c.LockTable(tx, info.TableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	return c.dropTrigger(tx, name)
+}
+
+func (c *Catalog) dropTrigger(tx *Transaction, name string) error {
+	_, err := c.Cache.Delete(tx, RelationTriggerType, name)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Delete(tx, name)
+}
+
 func (c *Catalog) GetSequence(name string) (*Sequence, error) {
 	r, err := c.Cache.Get(RelationSequenceType, name)
 	if err != nil {
@@ -620,6 +1473,46 @@ func (r *IndexInfoRelation) GenerateBaseName() string {
 	return fmt.Sprintf("%s_%s_idx", r.Info.Owner.TableName, pathsToIndexName(r.Info.Paths))
 }
 
+type ViewInfoRelation struct {
+	Info *ViewInfo
+}
+
+func (r *ViewInfoRelation) Type() string {
+	return "view"
+}
+
+func (r *ViewInfoRelation) Name() string {
+	return r.Info.ViewName
+}
+
+func (r *ViewInfoRelation) SetName(name string) {
+	r.Info.ViewName = name
+}
+
+func (r *ViewInfoRelation) GenerateBaseName() string {
+	return r.Info.ViewName
+}
+
+type TriggerInfoRelation struct {
+	Info *TriggerInfo
+}
+
+func (r *TriggerInfoRelation) Type() string {
+	return RelationTriggerType
+}
+
+func (r *TriggerInfoRelation) Name() string {
+	return r.Info.TriggerName
+}
+
+func (r *TriggerInfoRelation) SetName(name string) {
+	r.Info.TriggerName = name
+}
+
+func (r *TriggerInfoRelation) GenerateBaseName() string {
+	return r.Info.TriggerName
+}
+
 func pathsToIndexName(paths []document.Path) string {
 	var s strings.Builder
 
@@ -628,6 +1521,12 @@ func pathsToIndexName(paths []document.Path) string {
 			s.WriteRune('_')
 		}
 
+		// indexed expressions have no path
+		if len(p) == 0 {
+			s.WriteString("expr")
+			continue
+		}
+
 		s.WriteString(p.String())
 	}
 
@@ -638,6 +1537,13 @@ type catalogCache struct {
 	tables    map[string]Relation
 	indexes   map[string]Relation
 	sequences map[string]Relation
+	views     map[string]Relation
+	triggers  map[string]Relation
+
+	// version is incremented every time a relation is added,
+	// replaced or deleted, including when the change is rolled back.
+	// It is shared with the clones of the cache.
+	version *atomic.Counter
 }
 
 func newCatalogCache() *catalogCache {
@@ -645,10 +1551,13 @@ func newCatalogCache() *catalogCache {
 		tables:    make(map[string]Relation),
 		indexes:   make(map[string]Relation),
 		sequences: make(map[string]Relation),
+		views:     make(map[string]Relation),
+		triggers:  make(map[string]Relation),
+		version:   atomic.NewCounter(0, math.MaxInt64),
 	}
 }
 
-func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences []Sequence) {
+func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences []Sequence, views []ViewInfo, triggers []TriggerInfo) {
 	for i := range tables {
 		c.tables[tables[i].TableName] = &TableInfoRelation{Info: &tables[i]}
 	}
@@ -660,6 +1569,14 @@ func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences [
 	for i := range sequences {
 		c.sequences[sequences[i].Info.Name] = &sequences[i]
 	}
+
+	for i := range views {
+		c.views[views[i].ViewName] = &ViewInfoRelation{Info: &views[i]}
+	}
+
+	for i := range triggers {
+		c.triggers[triggers[i].TriggerName] = &TriggerInfoRelation{Info: &triggers[i]}
+	}
 }
 
 // TODO put in tests
@@ -675,6 +1592,13 @@ func (c *catalogCache) Clone() *catalogCache {
 	for k, v := range c.sequences {
 		clone.sequences[k] = v
 	}
+	for k, v := range c.views {
+		clone.views[k] = v
+	}
+	for k, v := range c.triggers {
+		clone.triggers[k] = v
+	}
+	clone.version = c.version
 
 	return clone
 }
@@ -695,9 +1619,29 @@ func (c *catalogCache) objectExists(name string) bool {
 		return true
 	}
 
+	// checking if view exists with the same name
+	if _, ok := c.views[name]; ok {
+		return true
+	}
+
+	// checking if trigger exists with the same name
+	if _, ok := c.triggers[name]; ok {
+		return true
+	}
+
 	return false
 }
 
+// relationExists returns whether a table or a view exists with the given name.
+func (c *catalogCache) relationExists(name string) bool {
+	if _, ok := c.tables[name]; ok {
+		return true
+	}
+
+	_, ok := c.views[name]
+	return ok
+}
+
 func (c *catalogCache) generateUnusedName(baseName string) string {
 	name := baseName
 	i := 0
@@ -721,6 +1665,10 @@ func (c *catalogCache) getMapByType(tp string) map[string]Relation {
 		return c.indexes
 	case RelationSequenceType:
 		return c.sequences
+	case RelationViewType:
+		return c.views
+	case RelationTriggerType:
+		return c.triggers
 	}
 
 	panic(fmt.Sprintf("unknown catalog object type %q", tp))
@@ -742,9 +1690,11 @@ func (c *catalogCache) Add(tx *Transaction, o Relation) error {
 
 	m := c.getMapByType(o.Type())
 	m[name] = o
+	c.version.Incr()
 
 	tx.OnRollbackHooks = append(tx.OnRollbackHooks, func() {
 		delete(m, name)
+		c.version.Incr()
 	})
 
 	return nil
@@ -759,9 +1709,11 @@ func (c *catalogCache) Replace(tx *Transaction, o Relation) error {
 	}
 
 	m[o.Name()] = o
+	c.version.Incr()
 
 	tx.OnRollbackHooks = append(tx.OnRollbackHooks, func() {
 		m[o.Name()] = old
+		c.version.Incr()
 	})
 
 	return nil
@@ -776,14 +1728,22 @@ func (c *catalogCache) Delete(tx *Transaction, tp, name string) (Relation, error
 	}
 
 	delete(m, name)
+	c.version.Incr()
 
 	tx.OnRollbackHooks = append(tx.OnRollbackHooks, func() {
 		m[name] = o
+		c.version.Incr()
 	})
 
 	return o, nil
 }
 
+// Version returns a number that changes every time the relations of the catalog change.
+// It allows to detect that data computed from the catalog, such as prepared statements, is outdated.
+func (c *catalogCache) Version() int64 {
+	return c.version.Get()
+}
+
 func (c *catalogCache) Get(tp, name string) (Relation, error) {
 	m := c.getMapByType(tp)
 
@@ -820,6 +1780,74 @@ func (c *catalogCache) GetTableIndexes(tableName string) []*IndexInfo {
 	return indexes
 }
 
+// GetTableTriggers returns the triggers of the table, sorted by name.
+func (c *catalogCache) GetTableTriggers(tableName string) []*TriggerInfo {
+	var triggers []*TriggerInfo
+	for _, o := range c.triggers {
+		t := o.(*TriggerInfoRelation).Info
+		if t.TableName != tableName {
+			continue
+		}
+		triggers = append(triggers, t)
+	}
+
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].TriggerName < triggers[j].TriggerName
+	})
+
+	return triggers
+}
+
+// GetDependentViews returns the views reading from the relation with the given name,
+// sorted by name.
+func (c *catalogCache) GetDependentViews(name string) []*ViewInfo {
+	var views []*ViewInfo
+	for _, o := range c.views {
+		v := o.(*ViewInfoRelation).Info
+		for _, r := range v.Query.Relations() {
+			if r == name {
+				views = append(views, v)
+				break
+			}
+		}
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].ViewName < views[j].ViewName
+	})
+
+	return views
+}
+
+// ForeignKeyReference is a foreign key constraint of a table.
+type ForeignKeyReference struct {
+	Table      *TableInfo
+	Constraint *TableConstraint
+}
+
+// GetReferencingConstraints returns the foreign keys referencing the table with the given name,
+// sorted by table and constraint name.
+func (c *catalogCache) GetReferencingConstraints(tableName string) []ForeignKeyReference {
+	var refs []ForeignKeyReference
+	for _, o := range c.tables {
+		ti := o.(*TableInfoRelation).Info
+		for _, tc := range ti.TableConstraints {
+			if tc.ForeignKey != nil && tc.ForeignKey.Table == tableName {
+				refs = append(refs, ForeignKeyReference{Table: ti, Constraint: tc})
+			}
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Table.TableName != refs[j].Table.TableName {
+			return refs[i].Table.TableName < refs[j].Table.TableName
+		}
+		return refs[i].Constraint.Name < refs[j].Constraint.Name
+	})
+
+	return refs
+}
+
 type CatalogStore struct {
 	Catalog *Catalog
 	info    *TableInfo
@@ -942,6 +1970,10 @@ func relationToDocument(r Relation) types.Document {
 		return indexInfoToDocument(t.Info)
 	case *Sequence:
 		return sequenceInfoToDocument(t.Info)
+	case *ViewInfoRelation:
+		return viewInfoToDocument(t.Info)
+	case *TriggerInfoRelation:
+		return triggerInfoToDocument(t.Info)
 	}
 
 	panic(fmt.Sprintf("objectToDocument: unknown type %q", r.Type()))
@@ -986,6 +2018,25 @@ func sequenceInfoToDocument(seq *SequenceInfo) types.Document {
 	return buf
 }
 
+func viewInfoToDocument(v *ViewInfo) types.Document {
+	buf := document.NewFieldBuffer()
+	buf.Add("name", types.NewTextValue(v.ViewName))
+	buf.Add("type", types.NewTextValue(RelationViewType))
+	buf.Add("sql", types.NewTextValue(v.String()))
+
+	return buf
+}
+
+func triggerInfoToDocument(t *TriggerInfo) types.Document {
+	buf := document.NewFieldBuffer()
+	buf.Add("name", types.NewTextValue(t.TriggerName))
+	buf.Add("type", types.NewTextValue(RelationTriggerType))
+	buf.Add("sql", types.NewTextValue(t.String()))
+	buf.Add("owner", types.NewDocumentValue(ownerToDocument(&Owner{TableName: t.TableName})))
+
+	return buf
+}
+
 func ownerToDocument(owner *Owner) types.Document {
 	buf := document.NewFieldBuffer().Add("table_name", types.NewTextValue(owner.TableName))
 	if owner.Paths != nil {
@@ -249,6 +249,45 @@ func TestCatalogTable(t *testing.T) {
 
 		require.Equal(t, clone, db.Catalog)
 	})
+
+	t.Run("Add field constraint with extra fields", func(t *testing.T) {
+		db := testutil.NewTestDB(t)
+
+		ti := &database.TableInfo{FieldConstraints: database.MustNewFieldConstraints(
+			&database.FieldConstraint{Field: "a", Type: types.IntegerValue},
+		), TableConstraints: []*database.TableConstraint{
+			{Paths: []document.Path{testutil.ParseDocumentPath(t, "a")}, PrimaryKey: true},
+		}}
+		ti.FieldConstraints.AllowExtraFields = true
+
+		var key *tree.Key
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.Catalog) error {
+			err := catalog.CreateTable(tx, "foo", ti)
+			assert.NoError(t, err)
+
+			tb, err := catalog.GetTable(tx, "foo")
+			assert.NoError(t, err)
+
+			key, _, err = tb.Insert(testutil.MakeDocument(t, `{"a": 1, "b": "foo"}`))
+			return err
+		})
+
+		// the new field is encoded before the extra fields,
+		// existing documents must still be decoded correctly
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.Catalog) error {
+			err := catalog.AddFieldConstraint(tx, "foo", &database.FieldConstraint{Field: "c", Type: types.IntegerValue}, nil)
+			assert.NoError(t, err)
+
+			tb, err := catalog.GetTable(tx, "foo")
+			assert.NoError(t, err)
+
+			d, err := tb.GetDocument(key)
+			assert.NoError(t, err)
+			testutil.RequireDocJSONEq(t, d, `{"a": 1, "b": "foo"}`)
+
+			return nil
+		})
+	})
 }
 
 func TestCatalogCreateTable(t *testing.T) {
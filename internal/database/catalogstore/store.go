@@ -19,7 +19,7 @@ func LoadCatalog(tx *database.Transaction) (*database.Catalog, error) {
 		return nil, err
 	}
 
-	tables, indexes, sequences, err := loadCatalogStore(tx, c.CatalogTable)
+	tables, indexes, sequences, views, triggers, err := loadCatalogStore(tx, c.CatalogTable)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load catalog store")
 	}
@@ -35,6 +35,18 @@ func LoadCatalog(tx *database.Transaction) (*database.Catalog, error) {
 		}
 	}
 
+	// bind indexed expressions and index predicates with catalog
+	for i := range indexes {
+		for _, e := range indexes[i].Exprs {
+			if e != nil {
+				e.Bind(c)
+			}
+		}
+		if indexes[i].Predicate != nil {
+			indexes[i].Predicate.Bind(c)
+		}
+	}
+
 	// add the __genji_catalog table to the list of tables
 	// so that it can be queried
 	ti := c.CatalogTable.Info().Clone()
@@ -42,8 +54,8 @@ func LoadCatalog(tx *database.Transaction) (*database.Catalog, error) {
 	ti.ReadOnly = true
 	tables = append(tables, *ti)
 
-	// load tables and indexes first
-	c.Cache.Load(tables, indexes, nil)
+	// load tables, indexes, views and triggers first
+	c.Cache.Load(tables, indexes, nil, views, triggers)
 
 	if len(sequences) > 0 {
 		var seqList []database.Sequence
@@ -52,7 +64,7 @@ func LoadCatalog(tx *database.Transaction) (*database.Catalog, error) {
 			return nil, errors.Wrap(err, "failed to load sequences")
 		}
 
-		c.Cache.Load(nil, nil, seqList)
+		c.Cache.Load(nil, nil, seqList, nil, nil)
 	}
 
 	return c, nil
@@ -89,7 +101,7 @@ func loadSequences(tx *database.Transaction, c *database.Catalog, info []databas
 	return sequences, nil
 }
 
-func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (tables []database.TableInfo, indexes []database.IndexInfo, sequences []database.SequenceInfo, err error) {
+func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (tables []database.TableInfo, indexes []database.IndexInfo, sequences []database.SequenceInfo, views []database.ViewInfo, triggers []database.TriggerInfo, err error) {
 	tb := s.Table(tx)
 
 	err = tb.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
@@ -118,6 +130,18 @@ func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (table
 				return errors.Wrap(err, "failed to decode sequence info")
 			}
 			sequences = append(sequences, *i)
+		case database.RelationViewType:
+			v, err := viewInfoFromDocument(d)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode view info")
+			}
+			views = append(views, *v)
+		case database.RelationTriggerType:
+			t, err := triggerInfoFromDocument(d)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode trigger info")
+			}
+			triggers = append(triggers, *t)
 		}
 
 		return nil
@@ -228,6 +252,36 @@ func sequenceInfoFromDocument(d types.Document) (*database.SequenceInfo, error)
 	return &i, nil
 }
 
+func viewInfoFromDocument(d types.Document) (*database.ViewInfo, error) {
+	s, err := d.GetByField("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := parser.NewParser(strings.NewReader(types.As[string](s))).ParseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	v := stmt.(*statement.CreateViewStmt).Info
+	return &v, nil
+}
+
+func triggerInfoFromDocument(d types.Document) (*database.TriggerInfo, error) {
+	s, err := d.GetByField("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := parser.NewParser(strings.NewReader(types.As[string](s))).ParseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	t := stmt.(*statement.CreateTriggerStmt).Info
+	return &t, nil
+}
+
 func ownerFromDocument(d types.Document) (*database.Owner, error) {
 	var owner database.Owner
 
@@ -11,6 +11,9 @@ const (
 
 	// OnConflictDoReplace replaces the conflicting document with a new one.
 	OnConflictDoReplace
+
+	// OnConflictDoUpdate updates the conflicting document.
+	OnConflictDoUpdate
 )
 
 func (o OnConflictAction) String() string {
@@ -19,6 +22,8 @@ func (o OnConflictAction) String() string {
 		return "DO NOTHING"
 	case OnConflictDoReplace:
 		return "DO REPLACE"
+	case OnConflictDoUpdate:
+		return "DO UPDATE"
 	}
 
 	return ""
@@ -19,6 +19,13 @@ type FieldConstraint struct {
 	IsNotNull     bool
 	DefaultValue  TableExpression
 	AnonymousType *AnonymousType
+
+	// Precision and Scale of DECIMAL fields.
+	// If Precision is zero, the decimal is not constrained.
+	Precision, Scale int
+
+	// IsDate is true for DATE fields, whose timestamps are truncated to midnight UTC.
+	IsDate bool
 }
 
 func (f *FieldConstraint) IsEmpty() bool {
@@ -29,9 +36,14 @@ func (f *FieldConstraint) String() string {
 	var s strings.Builder
 
 	s.WriteString(f.Field)
-	if f.Type != types.DocumentValue {
+	if f.IsDate {
+		s.WriteString(" DATE")
+	} else if f.Type != types.DocumentValue {
 		s.WriteString(" ")
 		s.WriteString(strings.ToUpper(f.Type.String()))
+		if f.Precision != 0 {
+			fmt.Fprintf(&s, "(%d, %d)", f.Precision, f.Scale)
+		}
 	} else if f.AnonymousType != nil {
 		s.WriteString(" ")
 		s.WriteString(f.AnonymousType.String())
@@ -56,6 +68,34 @@ func (f *FieldConstraint) HasDefaultValue() bool {
 	return f.DefaultValue != nil
 }
 
+// checkDefaultValue ensures the default value type is compatible with the type of the field.
+func (f *FieldConstraint) checkDefaultValue() error {
+	if f.DefaultValue == nil || f.Type.IsAny() {
+		return nil
+	}
+
+	// first, try to evaluate the default value
+	v, err := f.DefaultValue.Eval(nil, nil)
+	// if there is no error, check if the default value can be converted to the type of the constraint
+	if err == nil {
+		_, err = document.CastAs(v, f.Type)
+		if err != nil {
+			return fmt.Errorf("default value %q cannot be converted to type %q", f.DefaultValue, f.Type)
+		}
+	} else {
+		// if there is an error, we know we are using a function that returns an integer (NEXT VALUE FOR)
+		// which is the only one compatible for the moment.
+		// Integers can be converted to other integers, doubles, decimals, texts and bools.
+		switch f.Type {
+		case types.IntegerValue, types.DoubleValue, types.DecimalValue, types.TextValue, types.BooleanValue:
+		default:
+			return fmt.Errorf("default value %q cannot be converted to type %q", f.DefaultValue, f.Type)
+		}
+	}
+
+	return nil
+}
+
 // FieldConstraints is a list of field constraints.
 type FieldConstraints struct {
 	Ordered          []*FieldConstraint
@@ -92,26 +132,9 @@ func (f *FieldConstraints) Add(newFc *FieldConstraint) error {
 		return fmt.Errorf("conflicting constraints: %q and %q: %#v", c.String(), newFc.String(), f.ByField)
 	}
 
-	// ensure default value type is compatible
-	if newFc.DefaultValue != nil && !newFc.Type.IsAny() {
-		// first, try to evaluate the default value
-		v, err := newFc.DefaultValue.Eval(nil, nil)
-		// if there is no error, check if the default value can be converted to the type of the constraint
-		if err == nil {
-			_, err = document.CastAs(v, newFc.Type)
-			if err != nil {
-				return fmt.Errorf("default value %q cannot be converted to type %q", newFc.DefaultValue, newFc.Type)
-			}
-		} else {
-			// if there is an error, we know we are using a function that returns an integer (NEXT VALUE FOR)
-			// which is the only one compatible for the moment.
-			// Integers can be converted to other integers, doubles, texts and bools.
-			switch newFc.Type {
-			case types.IntegerValue, types.DoubleValue, types.TextValue, types.BooleanValue:
-			default:
-				return fmt.Errorf("default value %q cannot be converted to type %q", newFc.DefaultValue, newFc.Type)
-			}
-		}
+	err := newFc.checkDefaultValue()
+	if err != nil {
+		return err
 	}
 
 	newFc.Position = len(f.Ordered)
@@ -193,6 +216,123 @@ func (f FieldConstraints) GetFieldConstraintForPath(path document.Path) *FieldCo
 	return nil
 }
 
+// DropField returns a copy of the field constraints without the constraint
+// of the field at the given path.
+func (f FieldConstraints) DropField(path document.Path) (FieldConstraints, error) {
+	return f.alterField(path, func(fcs *FieldConstraints, field string) error {
+		fc, ok := fcs.ByField[field]
+		if !ok {
+			return nil
+		}
+
+		delete(fcs.ByField, field)
+		fcs.Ordered = append(fcs.Ordered[:fc.Position], fcs.Ordered[fc.Position+1:]...)
+		for i := fc.Position; i < len(fcs.Ordered); i++ {
+			fcs.Ordered[i].Position = i
+		}
+
+		return nil
+	})
+}
+
+// RenameField returns a copy of the field constraints where the field
+// at the given path is renamed.
+func (f FieldConstraints) RenameField(path document.Path, newName string) (FieldConstraints, error) {
+	return f.alterField(path, func(fcs *FieldConstraints, field string) error {
+		if _, ok := fcs.ByField[newName]; ok {
+			return fmt.Errorf("field %q already exists", newName)
+		}
+
+		fc, ok := fcs.ByField[field]
+		if !ok {
+			return nil
+		}
+
+		delete(fcs.ByField, field)
+		fc.Field = newName
+		fcs.ByField[newName] = fc
+
+		return nil
+	})
+}
+
+// SetFieldType returns a copy of the field constraints where the field
+// at the given path has the given type.
+// If the field has no constraint, one is added.
+func (f FieldConstraints) SetFieldType(path document.Path, tp types.ValueType) (FieldConstraints, error) {
+	var found bool
+	fcs, err := f.alterField(path, func(fcs *FieldConstraints, field string) error {
+		found = true
+
+		fc, ok := fcs.ByField[field]
+		if !ok {
+			return fcs.Add(&FieldConstraint{Field: field, Type: tp})
+		}
+
+		fc.Type = tp
+		if tp != types.DocumentValue {
+			fc.AnonymousType = nil
+		}
+
+		return fc.checkDefaultValue()
+	})
+	if err != nil {
+		return fcs, err
+	}
+	if !found {
+		return fcs, fmt.Errorf("cannot set the type of field %q: its parent document has no constraints", path)
+	}
+
+	return fcs, nil
+}
+
+// alterField copies the field constraints down to the document containing the field
+// at the given path and calls fn with the copied constraints of that document.
+// It returns an error if the field cannot exist.
+func (f FieldConstraints) alterField(path document.Path, fn func(fcs *FieldConstraints, field string) error) (FieldConstraints, error) {
+	cp := FieldConstraints{
+		ByField:          make(map[string]*FieldConstraint),
+		AllowExtraFields: f.AllowExtraFields,
+	}
+
+	field := path[0].FieldName
+	if field == "" {
+		return cp, fmt.Errorf("invalid path %q: array elements cannot be altered", path)
+	}
+
+	fc, ok := f.ByField[field]
+	if !ok && !f.AllowExtraFields {
+		return cp, errors.Wrapf(types.ErrFieldNotFound, "field %q not found", path)
+	}
+
+	for _, fc := range f.Ordered {
+		c := *fc
+		cp.Ordered = append(cp.Ordered, &c)
+		cp.ByField[c.Field] = &c
+	}
+
+	if len(path) == 1 {
+		return cp, fn(&cp, field)
+	}
+
+	// fields of nested documents without constraints can be altered freely
+	if !ok || fc.AnonymousType == nil {
+		if ok && !fc.Type.IsAny() && fc.Type != types.DocumentValue {
+			return cp, errors.Wrapf(types.ErrFieldNotFound, "field %q not found", path)
+		}
+
+		return cp, nil
+	}
+
+	nested, err := fc.AnonymousType.FieldConstraints.alterField(path[1:], fn)
+	if err != nil {
+		return cp, err
+	}
+	cp.ByField[field].AnonymousType = &AnonymousType{FieldConstraints: nested}
+
+	return cp, nil
+}
+
 func (f FieldConstraints) convertDocumentAtPath(path document.Path, d types.Document, conversionFn ConversionFunc) (*document.FieldBuffer, error) {
 	fb, ok := d.(*document.FieldBuffer)
 	if !ok {
@@ -238,6 +378,7 @@ type TableConstraint struct {
 	Check      TableExpression
 	Unique     bool
 	PrimaryKey bool
+	ForeignKey *ForeignKey
 }
 
 func (t *TableConstraint) String() string {
@@ -259,6 +400,70 @@ func (t *TableConstraint) String() string {
 		sb.WriteString(" UNIQUE (")
 		sb.WriteString(t.Paths.String())
 		sb.WriteString(")")
+	case t.ForeignKey != nil:
+		sb.WriteString(" FOREIGN KEY (")
+		sb.WriteString(t.Paths.String())
+		sb.WriteString(") ")
+		sb.WriteString(t.ForeignKey.String())
+	}
+
+	return sb.String()
+}
+
+// ForeignKeyAction is the action performed on the referencing documents
+// when the document they reference is deleted or updated.
+type ForeignKeyAction uint8
+
+// Foreign key actions.
+const (
+	// ForeignKeyRestrict prevents the referenced document from being deleted or updated.
+	ForeignKeyRestrict ForeignKeyAction = iota
+	// ForeignKeyCascade deletes or updates the referencing documents.
+	ForeignKeyCascade
+	// ForeignKeySetNull sets the referencing paths to NULL.
+	ForeignKeySetNull
+)
+
+func (a ForeignKeyAction) String() string {
+	switch a {
+	case ForeignKeyCascade:
+		return "CASCADE"
+	case ForeignKeySetNull:
+		return "SET NULL"
+	}
+
+	return "RESTRICT"
+}
+
+// ForeignKey describes the documents referenced by a FOREIGN KEY constraint.
+type ForeignKey struct {
+	// Table is the name of the referenced table.
+	Table string
+	// Paths are the referenced paths. They must be the primary key
+	// of the referenced table or have a UNIQUE constraint.
+	// If empty, they default to the primary key of the referenced table.
+	Paths    document.Paths
+	OnDelete ForeignKeyAction
+	OnUpdate ForeignKeyAction
+}
+
+func (f *ForeignKey) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("REFERENCES ")
+	sb.WriteString(stringutil.NormalizeIdentifier(f.Table, '`'))
+	if len(f.Paths) > 0 {
+		sb.WriteString(" (")
+		sb.WriteString(f.Paths.String())
+		sb.WriteString(")")
+	}
+	if f.OnDelete != ForeignKeyRestrict {
+		sb.WriteString(" ON DELETE ")
+		sb.WriteString(f.OnDelete.String())
+	}
+	if f.OnUpdate != ForeignKeyRestrict {
+		sb.WriteString(" ON UPDATE ")
+		sb.WriteString(f.OnUpdate.String())
 	}
 
 	return sb.String()
@@ -286,6 +491,8 @@ func (t *TableConstraints) ValidateDocument(tx *Transaction, d types.Document) e
 			ok = types.As[int64](v) != 0
 		case types.DoubleValue:
 			ok = types.As[float64](v) != 0
+		case types.DecimalValue:
+			ok = types.As[types.Decimal](v).Sign() != 0
 		case types.NullValue:
 			ok = true
 		}
@@ -339,12 +546,26 @@ func (an *AnonymousType) String() string {
 
 type ConstraintViolationError struct {
 	Constraint string
-	Paths      []document.Path
-	Key        *tree.Key
+	// Name of the violated constraint, if any.
+	Name  string
+	Paths []document.Path
+	// Text of the indexed paths and expressions, if the constraint
+	// is enforced by an index on expressions.
+	Exprs []string
+	Key   *tree.Key
 }
 
 func (c ConstraintViolationError) Error() string {
-	return fmt.Sprintf("%s constraint error: %s", c.Constraint, c.Paths)
+	var cols interface{} = c.Paths
+	if c.Exprs != nil {
+		cols = c.Exprs
+	}
+
+	if c.Name != "" {
+		return fmt.Sprintf("%s constraint %q error: %s", c.Constraint, c.Name, cols)
+	}
+
+	return fmt.Sprintf("%s constraint error: %s", c.Constraint, cols)
 }
 
 func IsConstraintViolationError(err error) bool {
@@ -59,6 +59,23 @@ func encodeDocument(tx *Transaction, dst []byte, fcs *FieldConstraints, d types.
 			}
 		}
 
+		// ensure decimals fit in the precision and scale of the field
+		if fc.Precision != 0 && v.Type() == types.DecimalValue {
+			x, err := types.As[types.Decimal](v).Fit(fc.Precision, fc.Scale)
+			if err != nil {
+				return nil, err
+			}
+			v = types.NewDecimalValue(x)
+		}
+
+		// truncate dates to midnight
+		if fc.IsDate {
+			v, err = document.CastAsDate(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// Encode the value only.
 		if v.Type() == types.DocumentValue {
 			// encode map length
@@ -190,6 +207,12 @@ func (e *EncodedDocument) decodeValue(fc *FieldConstraint, b []byte) (types.Valu
 		}
 	}
 
+	// decimals are encoded without their trailing zeros,
+	// restore the scale of the field
+	if fc.Precision != 0 && v.Type() == types.DecimalValue {
+		v = types.NewDecimalValue(types.As[types.Decimal](v).Round(fc.Scale))
+	}
+
 	return v, n, nil
 }
 
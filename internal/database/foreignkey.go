@@ -0,0 +1,558 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// checkForeignKeys ensures the foreign keys of the table reference
+// the primary key or a UNIQUE constraint of an existing table.
+// Foreign keys without referenced paths are set to reference the primary key.
+func (c *Catalog) checkForeignKeys(info *TableInfo) error {
+	for _, tc := range info.TableConstraints {
+		fk := tc.ForeignKey
+		if fk == nil {
+			continue
+		}
+
+		parent := info
+		if fk.Table != info.TableName {
+			var err error
+			parent, err = c.GetTableInfo(fk.Table)
+			if err != nil {
+				return errors.Wrapf(err, "cannot create foreign key %s", tc.Name)
+			}
+		}
+
+		if len(fk.Paths) == 0 {
+			pk := parent.GetPrimaryKey()
+			if pk == nil {
+				return fmt.Errorf("foreign key %s must specify the referenced paths: table %s has no primary key", tc.Name, parent.TableName)
+			}
+
+			fk.Paths = pk.Paths
+		}
+
+		if len(fk.Paths) != len(tc.Paths) {
+			return fmt.Errorf("foreign key %s must reference %d paths", tc.Name, len(tc.Paths))
+		}
+
+		if !parent.HasUniqueConstraint(fk.Paths) {
+			return fmt.Errorf("foreign key %s must reference the primary key or a unique constraint of table %s", tc.Name, parent.TableName)
+		}
+	}
+
+	return nil
+}
+
+// checkNoReferencingTables returns an error if another table has a foreign key
+// referencing the given table.
+func (c *Catalog) checkNoReferencingTables(action, tableName string) error {
+	for _, ref := range c.Cache.GetReferencingConstraints(tableName) {
+		if ref.Table.TableName != tableName {
+			return fmt.Errorf("cannot %s %s because foreign key %s of table %s references it", action, tableName, ref.Constraint.Name, ref.Table.TableName)
+		}
+	}
+
+	return nil
+}
+
+// renameForeignKeyReferences updates the foreign keys referencing the renamed table.
+func (c *Catalog) renameForeignKeyReferences(tx *Transaction, oldName, newName string) error {
+	clones := make(map[string]*TableInfo)
+	var names []string
+	for _, ref := range c.Cache.GetReferencingConstraints(oldName) {
+		clone, ok := clones[ref.Table.TableName]
+		if !ok {
+			clone = ref.Table.Clone()
+			clones[ref.Table.TableName] = clone
+			names = append(names, ref.Table.TableName)
+		}
+
+		for i, tc := range clone.TableConstraints {
+			if tc != ref.Constraint {
+				continue
+			}
+
+			tcClone := *tc
+			fk := *tc.ForeignKey
+			fk.Table = newName
+			tcClone.ForeignKey = &fk
+			clone.TableConstraints[i] = &tcClone
+		}
+	}
+
+	for _, name := range names {
+		cloneRel := &TableInfoRelation{Info: clones[name]}
+		err := c.Cache.Replace(tx, cloneRel)
+		if err != nil {
+			return err
+		}
+
+		err = c.CatalogTable.Replace(tx, name, cloneRel)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateForeignKeys ensures the documents referenced by d exist.
+// Foreign keys with a NULL value are not checked.
+func (c *Catalog) ValidateForeignKeys(tx *Transaction, info *TableInfo, d types.Document) error {
+	for _, tc := range info.TableConstraints {
+		fk := tc.ForeignKey
+		if fk == nil {
+			continue
+		}
+
+		vs, ok := valuesAtPaths(d, tc.Paths)
+		if !ok {
+			continue
+		}
+
+		parent, err := c.GetTable(tx, fk.Table)
+		if err != nil {
+			return err
+		}
+
+		found, err := c.referencedDocumentExists(parent, fk.Paths, vs)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return &ConstraintViolationError{
+				Constraint: "FOREIGN KEY",
+				Name:       tc.Name,
+				Paths:      tc.Paths,
+			}
+		}
+	}
+
+	return nil
+}
+
+// referencedDocumentExists returns whether the table contains a document
+// with the given values at the given paths, which are its primary key
+// or have a UNIQUE constraint.
+func (c *Catalog) referencedDocumentExists(t *Table, paths document.Paths, vs []types.Value) (bool, error) {
+	vs, err := convertValuesAtPaths(&t.Info.FieldConstraints, paths, vs)
+	if err != nil {
+		// the values cannot be stored in the referenced table
+		return false, nil
+	}
+
+	if pk := t.Info.GetPrimaryKey(); pk != nil && pk.Paths.IsEqual(paths) {
+		return t.Tree.Exists(tree.NewKey(vs...))
+	}
+
+	for _, info := range c.Cache.GetTableIndexes(t.Info.TableName) {
+		// partial indexes don't contain every document of the table
+		if !info.Unique || info.Predicate != nil || !document.Paths(info.Paths).IsEqual(paths) {
+			continue
+		}
+
+		idx, err := c.GetIndex(t.Tx, info.IndexName)
+		if err != nil {
+			return false, err
+		}
+
+		found, _, err := idx.Exists(vs)
+		return found, err
+	}
+
+	return false, errors.Errorf("no unique index found on table %s for %s", t.Info.TableName, paths)
+}
+
+// OnDelete performs the ON DELETE action of the foreign keys referencing
+// the document d, which was deleted from the table.
+// Like DML statements, the actions run the triggers of the modified tables.
+// triggerDepth is the number of triggers being executed by the statement.
+func (c *Catalog) OnDelete(db *Database, tx *Transaction, triggerDepth int, tableName string, d types.Document) error {
+	for _, ref := range c.Cache.GetReferencingConstraints(tableName) {
+		tc := ref.Constraint
+
+		vs, ok := valuesAtPaths(d, tc.ForeignKey.Paths)
+		if !ok {
+			continue
+		}
+
+		child, err := c.GetTable(tx, ref.Table.TableName)
+		if err != nil {
+			return err
+		}
+
+		keys, err := c.referencingKeys(child, tc.Paths, vs)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			switch tc.ForeignKey.OnDelete {
+			case ForeignKeyCascade:
+				err = c.deleteDocument(db, triggerDepth, child, key)
+			case ForeignKeySetNull:
+				err = c.setValues(db, triggerDepth, child, key, tc.Paths, nil)
+			default:
+				err = &ConstraintViolationError{
+					Constraint: "FOREIGN KEY",
+					Name:       tc.Name,
+					Paths:      tc.Paths,
+					Key:        key,
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// OnUpdate performs the ON UPDATE action of the foreign keys referencing
+// the document old, which was replaced by d in the table.
+// Like DML statements, the actions run the triggers of the modified tables.
+// triggerDepth is the number of triggers being executed by the statement.
+func (c *Catalog) OnUpdate(db *Database, tx *Transaction, triggerDepth int, tableName string, old, d types.Document) error {
+	for _, ref := range c.Cache.GetReferencingConstraints(tableName) {
+		tc := ref.Constraint
+
+		vs, ok := valuesAtPaths(old, tc.ForeignKey.Paths)
+		if !ok {
+			continue
+		}
+
+		newVs, ok := valuesAtPaths(d, tc.ForeignKey.Paths)
+		if ok && valuesAreEqual(vs, newVs) {
+			continue
+		}
+
+		child, err := c.GetTable(tx, ref.Table.TableName)
+		if err != nil {
+			return err
+		}
+
+		keys, err := c.referencingKeys(child, tc.Paths, vs)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			switch tc.ForeignKey.OnUpdate {
+			case ForeignKeyCascade:
+				if ok {
+					err = c.setValues(db, triggerDepth, child, key, tc.Paths, newVs)
+				} else {
+					err = c.setValues(db, triggerDepth, child, key, tc.Paths, nil)
+				}
+			case ForeignKeySetNull:
+				err = c.setValues(db, triggerDepth, child, key, tc.Paths, nil)
+			default:
+				err = &ConstraintViolationError{
+					Constraint: "FOREIGN KEY",
+					Name:       tc.Name,
+					Paths:      tc.Paths,
+					Key:        key,
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// referencingKeys returns the keys of the documents of the table
+// with the given values at the given paths.
+// It uses an index on the paths if there is one, otherwise it reads the whole table.
+func (c *Catalog) referencingKeys(t *Table, paths document.Paths, vs []types.Value) ([]*tree.Key, error) {
+	vs, err := convertValuesAtPaths(&t.Info.FieldConstraints, paths, vs)
+	if err != nil {
+		// the values cannot be stored in the referencing table
+		return nil, nil
+	}
+
+	if pk := t.Info.GetPrimaryKey(); pk != nil && pk.Paths.IsEqual(paths) {
+		key := tree.NewKey(vs...)
+		ok, err := t.Tree.Exists(key)
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		return []*tree.Key{key}, nil
+	}
+
+	var keys []*tree.Key
+	for _, info := range c.Cache.GetTableIndexes(t.Info.TableName) {
+		if info.Predicate != nil || !document.Paths(info.Paths).IsEqual(paths) {
+			continue
+		}
+
+		idx, err := c.GetIndex(t.Tx, info.IndexName)
+		if err != nil {
+			return nil, err
+		}
+
+		vk := tree.NewKey(vs...)
+		err = idx.IterateOnRange(&tree.Range{Min: vk, Max: vk}, false, func(key *tree.Key) error {
+			keys = append(keys, tree.NewEncodedKey(append([]byte{}, key.Encoded...)))
+			return nil
+		})
+		return keys, err
+	}
+
+	err = t.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
+		dvs, ok := valuesAtPaths(d, paths)
+		if ok && valuesAreEqual(vs, dvs) {
+			keys = append(keys, tree.NewEncodedKey(append([]byte{}, key.Encoded...)))
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// deleteDocument deletes the document and its index entries from the table,
+// then performs the ON DELETE action of the foreign keys referencing it.
+// The DELETE triggers of the table are run before and after the deletion.
+func (c *Catalog) deleteDocument(db *Database, triggerDepth int, t *Table, key *tree.Key) error {
+	d, err := t.GetDocument(key)
+	if errs.IsNotFoundError(err) {
+		// already deleted by another foreign key
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = c.runTriggers(db, triggerDepth, t, TriggerBefore, TriggerDelete, d, nil)
+	if err != nil {
+		return err
+	}
+
+	err = c.deleteIndexEntries(t, key, d)
+	if err != nil {
+		return err
+	}
+
+	err = t.Delete(key)
+	if err != nil {
+		return err
+	}
+
+	err = c.OnDelete(db, t.Tx, triggerDepth, t.Info.TableName, d)
+	if err != nil {
+		return err
+	}
+
+	return c.runTriggers(db, triggerDepth, t, TriggerAfter, TriggerDelete, d, nil)
+}
+
+// setValues sets the values at the given paths of the document, or NULL if vs is nil,
+// and updates its index entries, then performs the ON UPDATE action
+// of the foreign keys referencing it.
+// The UPDATE triggers of the table are run before and after the update.
+func (c *Catalog) setValues(db *Database, triggerDepth int, t *Table, key *tree.Key, paths document.Paths, vs []types.Value) error {
+	old, err := t.GetDocument(key)
+	if errs.IsNotFoundError(err) {
+		// already deleted by another foreign key
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fb := document.NewFieldBuffer()
+	err = fb.Copy(old)
+	if err != nil {
+		return err
+	}
+
+	for i, p := range paths {
+		v := types.NewNullValue()
+		if vs != nil {
+			v = vs[i]
+		}
+
+		err = fb.Set(p, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	// validate the document against the constraints of the table
+	enc, err := t.Info.EncodeDocument(t.Tx, nil, fb)
+	if err != nil {
+		return err
+	}
+	var d types.Document = NewEncodedDocument(&t.Info.FieldConstraints, enc)
+
+	err = t.Info.TableConstraints.ValidateDocument(t.Tx, d)
+	if err != nil {
+		return err
+	}
+
+	err = c.runTriggers(db, triggerDepth, t, TriggerBefore, TriggerUpdate, old, d)
+	if err != nil {
+		return err
+	}
+
+	err = c.deleteIndexEntries(t, key, old)
+	if err != nil {
+		return err
+	}
+
+	key, d, err = t.Update(key, d)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range c.Cache.GetTableIndexes(t.Info.TableName) {
+		ok, err := info.Matches(t.Tx, d)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		idx, err := c.GetIndex(t.Tx, info.IndexName)
+		if err != nil {
+			return err
+		}
+
+		entries, err := info.Entries(t.Tx, d)
+		if err != nil {
+			return err
+		}
+
+		for _, vs := range entries {
+			if info.Unique {
+				if !valuesHaveNull(vs) {
+					duplicate, dKey, err := idx.Exists(vs)
+					if err != nil {
+						return err
+					}
+					if duplicate {
+						return info.UniqueViolation(dKey)
+					}
+				}
+			}
+
+			err = idx.Set(vs, key.Encoded)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = c.OnUpdate(db, t.Tx, triggerDepth, t.Info.TableName, old, d)
+	if err != nil {
+		return err
+	}
+
+	return c.runTriggers(db, triggerDepth, t, TriggerAfter, TriggerUpdate, old, d)
+}
+
+// runTriggers runs the triggers of the table fired by the event at the given timing.
+func (c *Catalog) runTriggers(db *Database, triggerDepth int, t *Table, timing, event string, oldDoc, newDoc types.Document) error {
+	for _, trigger := range c.GetTableTriggers(t.Info.TableName, timing, event) {
+		err := trigger.Body.Run(db, t.Tx, c, triggerDepth, oldDoc, newDoc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Catalog) deleteIndexEntries(t *Table, key *tree.Key, d types.Document) error {
+	for _, info := range c.Cache.GetTableIndexes(t.Info.TableName) {
+		ok, err := info.Matches(t.Tx, d)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		idx, err := c.GetIndex(t.Tx, info.IndexName)
+		if err != nil {
+			return err
+		}
+
+		entries, err := info.Entries(t.Tx, d)
+		if err != nil {
+			return err
+		}
+
+		for _, vs := range entries {
+			err = idx.Delete(vs, key.Encoded)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// valuesHaveNull returns whether any of the values is NULL.
+func valuesHaveNull(vs []types.Value) bool {
+	for _, v := range vs {
+		if v.Type() == types.NullValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// valuesAtPaths returns the values of d at the given paths.
+// It returns false if any of them is missing or NULL.
+func valuesAtPaths(d types.Document, paths document.Paths) ([]types.Value, bool) {
+	vs := make([]types.Value, 0, len(paths))
+	for _, p := range paths {
+		v, err := p.GetValueFromDocument(d)
+		if err != nil || v.Type() == types.NullValue {
+			return nil, false
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, true
+}
+
+// convertValuesAtPaths converts the values to the types of the field constraints of the paths.
+func convertValuesAtPaths(fcs *FieldConstraints, paths document.Paths, vs []types.Value) ([]types.Value, error) {
+	converted := make([]types.Value, len(vs))
+	for i, v := range vs {
+		var err error
+		converted[i], err = fcs.ConvertValueAtPath(paths[i], v, CastConversion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return converted, nil
+}
+
+func valuesAreEqual(a, b []types.Value) bool {
+	for i := range a {
+		ok, err := types.IsEqual(a[i], b[i])
+		if err != nil || !ok {
+			return false
+		}
+	}
+
+	return true
+}
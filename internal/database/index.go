@@ -130,6 +130,21 @@ func (idx *Index) IterateOnRange(rng *tree.Range, reverse bool, fn func(key *tre
 	})
 }
 
+// IterateValuesOnRange does the same as IterateOnRange but also passes
+// the indexed values, decoded from the index entry, to fn.
+func (idx *Index) IterateValuesOnRange(rng *tree.Range, reverse bool, fn func(vs []types.Value, key *tree.Key) error) error {
+	return idx.Tree.IterateOnRange(rng, reverse, func(k *tree.Key, _ []byte) error {
+		values, err := k.Decode()
+		if err != nil {
+			return err
+		}
+
+		pk := tree.NewEncodedKey(types.As[[]byte](values[len(values)-1]))
+
+		return fn(values[:len(values)-1], pk)
+	})
+}
+
 func (idx *Index) iterateOnRange(rng *tree.Range, reverse bool, fn func(itmKey *tree.Key, key *tree.Key) error) error {
 	return idx.Tree.IterateOnRange(rng, reverse, idx.iterator(fn))
 }
@@ -127,6 +127,21 @@ func (ti *TableInfo) AddTableConstraint(newTc *TableConstraint) error {
 		if newTc.Name == "" {
 			newTc.Name = fmt.Sprintf("%s_%s_unique", ti.TableName, pathsToIndexName(newTc.Paths))
 		}
+	case newTc.ForeignKey != nil:
+		if len(newTc.Paths) == 0 {
+			return errors.New("foreign key requires at least one path")
+		}
+
+		// the referenced paths default to the primary key of the referenced table,
+		// which is checked by the catalog
+		if len(newTc.ForeignKey.Paths) > 0 && len(newTc.ForeignKey.Paths) != len(newTc.Paths) {
+			return errors.Errorf("foreign key on %q must reference %d paths", newTc.Paths, len(newTc.Paths))
+		}
+
+		// generate name if not provided
+		if newTc.Name == "" {
+			newTc.Name = fmt.Sprintf("%s_%s_fkey", ti.TableName, pathsToIndexName(newTc.Paths))
+		}
 	default:
 		return errors.New("invalid table constraint")
 	}
@@ -167,6 +182,29 @@ func (ti *TableInfo) GetPrimaryKey() *PrimaryKey {
 	return nil
 }
 
+// HasUniqueConstraint returns whether the paths are the primary key of the table
+// or have a UNIQUE constraint.
+func (ti *TableInfo) HasUniqueConstraint(paths document.Paths) bool {
+	for _, tc := range ti.TableConstraints {
+		if (tc.PrimaryKey || tc.Unique) && tc.Paths.IsEqual(paths) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasForeignKey returns whether the paths have a FOREIGN KEY constraint.
+func (ti *TableInfo) HasForeignKey(paths document.Paths) bool {
+	for _, tc := range ti.TableConstraints {
+		if tc.ForeignKey != nil && tc.Paths.IsEqual(paths) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (ti *TableInfo) GetFieldConstraintForPath(p document.Path) *FieldConstraint {
 	return ti.FieldConstraints.GetFieldConstraintForPath(p)
 }
@@ -240,6 +278,10 @@ type IndexInfo struct {
 	StoreNamespace tree.Namespace
 	IndexName      string
 	Paths          []document.Path
+	// If set, the values at the positions of the non nil expressions
+	// are the result of these expressions, and the corresponding paths are empty.
+	// i.e CREATE INDEX idx ON tbl(strings.lower(a))
+	Exprs []TableExpression
 
 	// If set to true, values will be associated with at most one key. False by default.
 	Unique bool
@@ -248,6 +290,12 @@ type IndexInfo struct {
 	// i.e CREATE TABLE tbl(a INT UNIQUE)
 	// The path refers to the path this index is related to.
 	Owner Owner
+
+	// If set, only the documents matching this expression are indexed.
+	// i.e CREATE INDEX idx ON tbl(a) WHERE b > 10
+	Predicate TableExpression
+	// ExprPaths are the paths used by the indexed expressions and the predicate.
+	ExprPaths document.Paths
 }
 
 // String returns a SQL representation.
@@ -261,17 +309,14 @@ func (i *IndexInfo) String() string {
 
 	fmt.Fprintf(&s, "INDEX %s ON %s (", stringutil.NormalizeIdentifier(i.IndexName, '`'), stringutil.NormalizeIdentifier(i.Owner.TableName, '`'))
 
-	for i, p := range i.Paths {
-		if i > 0 {
-			s.WriteString(", ")
-		}
+	s.WriteString(strings.Join(i.Columns(), ", "))
+	s.WriteString(")")
 
-		// Path
-		s.WriteString(p.String())
+	if i.Predicate != nil {
+		s.WriteString(" WHERE ")
+		s.WriteString(i.Predicate.String())
 	}
 
-	s.WriteString(")")
-
 	return s.String()
 }
 
@@ -284,9 +329,248 @@ func (i IndexInfo) Clone() *IndexInfo {
 		c.Paths[i] = p.Clone()
 	}
 
+	if i.Exprs != nil {
+		c.Exprs = append([]TableExpression{}, i.Exprs...)
+	}
+
+	if i.ExprPaths != nil {
+		c.ExprPaths = make(document.Paths, len(i.ExprPaths))
+		for i, p := range i.ExprPaths {
+			c.ExprPaths[i] = p.Clone()
+		}
+	}
+
 	return &c
 }
 
+// Matches returns whether the document must be stored in the index.
+// Documents for which the predicate is falsy or NULL are not indexed.
+func (i *IndexInfo) Matches(tx *Transaction, d types.Document) (bool, error) {
+	if i.Predicate == nil {
+		return true, nil
+	}
+
+	v, err := i.Predicate.Eval(tx, d)
+	if err != nil {
+		return false, err
+	}
+
+	return types.IsTruthy(v)
+}
+
+// Entries returns the list of values of d stored in the index.
+// Missing values are replaced by NULL.
+// Multikey indexes, i.e. CREATE INDEX idx ON tbl(a[*]), store one entry
+// per distinct element of the array: documents where the array is empty
+// are not indexed, and documents where the value is not an array
+// are indexed with a NULL element.
+func (i *IndexInfo) Entries(tx *Transaction, d types.Document) ([][]types.Value, error) {
+	vs, err := i.values(tx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	j := i.MultikeyPos()
+	if j < 0 {
+		return [][]types.Value{vs}, nil
+	}
+
+	if vs[j].Type() != types.ArrayValue {
+		vs[j] = types.NewNullValue()
+		return [][]types.Value{vs}, nil
+	}
+
+	var entries [][]types.Value
+	err = types.As[types.Array](vs[j]).Iterate(func(_ int, elem types.Value) error {
+		// elements with the same type and value would produce the same key
+		for _, entry := range entries {
+			if entry[j].Type() != elem.Type() {
+				continue
+			}
+
+			ok, err := types.IsEqual(entry[j], elem)
+			if err != nil || ok {
+				return err
+			}
+		}
+
+		entry := make([]types.Value, len(vs))
+		copy(entry, vs)
+		entry[j] = elem
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// MultikeyPos returns the position of the path indexing the elements
+// of an array, or -1 if the index is not a multikey index.
+func (i *IndexInfo) MultikeyPos() int {
+	for j, p := range i.Paths {
+		if _, ok := p.ElementsOf(); ok {
+			return j
+		}
+	}
+
+	return -1
+}
+
+func (i *IndexInfo) values(tx *Transaction, d types.Document) ([]types.Value, error) {
+	vs := make([]types.Value, 0, len(i.Paths))
+	for j, path := range i.Paths {
+		e := i.ExprAt(j)
+		if e == nil {
+			if ap, ok := path.ElementsOf(); ok {
+				path = ap
+			}
+
+			v, err := path.GetValueFromDocument(d)
+			if err != nil {
+				v = types.NewNullValue()
+			}
+			vs = append(vs, v)
+			continue
+		}
+
+		v, err := e.Eval(tx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		// the type of the result of an expression is not known in advance:
+		// like for fields without type constraints, integers are stored as doubles
+		// to be compared with the operands of the queries using the index.
+		if v.Type() == types.IntegerValue {
+			v, err = document.CastAsDouble(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, nil
+}
+
+// Columns returns the text of the indexed paths and expressions.
+func (i *IndexInfo) Columns() []string {
+	cols := make([]string, len(i.Paths))
+	for j, p := range i.Paths {
+		if e := i.ExprAt(j); e != nil {
+			cols[j] = e.String()
+			continue
+		}
+
+		cols[j] = p.String()
+	}
+
+	return cols
+}
+
+// UniqueViolation returns the error reported when a document
+// has the same indexed values as the document stored under key.
+func (i *IndexInfo) UniqueViolation(key *tree.Key) *ConstraintViolationError {
+	err := ConstraintViolationError{
+		Constraint: "UNIQUE",
+		Paths:      i.Paths,
+		Key:        key,
+	}
+
+	// the paths of indexed expressions are empty
+	if i.Exprs != nil {
+		err.Exprs = i.Columns()
+	}
+
+	return &err
+}
+
+// ExprAt returns the expression indexed at position j, if any.
+func (i *IndexInfo) ExprAt(j int) TableExpression {
+	if j >= len(i.Exprs) {
+		return nil
+	}
+
+	return i.Exprs[j]
+}
+
+// ViewInfo holds the configuration of a view.
+type ViewInfo struct {
+	ViewName string
+	Query    ViewQuery
+}
+
+// A ViewQuery is the SELECT statement of a view.
+type ViewQuery interface {
+	// Relations returns the names of the tables and views
+	// the query reads from.
+	Relations() []string
+	String() string
+}
+
+// String returns a SQL representation.
+func (v *ViewInfo) String() string {
+	return fmt.Sprintf("CREATE VIEW %s AS %s", stringutil.NormalizeIdentifier(v.ViewName, '`'), v.Query)
+}
+
+// Clone returns a copy of the view information.
+func (v ViewInfo) Clone() *ViewInfo {
+	return &v
+}
+
+// Trigger timings.
+const (
+	TriggerBefore = "BEFORE"
+	TriggerAfter  = "AFTER"
+)
+
+// Trigger events.
+const (
+	TriggerInsert = "INSERT"
+	TriggerUpdate = "UPDATE"
+	TriggerDelete = "DELETE"
+)
+
+// TriggerInfo holds the configuration of a trigger.
+type TriggerInfo struct {
+	TriggerName string
+	TableName   string
+	// Timing is either TriggerBefore or TriggerAfter.
+	Timing string
+	// Event is one of TriggerInsert, TriggerUpdate or TriggerDelete.
+	Event string
+	Body  TriggerBody
+}
+
+// A TriggerBody contains the statements executed by a trigger.
+type TriggerBody interface {
+	// Run executes the statements for the row that fired the trigger.
+	// depth is the number of triggers being executed by the statement that fired it.
+	// oldDoc is nil for INSERT triggers and newDoc is nil for DELETE triggers.
+	Run(db *Database, tx *Transaction, catalog *Catalog, depth int, oldDoc, newDoc types.Document) error
+	String() string
+}
+
+// String returns a SQL representation.
+func (t *TriggerInfo) String() string {
+	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW BEGIN %s; END",
+		stringutil.NormalizeIdentifier(t.TriggerName, '`'),
+		t.Timing,
+		t.Event,
+		stringutil.NormalizeIdentifier(t.TableName, '`'),
+		t.Body,
+	)
+}
+
+// Clone returns a copy of the trigger information.
+func (t TriggerInfo) Clone() *TriggerInfo {
+	return &t
+}
+
 // SequenceInfo holds the configuration of a sequence.
 type SequenceInfo struct {
 	Name        string
@@ -64,10 +64,31 @@ func (r *Range) Convert(constraints *FieldConstraints, v types.Value, p document
 	// if a number is encountered, try to convert it to the right type if and only if the conversion
 	// is lossless.
 	v, err := constraints.ConvertValueAtPath(p, v, func(v types.Value, path document.Path, targetType types.ValueType) (types.Value, error) {
+		if (v.Type() == types.IntegerValue || v.Type() == types.DoubleValue) && targetType == types.DecimalValue {
+			return document.CastAsDecimal(v)
+		}
+
+		if v.Type() == types.DecimalValue && (targetType == types.IntegerValue || targetType == types.DoubleValue) {
+			var err error
+			v, err = document.CastAsDouble(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if v.Type() == types.IntegerValue && targetType == types.DoubleValue {
 			return document.CastAsDouble(v)
 		}
 
+		// texts are compared to timestamps as timestamps,
+		// texts that don't represent a timestamp don't match any
+		if v.Type() == types.TextValue && targetType == types.TimestampValue {
+			if t, err := document.CastAsTimestamp(v); err == nil {
+				return t, nil
+			}
+			return v, nil
+		}
+
 		if v.Type() == types.DoubleValue && targetType == types.IntegerValue {
 			f := types.As[float64](v)
 			if float64(int64(f)) == f {
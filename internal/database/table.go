@@ -1,6 +1,7 @@
 package database
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/cockroachdb/errors"
@@ -65,6 +66,35 @@ func (t *Table) Insert(d types.Document) (*tree.Key, types.Document, error) {
 	return key, d, nil
 }
 
+// ValidatePrimaryKey returns a constraint violation error if the table
+// already contains a document with the same primary key as d.
+// Tables without a primary key never contain such a document.
+func (t *Table) ValidatePrimaryKey(d types.Document) error {
+	pk := t.Info.GetPrimaryKey()
+	if pk == nil {
+		return nil
+	}
+
+	key, err := t.generateKey(t.Info, d)
+	if err != nil {
+		return err
+	}
+
+	ok, err := t.Tree.Exists(key)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return &ConstraintViolationError{
+			Constraint: "PRIMARY KEY",
+			Paths:      pk.Paths,
+			Key:        key,
+		}
+	}
+
+	return nil
+}
+
 func (t *Table) encodeDocument(d types.Document) (types.Document, []byte, error) {
 	ed, ok := d.(*EncodedDocument)
 	if ok {
@@ -118,6 +148,42 @@ func (t *Table) Replace(key *tree.Key, d types.Document) (types.Document, error)
 	return d, err
 }
 
+// Update replaces the document stored at key by d.
+// If d has a different primary key, the document is moved to its new key.
+// It returns the key and the document stored.
+func (t *Table) Update(key *tree.Key, d types.Document) (*tree.Key, types.Document, error) {
+	if t.Info.GetPrimaryKey() == nil {
+		d, err := t.Replace(key, d)
+		return key, d, err
+	}
+
+	newKey, err := t.generateKey(t.Info, d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	old, err := key.Encode(t.Tree.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	enc, err := newKey.Encode(t.Tree.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if bytes.Equal(old, enc) {
+		d, err := t.Replace(key, d)
+		return key, d, err
+	}
+
+	err = t.Delete(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return t.Insert(d)
+}
+
 func (t *Table) IterateOnRange(rng *Range, reverse bool, fn func(key *tree.Key, d types.Document) error) error {
 	var paths []document.Path
 
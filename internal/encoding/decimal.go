@@ -0,0 +1,110 @@
+package encoding
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+
+	"github.com/genjidb/genji/types"
+)
+
+const (
+	decimalNegative byte = 0x00
+	decimalZero     byte = 0x01
+	decimalPositive byte = 0x02
+
+	// terminates the digits of negative decimals, so that
+	// longer negative decimals sort before their prefixes.
+	decimalNegativeEnd byte = 0xFF
+)
+
+// EncodeDecimal encodes a decimal in a way that preserves the ordering
+// of decimals when compared with bytes.Compare.
+// Trailing zeros are ignored, which means that decimals that are numerically
+// equal are encoded the same way, regardless of their scale.
+//
+// The decimal is represented as 0.d1d2...dn * 10^e, with d1 != 0. Its payload is made of:
+// - a sign byte
+// - the exponent e, on 4 bytes with the sign bit flipped
+// - the digits, one per byte
+// For negative decimals, the exponent and the digits are inverted and followed
+// by a terminator.
+// The payload is prefixed with its length to allow skipping it.
+func EncodeDecimal(dst []byte, x types.Decimal) []byte {
+	var payload []byte
+
+	x = x.Normalize()
+	if x.Sign() == 0 {
+		payload = []byte{decimalZero}
+	} else {
+		digits := new(big.Int).Abs(x.Unscaled()).String()
+		exp := uint32(int32(int64(len(digits))-int64(x.Scale()))) ^ (1 << 31)
+
+		payload = make([]byte, 0, 6+len(digits))
+		if x.Sign() > 0 {
+			payload = append(payload, decimalPositive)
+			payload = append(payload, byte(exp>>24), byte(exp>>16), byte(exp>>8), byte(exp))
+			for i := 0; i < len(digits); i++ {
+				payload = append(payload, digits[i]-'0')
+			}
+		} else {
+			payload = append(payload, decimalNegative)
+			exp = ^exp
+			payload = append(payload, byte(exp>>24), byte(exp>>16), byte(exp>>8), byte(exp))
+			for i := 0; i < len(digits); i++ {
+				payload = append(payload, '9'-digits[i])
+			}
+			payload = append(payload, decimalNegativeEnd)
+		}
+	}
+
+	buf := make([]byte, binary.MaxVarintLen64+1)
+	buf[0] = DecimalValue
+	n := binary.PutUvarint(buf[1:], uint64(len(payload)))
+
+	dst = append(dst, buf[:n+1]...)
+	return append(dst, payload...)
+}
+
+// DecodeDecimal decodes a decimal encoded with EncodeDecimal.
+// The returned decimal has no trailing zeros.
+func DecodeDecimal(b []byte) (types.Decimal, int) {
+	// skip type
+	b = b[1:]
+	// decode the length as a varint
+	l, n := binary.Uvarint(b)
+	size := 1 + n + int(l)
+	b = b[n : n+int(l)]
+
+	if b[0] == decimalZero {
+		return types.NewDecimalFromInt(0), size
+	}
+
+	exp := binary.BigEndian.Uint32(b[1:5])
+	digits := b[5:]
+	if b[0] == decimalNegative {
+		exp = ^exp
+		digits = digits[:len(digits)-1]
+	}
+	e := int64(int32(exp ^ (1 << 31)))
+
+	u := new(big.Int)
+	d := new(big.Int)
+	for _, c := range digits {
+		if b[0] == decimalNegative {
+			c = 9 - c
+		}
+		u.Mul(u, big.NewInt(10))
+		u.Add(u, d.SetUint64(uint64(c)))
+	}
+	if b[0] == decimalNegative {
+		u.Neg(u)
+	}
+
+	scale := int64(len(digits)) - e
+	if scale < math.MinInt32 || scale > math.MaxInt32 {
+		panic("decimal scale out of range")
+	}
+
+	return types.NewDecimal(u, int32(scale)), size
+}
@@ -0,0 +1,64 @@
+package encoding_test
+
+import (
+	"testing"
+
+	"github.com/genjidb/genji/internal/encoding"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeDecimal(t *testing.T) {
+	// sorted from the smallest to the largest
+	tests := []string{
+		"-1e30",
+		"-123.45",
+		"-12.345",
+		"-12.34",
+		"-1",
+		"-0.1",
+		"-0.01",
+		"0",
+		"0.0001",
+		"0.1",
+		"0.11",
+		"0.2",
+		"1",
+		"1.5",
+		"10",
+		"123456789012345678901234567890.123",
+	}
+
+	var prev []byte
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			d, err := types.ParseDecimal(test)
+			require.NoError(t, err)
+
+			got := encoding.EncodeDecimal(nil, d)
+			require.Equal(t, encoding.DecimalValue, got[0])
+			require.Equal(t, len(got), encoding.Skip(got))
+
+			x, n := encoding.DecodeDecimal(got)
+			require.Equal(t, len(got), n)
+			require.Equal(t, 0, d.Cmp(x))
+
+			// encoded decimals must preserve ordering
+			if prev != nil {
+				require.Less(t, encoding.Compare(prev, got), 0)
+				require.Greater(t, encoding.Compare(got, prev), 0)
+			}
+			prev = got
+		})
+	}
+}
+
+func TestEncodeDecimalScale(t *testing.T) {
+	// decimals that are numerically equal must be encoded the same way
+	a, err := types.ParseDecimal("1.5")
+	require.NoError(t, err)
+	b, err := types.ParseDecimal("1.5000")
+	require.NoError(t, err)
+
+	require.Equal(t, encoding.EncodeDecimal(nil, a), encoding.EncodeDecimal(nil, b))
+}
@@ -2,6 +2,7 @@ package encoding
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/genjidb/genji/types"
 )
@@ -36,6 +37,10 @@ func EncodeValue(dst []byte, v types.Value) ([]byte, error) {
 		return EncodeInt(dst, types.As[int64](v)), nil
 	case types.DoubleValue:
 		return EncodeFloat64(dst, types.As[float64](v)), nil
+	case types.DecimalValue:
+		return EncodeDecimal(dst, types.As[types.Decimal](v)), nil
+	case types.TimestampValue:
+		return EncodeTimestamp(dst, types.As[time.Time](v)), nil
 	case types.TextValue:
 		return EncodeText(dst, types.As[string](v)), nil
 	case types.BlobValue:
@@ -74,6 +79,12 @@ func DecodeValue(b []byte, intAsDouble bool) (types.Value, int) {
 	case Float64Value:
 		x := DecodeFloat64(b[1:])
 		return types.NewDoubleValue(x), 9
+	case DecimalValue:
+		x, n := DecodeDecimal(b)
+		return types.NewDecimalValue(x), n
+	case TimestampValue:
+		x := DecodeTimestamp(b[1:])
+		return types.NewTimestampValue(x), 9
 	case TextValue:
 		x, n := DecodeText(b)
 		return types.NewTextValue(x), n
@@ -55,9 +55,9 @@ func Skip(b []byte) int {
 		return 3
 	case Int32Value, Uint32Value, Float32Value:
 		return 5
-	case Int64Value, Uint64Value, Float64Value:
+	case Int64Value, Uint64Value, Float64Value, TimestampValue:
 		return 9
-	case TextValue, BlobValue:
+	case TextValue, BlobValue, DecimalValue:
 		l, n := binary.Uvarint(b[1:])
 		return n + int(l) + 1
 	case ArrayValue:
@@ -166,7 +166,7 @@ func compareNextValue(a, b []byte) (cmp int, n int) {
 
 	// compare non empty values
 	switch a[0] {
-	case Int64Value, Uint64Value, Float64Value:
+	case Int64Value, Uint64Value, Float64Value, TimestampValue:
 		return bytes.Compare(a[1:9], b[1:9]), 9
 	case Int32Value, Uint32Value, Float32Value:
 		return bytes.Compare(a[1:5], b[1:5]), 5
@@ -182,6 +182,12 @@ func compareNextValue(a, b []byte) (cmp int, n int) {
 		n++
 		endb := n + int(l)
 		return bytes.Compare(a[n:enda], b[n:endb]), enda
+	case DecimalValue:
+		l, na := binary.Uvarint(a[1:])
+		enda := 1 + na + int(l)
+		l, nb := binary.Uvarint(b[1:])
+		endb := 1 + nb + int(l)
+		return bytes.Compare(a[1+na:enda], b[1+nb:endb]), enda
 	case ArrayValue:
 		la, _ := binary.Uvarint(a[1:])
 		lb, n := binary.Uvarint(b[1:])
@@ -320,10 +326,10 @@ func abbreviatedValue(key []byte) uint64 {
 	case Uint32Value, Int32Value:
 		x := DecodeUint32(key[1:])
 		return uint64(x)
-	case Uint64Value, Int64Value, Float64Value:
+	case Uint64Value, Int64Value, Float64Value, TimestampValue:
 		x := DecodeUint64(key[1:])
 		return uint64(x) >> 24
-	case TextValue, BlobValue:
+	case TextValue, BlobValue, DecimalValue:
 		var abbv uint64
 		l, n := binary.Uvarint(key[1:])
 		n++
@@ -0,0 +1,21 @@
+package encoding
+
+import (
+	"math"
+	"time"
+)
+
+// EncodeTimestamp encodes the number of microseconds elapsed since
+// the Unix epoch. Like integers, the sign bit is flipped to preserve
+// the ordering of timestamps before the epoch.
+func EncodeTimestamp(dst []byte, t time.Time) []byte {
+	return write8(dst, TimestampValue, uint64(t.UnixMicro())+math.MaxInt64+1)
+}
+
+// DecodeTimestamp decodes a timestamp encoded with EncodeTimestamp.
+// The type byte must not be included.
+func DecodeTimestamp(b []byte) time.Time {
+	x := DecodeUint64(b)
+	x -= math.MaxInt64 + 1
+	return time.UnixMicro(int64(x)).UTC()
+}
@@ -0,0 +1,40 @@
+package encoding_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/genjidb/genji/internal/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeTimestamp(t *testing.T) {
+	tests := []time.Time{
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 999999000, time.UTC),
+		time.Unix(0, 0).UTC(),
+		time.Date(2023, 3, 15, 10, 20, 30, 123456000, time.UTC),
+		time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC),
+	}
+
+	var prev []byte
+	for _, test := range tests {
+		t.Run(test.String(), func(t *testing.T) {
+			got := encoding.EncodeTimestamp(nil, test)
+			require.Len(t, got, 9)
+			require.Equal(t, encoding.TimestampValue, got[0])
+
+			x := encoding.DecodeTimestamp(got[1:])
+			require.Equal(t, test, x)
+
+			// encoded timestamps must preserve ordering
+			if prev != nil {
+				require.Equal(t, -1, bytes.Compare(prev, got))
+				require.Less(t, encoding.Compare(prev, got), 0)
+			}
+			prev = got
+		})
+	}
+}
@@ -11,6 +11,9 @@ import (
 
 var (
 	TableKey = document.Path{document.PathFragment{FieldName: "$table"}}
+	// AliasesKey holds the list of the aliases of the tables
+	// stored in the document when tables are joined.
+	AliasesKey = document.Path{document.PathFragment{FieldName: "$aliases"}}
 )
 
 // A Param represents a parameter passed by the user to the statement.
@@ -32,8 +35,20 @@ type Environment struct {
 	DB      *database.Database
 	Catalog *database.Catalog
 	Tx      *database.Transaction
+	// TriggerDepth is the number of triggers being executed
+	// by the statement.
+	TriggerDepth int
 
 	Outer *Environment
+
+	// isScope is true if the environment is the root
+	// of a nested query, such as a subquery.
+	isScope bool
+	// readsOuter is set to true when a scope accesses
+	// the documents of its outer environment.
+	readsOuter bool
+	// cache stores values that are computed once per statement.
+	cache map[interface{}]types.Value
 }
 
 func New(d types.Document, params ...Param) *Environment {
@@ -45,6 +60,75 @@ func New(d types.Document, params ...Param) *Environment {
 	return &env
 }
 
+// NewScope creates an environment used to evaluate a nested query, such as a subquery.
+// The documents of the nested query take precedence over the ones of the outer environment,
+// which remain accessible using the GetOuterScope method.
+func NewScope(outer *Environment) *Environment {
+	return &Environment{
+		Outer:   outer,
+		isScope: true,
+	}
+}
+
+// GetOuterScope returns the outer environment of the closest scope.
+// It returns false if the environment is not part of a scope.
+// Reading from the outer environment must be reported using SetReadsOuter.
+func (e *Environment) GetOuterScope() (*Environment, bool) {
+	for env := e; env != nil; env = env.Outer {
+		if env.isScope {
+			return env.Outer, env.Outer != nil
+		}
+	}
+
+	return nil, false
+}
+
+// SetReadsOuter marks the closest scope as reading from its outer environment.
+func (e *Environment) SetReadsOuter() {
+	for env := e; env != nil; env = env.Outer {
+		if env.isScope {
+			env.readsOuter = true
+			return
+		}
+	}
+}
+
+// ReadsOuter returns true if the documents or keys of the outer environment
+// were accessed from this scope.
+func (e *Environment) ReadsOuter() bool {
+	return e.readsOuter
+}
+
+// GetCachedValue returns a value cached for the duration of the statement.
+func (e *Environment) GetCachedValue(key interface{}) (types.Value, bool) {
+	root := e.root()
+	if root.cache == nil {
+		return nil, false
+	}
+
+	v, ok := root.cache[key]
+	return v, ok
+}
+
+// SetCachedValue caches a value for the duration of the statement.
+func (e *Environment) SetCachedValue(key interface{}, v types.Value) {
+	root := e.root()
+	if root.cache == nil {
+		root.cache = make(map[interface{}]types.Value)
+	}
+
+	root.cache[key] = v
+}
+
+func (e *Environment) root() *Environment {
+	env := e
+	for env.Outer != nil {
+		env = env.Outer
+	}
+
+	return env
+}
+
 func (e *Environment) GetOuter() *Environment {
 	return e.Outer
 }
@@ -68,6 +152,26 @@ func (e *Environment) Get(path document.Path) (v types.Value, ok bool) {
 	return types.NewNullValue(), false
 }
 
+// GetAliases returns the aliases of the joined tables stored in the document
+// of the current query. Unlike Get, it doesn't look beyond the closest scope,
+// the aliases of an outer query don't apply to the documents of a subquery.
+func (e *Environment) GetAliases() (types.Array, bool) {
+	for env := e; env != nil; env = env.Outer {
+		if env.Vars != nil {
+			v, err := AliasesKey.GetValueFromDocument(env.Vars)
+			if err == nil && v.Type() == types.ArrayValue {
+				return types.As[types.Array](v), true
+			}
+		}
+
+		if env.isScope {
+			break
+		}
+	}
+
+	return nil, false
+}
+
 func (e *Environment) Set(path document.Path, v types.Value) {
 	if e.Vars == nil {
 		e.Vars = document.NewFieldBuffer()
@@ -82,6 +186,9 @@ func (e *Environment) GetDocument() (types.Document, bool) {
 	}
 
 	if e.Outer != nil {
+		if e.isScope {
+			e.readsOuter = true
+		}
 		return e.Outer.GetDocument()
 	}
 
@@ -98,6 +205,9 @@ func (e *Environment) GetKey() (*tree.Key, bool) {
 	}
 
 	if e.Outer != nil {
+		if e.isScope {
+			e.readsOuter = true
+		}
 		return e.Outer.GetKey()
 	}
 
@@ -177,3 +287,8 @@ func (e *Environment) GetCatalog() *database.Catalog {
 
 	return nil
 }
+
+// GetTriggerDepth returns the number of triggers being executed by the statement.
+func (e *Environment) GetTriggerDepth() int {
+	return e.root().TriggerDepth
+}
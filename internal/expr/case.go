@@ -0,0 +1,154 @@
+package expr
+
+import (
+	"strings"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// Case represents the CASE expression.
+// If Operand is nil, it is a searched CASE expression, which returns
+// the result of the first WHEN clause whose condition is truthy:
+//   CASE WHEN a > 10 THEN 'big' ELSE 'small' END
+// Otherwise, it is a simple CASE expression, which returns the result
+// of the first WHEN clause whose value is equal to the operand:
+//   CASE a WHEN 1 THEN 'one' WHEN 2 THEN 'two' END
+// If no clause matches, it returns the result of the ELSE clause, or NULL.
+type Case struct {
+	Operand Expr
+	Whens   []*When
+	Else    Expr
+}
+
+// A When is a WHEN ... THEN ... clause of a CASE expression.
+type When struct {
+	Cond Expr
+	Then Expr
+}
+
+// Eval evaluates the result of the first matching WHEN clause.
+func (c *Case) Eval(env *environment.Environment) (types.Value, error) {
+	var operand types.Value
+	if c.Operand != nil {
+		v, err := c.Operand.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+		operand = v
+	}
+
+	for _, w := range c.Whens {
+		v, err := w.Cond.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, err := c.Matches(operand, v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return w.Then.Eval(env)
+		}
+	}
+
+	if c.Else != nil {
+		return c.Else.Eval(env)
+	}
+
+	return NullLiteral, nil
+}
+
+// Matches reports whether the value of a WHEN condition selects its clause,
+// given the value of the operand, if any.
+// Like with the = operator, NULL never matches the operand of a simple CASE expression.
+func (c *Case) Matches(operand, v types.Value) (bool, error) {
+	if c.Operand == nil {
+		return types.IsTruthy(v)
+	}
+
+	if operand.Type() == types.NullValue || v.Type() == types.NullValue {
+		return false, nil
+	}
+
+	return types.IsEqual(operand, v)
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (c *Case) IsEqual(other Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Case)
+	if !ok {
+		return false
+	}
+
+	if len(c.Whens) != len(o.Whens) {
+		return false
+	}
+
+	if !equalOrNil(c.Operand, o.Operand) || !equalOrNil(c.Else, o.Else) {
+		return false
+	}
+
+	for i := range c.Whens {
+		if !Equal(c.Whens[i].Cond, o.Whens[i].Cond) || !Equal(c.Whens[i].Then, o.Whens[i].Then) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalOrNil(a, b Expr) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return Equal(a, b)
+}
+
+// Params returns the operand, the conditions and results of the WHEN clauses
+// and the ELSE clause, if any.
+func (c *Case) Params() []Expr {
+	var params []Expr
+
+	if c.Operand != nil {
+		params = append(params, c.Operand)
+	}
+	for _, w := range c.Whens {
+		params = append(params, w.Cond, w.Then)
+	}
+	if c.Else != nil {
+		params = append(params, c.Else)
+	}
+
+	return params
+}
+
+func (c *Case) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("CASE")
+	if c.Operand != nil {
+		sb.WriteString(" ")
+		sb.WriteString(c.Operand.String())
+	}
+	for _, w := range c.Whens {
+		sb.WriteString(" WHEN ")
+		sb.WriteString(w.Cond.String())
+		sb.WriteString(" THEN ")
+		sb.WriteString(w.Then.String())
+	}
+	if c.Else != nil {
+		sb.WriteString(" ELSE ")
+		sb.WriteString(c.Else.String())
+	}
+	sb.WriteString(" END")
+
+	return sb.String()
+}
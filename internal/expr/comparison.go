@@ -3,6 +3,7 @@ package expr
 import (
 	"fmt"
 
+	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/sql/scanner"
@@ -22,12 +23,17 @@ func newCmpOp(a, b Expr, t scanner.Token) *cmpOp {
 // Eval compares a and b together using the operator specified when constructing the CmpOp
 // and returns the result of the comparison.
 // Comparing with NULL always evaluates to NULL.
+// A text compared to a timestamp is converted to a timestamp.
+// If one of the operands is a path ending with [*], the comparison is true if
+// it is true for at least one element of the array.
 func (op *cmpOp) Eval(env *environment.Environment) (types.Value, error) {
-	return op.simpleOperator.eval(env, func(a, b types.Value) (types.Value, error) {
+	return op.simpleOperator.evalAny(env, func(a, b types.Value) (types.Value, error) {
 		if a.Type() == types.NullValue || b.Type() == types.NullValue {
 			return NullLiteral, nil
 		}
 
+		a, b = convertTimestampOperands(a, b)
+
 		ok, err := op.compare(a, b)
 		if ok {
 			return TrueLiteral, err
@@ -37,6 +43,24 @@ func (op *cmpOp) Eval(env *environment.Environment) (types.Value, error) {
 	})
 }
 
+// convertTimestampOperands converts a text compared to a timestamp to a timestamp.
+// Texts that don't represent a timestamp are left unchanged and are therefore
+// different from any timestamp.
+func convertTimestampOperands(a, b types.Value) (types.Value, types.Value) {
+	switch {
+	case a.Type() == types.TimestampValue && b.Type() == types.TextValue:
+		if v, err := document.CastAsTimestamp(b); err == nil {
+			b = v
+		}
+	case a.Type() == types.TextValue && b.Type() == types.TimestampValue:
+		if v, err := document.CastAsTimestamp(a); err == nil {
+			a = v
+		}
+	}
+
+	return a, b
+}
+
 func (op *cmpOp) compare(l, r types.Value) (bool, error) {
 	switch op.Tok {
 	case scanner.EQ:
@@ -100,16 +124,14 @@ func Between(a Expr) func(x, b Expr) Expr {
 }
 
 func (op *BetweenOperator) Eval(env *environment.Environment) (types.Value, error) {
-	x, err := op.X.Eval(env)
-	if err != nil {
-		return FalseLiteral, err
-	}
-
-	return op.simpleOperator.eval(env, func(a, b types.Value) (types.Value, error) {
+	between := func(x, a, b types.Value) (types.Value, error) {
 		if a.Type() == types.NullValue || b.Type() == types.NullValue {
 			return NullLiteral, nil
 		}
 
+		x, a = convertTimestampOperands(x, a)
+		x, b = convertTimestampOperands(x, b)
+
 		ok, err := types.IsGreaterThanOrEqual(x, a)
 		if !ok || err != nil {
 			return FalseLiteral, err
@@ -121,6 +143,30 @@ func (op *BetweenOperator) Eval(env *environment.Environment) (types.Value, erro
 		}
 
 		return TrueLiteral, nil
+	}
+
+	// if x is a path ending with [*], at least one element
+	// of the array must be between a and b
+	if p, ok := elementsOf(op.X); ok {
+		x, err := p.Eval(env)
+		if err != nil {
+			return FalseLiteral, err
+		}
+
+		return op.simpleOperator.eval(env, func(a, b types.Value) (types.Value, error) {
+			return anyElement(x, func(x types.Value) (types.Value, error) {
+				return between(x, a, b)
+			})
+		})
+	}
+
+	x, err := op.X.Eval(env)
+	if err != nil {
+		return FalseLiteral, err
+	}
+
+	return op.simpleOperator.eval(env, func(a, b types.Value) (types.Value, error) {
+		return between(x, a, b)
 	})
 }
 
@@ -132,7 +178,19 @@ func (op *BetweenOperator) String() string {
 // =, !=, >, >=, <, <=, IS, IS NOT, IN, or NOT IN operators.
 func IsComparisonOperator(op Operator) bool {
 	switch op.(type) {
-	case *cmpOp, *IsOperator, *IsNotOperator, *InOperator, *NotInOperator, *LikeOperator, *NotLikeOperator, *BetweenOperator:
+	case *cmpOp, *IsOperator, *IsNotOperator, *InOperator, *NotInOperator, *LikeOperator, *NotLikeOperator, *RegexOperator, *NotRegexOperator, *BetweenOperator:
+		return true
+	}
+
+	return false
+}
+
+// AcceptsAnyElement returns true if the operands of op can be paths ending with [*],
+// which is the case of =, !=, >, >=, <, <=, IN and NOT IN operators.
+// BETWEEN only accepts such a path as the value being tested.
+func AcceptsAnyElement(op Operator) bool {
+	switch op.(type) {
+	case *cmpOp, *InOperator, *NotInOperator:
 		return true
 	}
 
@@ -149,7 +207,7 @@ func In(a, b Expr) Expr {
 }
 
 func (op *InOperator) Eval(env *environment.Environment) (types.Value, error) {
-	return op.simpleOperator.eval(env, func(a, b types.Value) (types.Value, error) {
+	return op.simpleOperator.evalAny(env, func(a, b types.Value) (types.Value, error) {
 		if a.Type() == types.NullValue || b.Type() == types.NullValue {
 			return NullLiteral, nil
 		}
@@ -236,3 +294,87 @@ func (op *IsNotOperator) Eval(env *environment.Environment) (types.Value, error)
 func (op *IsNotOperator) String() string {
 	return fmt.Sprintf("%v IS NOT %v", op.a, op.b)
 }
+
+var errStop = errors.New("stop")
+
+// elementsOf returns the path of the array referred to by e,
+// if e is a path ending with [*].
+func elementsOf(e Expr) (Path, bool) {
+	p, ok := e.(Path)
+	if !ok {
+		return nil, false
+	}
+
+	ap, ok := document.Path(p).ElementsOf()
+	return Path(ap), ok
+}
+
+// evalAny evaluates both operands and calls fn with their values.
+// If an operand is a path ending with [*], fn is called with every
+// element of the array it refers to, and the result is true if fn
+// returns true for at least one of them.
+func (op *simpleOperator) evalAny(env *environment.Environment, fn func(a, b types.Value) (types.Value, error)) (types.Value, error) {
+	pa, aok := elementsOf(op.a)
+	pb, bok := elementsOf(op.b)
+	if !aok && !bok {
+		return op.eval(env, fn)
+	}
+
+	a, b := op.a, op.b
+	if aok {
+		a = pa
+	}
+	if bok {
+		b = pb
+	}
+
+	return (&simpleOperator{a, b, op.Tok}).eval(env, func(va, vb types.Value) (types.Value, error) {
+		if !aok {
+			return anyElement(vb, func(vb types.Value) (types.Value, error) {
+				return fn(va, vb)
+			})
+		}
+
+		return anyElement(va, func(va types.Value) (types.Value, error) {
+			if !bok {
+				return fn(va, vb)
+			}
+
+			return anyElement(vb, func(vb types.Value) (types.Value, error) {
+				return fn(va, vb)
+			})
+		})
+	})
+}
+
+// anyElement calls fn with every element of the array v until it returns true.
+// It returns NULL if v is not an array, or if fn returned NULL
+// and never returned true.
+func anyElement(v types.Value, fn func(v types.Value) (types.Value, error)) (types.Value, error) {
+	if v.Type() != types.ArrayValue {
+		return NullLiteral, nil
+	}
+
+	res := FalseLiteral
+	err := types.As[types.Array](v).Iterate(func(i int, elem types.Value) error {
+		r, err := fn(elem)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case r.Type() == types.NullValue:
+			res = NullLiteral
+		case r.Type() == types.BooleanValue && types.As[bool](r):
+			res = TrueLiteral
+			return errStop
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return NullLiteral, err
+	}
+
+	return res, nil
+}
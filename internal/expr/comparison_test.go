@@ -183,3 +183,32 @@ func TestComparisonBetweenExpr(t *testing.T) {
 		})
 	}
 }
+
+func TestComparisonAnyElementExpr(t *testing.T) {
+	tests := []struct {
+		expr  string
+		res   types.Value
+		fails bool
+	}{
+		{"c[*] = 1", types.NewBoolValue(true), false},
+		{"1 = c[*]", types.NewBoolValue(true), false},
+		{"c[*] >= 1", types.NewBoolValue(true), false},
+		{"c[*] > 1", types.NewBoolValue(false), false},
+		{"c[*] < 1", types.NewBoolValue(false), false},
+		{"c[*] = [1, 2]", types.NewBoolValue(true), false},
+		{"c[*] IN [2, 3]", types.NewBoolValue(false), false},
+		{"c[*] IN [2, 1]", types.NewBoolValue(true), false},
+		{"c[*] NOT IN [2, 1]", types.NewBoolValue(false), false},
+		{"c[*] BETWEEN 0 AND 2", types.NewBoolValue(true), false},
+		{"c[*] BETWEEN 2 AND 3", types.NewBoolValue(false), false},
+		{"a[*] = 1", nullLiteral, false},
+		{"notFound[*] = 1", nullLiteral, false},
+		{"c[*] = NULL", nullLiteral, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			testutil.TestExpr(t, test.expr, envWithDoc, test.res, test.fails)
+		})
+	}
+}
@@ -136,6 +136,11 @@ func Walk(e Expr, fn func(Expr) bool) bool {
 
 	switch t := e.(type) {
 	case Operator:
+		if bt, ok := t.(*BetweenOperator); ok {
+			if !Walk(bt.X, fn) {
+				return false
+			}
+		}
 		if !Walk(t.LeftHand(), fn) {
 			return false
 		}
@@ -144,6 +149,8 @@ func Walk(e Expr, fn func(Expr) bool) bool {
 		}
 	case *NamedExpr:
 		return Walk(t.Expr, fn)
+	case Parentheses:
+		return Walk(t.E, fn)
 	case Function:
 		for _, p := range t.Params() {
 			if !Walk(p, fn) {
@@ -0,0 +1,199 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+// ArrayFunctions returns all array functions.
+// Since ARRAY is a reserved keyword, they are registered
+// as builtin functions prefixed with "array_".
+func ArrayFunctions() Definitions {
+	return arrayFunctions
+}
+
+var arrayFunctions = Definitions{
+	"array_contains": arrayContains,
+	"array_append":   arrayAppend,
+	"array_remove":   arrayRemove,
+	"array_slice":    arraySlice,
+	"array_concat":   arrayConcat,
+	"array_distinct": arrayDistinct,
+}
+
+var arrayContains = &ScalarDefinition{
+	name:  "array_contains",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_contains(arg1, arg2)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+
+		ok, err := document.ArrayContains(a, args[1])
+		if err != nil {
+			return nil, err
+		}
+		return types.NewBoolValue(ok), nil
+	},
+}
+
+var arrayAppend = &ScalarDefinition{
+	name:  "array_append",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_append(arg1, arg2)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+
+		var vb document.ValueBuffer
+		err = vb.ScanArray(a)
+		if err != nil {
+			return nil, err
+		}
+		vb.Append(args[1])
+
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+var arrayRemove = &ScalarDefinition{
+	name:  "array_remove",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_remove(arg1, arg2)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+
+		var vb document.ValueBuffer
+		err = a.Iterate(func(i int, v types.Value) error {
+			ok, err := types.IsEqual(v, args[1])
+			if err != nil || ok {
+				return err
+			}
+
+			vb.Append(v)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+var arraySlice = &ScalarDefinition{
+	name:  "array_slice",
+	arity: 3,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_slice(arg1, arg2, arg3)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+		if args[1].Type() != types.IntegerValue || args[2].Type() != types.IntegerValue {
+			return nil, fmt.Errorf("array_slice(arg1, arg2, arg3) expects arg2 and arg3 to be integers")
+		}
+
+		length, err := document.ArrayLength(a)
+		if err != nil {
+			return nil, err
+		}
+		start := sliceBound(types.As[int64](args[1]), length)
+		end := sliceBound(types.As[int64](args[2]), length)
+
+		var vb document.ValueBuffer
+		err = a.Iterate(func(i int, v types.Value) error {
+			if i >= start && i < end {
+				vb.Append(v)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+// sliceBound converts an index to a position within [0, length].
+// Negative indexes are relative to the end of the array.
+func sliceBound(idx int64, length int) int {
+	if idx < 0 {
+		idx += int64(length)
+	}
+	if idx < 0 {
+		return 0
+	}
+	if idx > int64(length) {
+		return length
+	}
+	return int(idx)
+}
+
+var arrayConcat = &ScalarDefinition{
+	name:  "array_concat",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		var vb document.ValueBuffer
+
+		for i, arg := range args {
+			a, err := arrayArg("array_concat(arg1, arg2)", i+1, arg)
+			if err != nil || a == nil {
+				return types.NewNullValue(), err
+			}
+
+			err = vb.ScanArray(a)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+var arrayDistinct = &ScalarDefinition{
+	name:  "array_distinct",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_distinct(arg1)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+
+		var vb document.ValueBuffer
+		err = a.Iterate(func(i int, v types.Value) error {
+			ok, err := document.ArrayContains(&vb, v)
+			if err != nil || ok {
+				return err
+			}
+
+			vb.Append(v)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+// arrayArg returns the array of the n-th argument of fn,
+// or nil if the argument is NULL.
+func arrayArg(fn string, n int, v types.Value) (types.Array, error) {
+	switch v.Type() {
+	case types.NullValue:
+		return nil, nil
+	case types.ArrayValue:
+		return types.As[types.Array](v), nil
+	}
+
+	return nil, fmt.Errorf("%s expects arg%d to be an array", fn, n)
+}
@@ -0,0 +1,12 @@
+package functions_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/genjidb/genji/internal/testutil"
+)
+
+func TestArrayFunctions(t *testing.T) {
+	testutil.ExprRunner(t, filepath.Join("testdata", "array_functions.sql"))
+}
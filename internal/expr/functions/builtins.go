@@ -2,6 +2,7 @@ package functions
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
@@ -67,6 +68,86 @@ var builtinFunctions = Definitions{
 			return &Len{Expr: args[0]}, nil
 		},
 	},
+	"row_number": &definition{
+		name:  "row_number",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &RowNumber{}, nil
+		},
+	},
+	"rank": &definition{
+		name:  "rank",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &Rank{}, nil
+		},
+	},
+	"dense_rank": &definition{
+		name:  "dense_rank",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &DenseRank{}, nil
+		},
+	},
+	"lag": &variadicDefinition{
+		name:     "lag",
+		minArity: 1,
+		maxArity: 3,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			l := Lag{Expr: args[0]}
+			if len(args) > 1 {
+				l.Offset = args[1]
+			}
+			if len(args) > 2 {
+				l.Default = args[2]
+			}
+			return &l, nil
+		},
+	},
+	"lead": &variadicDefinition{
+		name:     "lead",
+		minArity: 1,
+		maxArity: 3,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			l := Lead{Expr: args[0]}
+			if len(args) > 1 {
+				l.Offset = args[1]
+			}
+			if len(args) > 2 {
+				l.Default = args[2]
+			}
+			return &l, nil
+		},
+	},
+	"first_value": &definition{
+		name:  "first_value",
+		arity: 1,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &FirstValue{Expr: args[0]}, nil
+		},
+	},
+	"coalesce": &variadicDefinition{
+		name:     "coalesce",
+		minArity: 1,
+		maxArity: -1,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &Coalesce{Exprs: args}, nil
+		},
+	},
+	"nullif": &definition{
+		name:  "nullif",
+		arity: 2,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &NullIf{Expr: args[0], Other: args[1]}, nil
+		},
+	},
+	"ifnull": &definition{
+		name:  "ifnull",
+		arity: 2,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &IfNull{Expr: args[0], Default: args[1]}, nil
+		},
+	},
 }
 
 // BuiltinDefinitions returns a map of builtin functions.
@@ -515,24 +596,29 @@ type SumAggregator struct {
 	Fn   *Sum
 	SumI *int64
 	SumF *float64
+	SumD *types.Decimal
 }
 
 // Aggregate stores the sum of all non-NULL numeric values in the group.
 // The result is an integer value if all summed values are integers.
 // If any of the value is a double, the returned result will be a double.
+// Otherwise, if any of the value is a decimal, the returned result will be a decimal.
 func (s *SumAggregator) Aggregate(env *environment.Environment) error {
 	v, err := s.Fn.Expr.Eval(env)
 	if err != nil && !errors.Is(err, types.ErrFieldNotFound) {
 		return err
 	}
-	if v.Type() != types.IntegerValue && v.Type() != types.DoubleValue {
+	if !v.Type().IsNumber() {
 		return nil
 	}
 
 	if s.SumF != nil {
-		if v.Type() == types.IntegerValue {
+		switch v.Type() {
+		case types.IntegerValue:
 			*s.SumF += float64(types.As[int64](v))
-		} else {
+		case types.DecimalValue:
+			*s.SumF += types.As[types.Decimal](v).Float64()
+		default:
 			*s.SumF += float64(types.As[float64](v))
 		}
 
@@ -544,12 +630,33 @@ func (s *SumAggregator) Aggregate(env *environment.Environment) error {
 		if s.SumI != nil {
 			sumF = float64(*s.SumI)
 		}
+		if s.SumD != nil {
+			sumF = s.SumD.Float64()
+		}
 		s.SumF = &sumF
 		*s.SumF += float64(types.As[float64](v))
 
 		return nil
 	}
 
+	if v.Type() == types.DecimalValue && s.SumD == nil {
+		var sumD types.Decimal
+		if s.SumI != nil {
+			sumD = types.NewDecimalFromInt(*s.SumI)
+		}
+		s.SumD = &sumD
+	}
+
+	if s.SumD != nil {
+		if v.Type() == types.IntegerValue {
+			*s.SumD = s.SumD.Add(types.NewDecimalFromInt(types.As[int64](v)))
+		} else {
+			*s.SumD = s.SumD.Add(types.As[types.Decimal](v))
+		}
+
+		return nil
+	}
+
 	if s.SumI == nil {
 		var sumI int64
 		s.SumI = &sumI
@@ -564,6 +671,9 @@ func (s *SumAggregator) Eval(_ *environment.Environment) (types.Value, error) {
 	if s.SumF != nil {
 		return types.NewDoubleValue(*s.SumF), nil
 	}
+	if s.SumD != nil {
+		return types.NewDecimalValue(*s.SumD), nil
+	}
 	if s.SumI != nil {
 		return types.NewIntegerValue(*s.SumI), nil
 	}
@@ -625,6 +735,12 @@ type AvgAggregator struct {
 	Fn      *Avg
 	Avg     float64
 	Counter int64
+
+	// exact sum of integers and decimals, used if
+	// there is at least one decimal and no doubles.
+	SumD       types.Decimal
+	HasDecimal bool
+	HasDouble  bool
 }
 
 // Aggregate stores the average value of all non-NULL numeric values in the group.
@@ -637,8 +753,14 @@ func (s *AvgAggregator) Aggregate(env *environment.Environment) error {
 	switch v.Type() {
 	case types.IntegerValue:
 		s.Avg += float64(types.As[int64](v))
+		s.SumD = s.SumD.Add(types.NewDecimalFromInt(types.As[int64](v)))
 	case types.DoubleValue:
 		s.Avg += types.As[float64](v)
+		s.HasDouble = true
+	case types.DecimalValue:
+		s.Avg += types.As[types.Decimal](v).Float64()
+		s.SumD = s.SumD.Add(types.As[types.Decimal](v))
+		s.HasDecimal = true
 	default:
 		return nil
 	}
@@ -647,12 +769,17 @@ func (s *AvgAggregator) Aggregate(env *environment.Environment) error {
 	return nil
 }
 
-// Eval returns the aggregated average as a double.
+// Eval returns the aggregated average as a double,
+// or as a decimal if there are decimals but no doubles in the group.
 func (s *AvgAggregator) Eval(_ *environment.Environment) (types.Value, error) {
 	if s.Counter == 0 {
 		return types.NewDoubleValue(0), nil
 	}
 
+	if s.HasDecimal && !s.HasDouble {
+		return types.NewDecimalValue(s.SumD.Quo(types.NewDecimalFromInt(s.Counter))), nil
+	}
+
 	return types.NewDoubleValue(s.Avg / float64(s.Counter)), nil
 }
 
@@ -717,3 +844,162 @@ func (s *Len) Params() []expr.Expr { return []expr.Expr{s.Expr} }
 func (s *Len) String() string {
 	return fmt.Sprintf("LEN(%v)", s.Expr)
 }
+
+// Coalesce returns the first of its arguments that is not NULL.
+// Arguments after that one are not evaluated.
+type Coalesce struct {
+	Exprs []expr.Expr
+}
+
+// Eval returns the value of the first non-NULL argument, or NULL.
+func (c *Coalesce) Eval(env *environment.Environment) (types.Value, error) {
+	for _, e := range c.Exprs {
+		v, err := e.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if v.Type() != types.NullValue {
+			return v, nil
+		}
+	}
+
+	return types.NewNullValue(), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (c *Coalesce) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Coalesce)
+	if !ok {
+		return false
+	}
+
+	if len(c.Exprs) != len(o.Exprs) {
+		return false
+	}
+
+	for i := range c.Exprs {
+		if !expr.Equal(c.Exprs[i], o.Exprs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Coalesce) Params() []expr.Expr { return c.Exprs }
+
+func (c *Coalesce) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("COALESCE(")
+	for i, e := range c.Exprs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(e.String())
+	}
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// NullIf returns NULL if both of its arguments are equal,
+// otherwise it returns its first argument.
+type NullIf struct {
+	Expr  expr.Expr
+	Other expr.Expr
+}
+
+// Eval returns NULL if both arguments are equal, or the value of the first one.
+func (n *NullIf) Eval(env *environment.Environment) (types.Value, error) {
+	v, err := n.Expr.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	other, err := n.Other.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Type() == types.NullValue || other.Type() == types.NullValue {
+		return v, nil
+	}
+
+	ok, err := types.IsEqual(v, other)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return types.NewNullValue(), nil
+	}
+
+	return v, nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (n *NullIf) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*NullIf)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(n.Expr, o.Expr) && expr.Equal(n.Other, o.Other)
+}
+
+func (n *NullIf) Params() []expr.Expr { return []expr.Expr{n.Expr, n.Other} }
+
+func (n *NullIf) String() string {
+	return fmt.Sprintf("NULLIF(%v, %v)", n.Expr, n.Other)
+}
+
+// IfNull returns its first argument if it is not NULL,
+// otherwise it returns its second argument.
+type IfNull struct {
+	Expr    expr.Expr
+	Default expr.Expr
+}
+
+// Eval returns the value of the first argument, or the value of the second one if it is NULL.
+func (n *IfNull) Eval(env *environment.Environment) (types.Value, error) {
+	v, err := n.Expr.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if v.Type() != types.NullValue {
+		return v, nil
+	}
+
+	return n.Default.Eval(env)
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (n *IfNull) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*IfNull)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(n.Expr, o.Expr) && expr.Equal(n.Default, o.Default)
+}
+
+func (n *IfNull) Params() []expr.Expr { return []expr.Expr{n.Expr, n.Default} }
+
+func (n *IfNull) String() string {
+	return fmt.Sprintf("IFNULL(%v, %v)", n.Expr, n.Default)
+}
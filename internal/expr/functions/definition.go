@@ -23,11 +23,27 @@ type Packages map[string]Definitions
 
 func DefaultPackages() Packages {
 	return Packages{
-		"":     BuiltinDefinitions(),
-		"math": MathFunctions(),
+		"":        defaultFunctions,
+		"math":    MathFunctions(),
+		"strings": StringsFunctions(),
+		"time":    TimeFunctions(),
 	}
 }
 
+// defaultFunctions holds the functions that can be called without a package name.
+var defaultFunctions = mergeDefinitions(builtinFunctions, arrayFunctions, documentFunctions, uuidFunctions)
+
+// mergeDefinitions returns a table containing the definitions of all the given tables.
+func mergeDefinitions(tables ...Definitions) Definitions {
+	defs := make(Definitions)
+	for _, t := range tables {
+		for name, def := range t {
+			defs[name] = def
+		}
+	}
+	return defs
+}
+
 // GetFunc return a function definition by its package and name.
 func (t Packages) GetFunc(pkg string, fname string) (Definition, error) {
 	fs, ok := t[pkg]
@@ -73,3 +89,52 @@ func (fd *definition) String() string {
 func (fd *definition) Arity() int {
 	return fd.arity
 }
+
+// A variadicDefinition is a definition of a function
+// whose last arguments are optional.
+// A negative maxArity means the function accepts any number of arguments.
+type variadicDefinition struct {
+	name          string
+	minArity      int
+	maxArity      int
+	constructorFn func(...expr.Expr) (expr.Function, error)
+}
+
+func (fd *variadicDefinition) Name() string {
+	return fd.name
+}
+
+func (fd *variadicDefinition) Function(args ...expr.Expr) (expr.Function, error) {
+	if fd.maxArity < 0 {
+		if len(args) < fd.minArity {
+			return nil, fmt.Errorf("%s() takes at least %d argument(s), not %d", fd.name, fd.minArity, len(args))
+		}
+	} else if len(args) < fd.minArity || len(args) > fd.maxArity {
+		return nil, fmt.Errorf("%s() takes between %d and %d argument(s), not %d", fd.name, fd.minArity, fd.maxArity, len(args))
+	}
+	return fd.constructorFn(args...)
+}
+
+func (fd *variadicDefinition) String() string {
+	args := make([]string, 0, fd.Arity()+1)
+	for i := 0; i < fd.Arity(); i++ {
+		if i < fd.minArity {
+			args = append(args, fmt.Sprintf("arg%d", i+1))
+		} else {
+			args = append(args, fmt.Sprintf("[arg%d]", i+1))
+		}
+	}
+	if fd.maxArity < 0 {
+		args = append(args, "...")
+	}
+	return fmt.Sprintf("%s(%s)", fd.name, strings.Join(args, ", "))
+}
+
+// Arity returns the maximum number of arguments of the function,
+// or its minimum number of arguments if it accepts any number of them.
+func (fd *variadicDefinition) Arity() int {
+	if fd.maxArity < 0 {
+		return fd.minArity
+	}
+	return fd.maxArity
+}
@@ -0,0 +1,132 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+// DocumentFunctions returns all document functions.
+// Since DOCUMENT is a reserved keyword, they are registered
+// as builtin functions prefixed with "document_".
+func DocumentFunctions() Definitions {
+	return documentFunctions
+}
+
+var documentFunctions = Definitions{
+	"document_keys":   documentKeys,
+	"document_values": documentValues,
+	"document_merge":  documentMerge,
+	"document_unset":  documentUnset,
+}
+
+var documentKeys = &ScalarDefinition{
+	name:  "document_keys",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		d, err := documentArg("document_keys(arg1)", 1, args[0])
+		if err != nil || d == nil {
+			return types.NewNullValue(), err
+		}
+
+		var vb document.ValueBuffer
+		err = d.Iterate(func(field string, _ types.Value) error {
+			vb.Append(types.NewTextValue(field))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+var documentValues = &ScalarDefinition{
+	name:  "document_values",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		d, err := documentArg("document_values(arg1)", 1, args[0])
+		if err != nil || d == nil {
+			return types.NewNullValue(), err
+		}
+
+		var vb document.ValueBuffer
+		err = d.Iterate(func(_ string, v types.Value) error {
+			vb.Append(v)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+var documentMerge = &ScalarDefinition{
+	name:  "document_merge",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		var fb document.FieldBuffer
+
+		for i, arg := range args {
+			d, err := documentArg("document_merge(arg1, arg2)", i+1, arg)
+			if err != nil || d == nil {
+				return types.NewNullValue(), err
+			}
+
+			// fields of arg2 replace the fields of arg1 with the same name
+			err = d.Iterate(func(field string, v types.Value) error {
+				return fb.Set(document.NewPath(field), v)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return types.NewDocumentValue(&fb), nil
+	},
+}
+
+var documentUnset = &ScalarDefinition{
+	name:  "document_unset",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		d, err := documentArg("document_unset(arg1, arg2)", 1, args[0])
+		if err != nil || d == nil {
+			return types.NewNullValue(), err
+		}
+		if args[1].Type() != types.TextValue {
+			return nil, fmt.Errorf("document_unset(arg1, arg2) expects arg2 to be a string")
+		}
+		field := types.As[string](args[1])
+
+		var fb document.FieldBuffer
+		err = d.Iterate(func(f string, v types.Value) error {
+			if f != field {
+				fb.Add(f, v)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewDocumentValue(&fb), nil
+	},
+}
+
+// documentArg returns the document of the n-th argument of fn,
+// or nil if the argument is NULL.
+func documentArg(fn string, n int, v types.Value) (types.Document, error) {
+	switch v.Type() {
+	case types.NullValue:
+		return nil, nil
+	case types.DocumentValue:
+		return types.As[types.Document](v), nil
+	}
+
+	return nil, fmt.Errorf("%s expects arg%d to be a document", fn, n)
+}
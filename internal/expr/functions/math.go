@@ -25,6 +25,7 @@ var mathFunctions = Definitions{
 }
 
 var floor = &ScalarDefinition{
+	pkg:   "math",
 	name:  "floor",
 	arity: 1,
 	callFn: func(args ...types.Value) (types.Value, error) {
@@ -33,6 +34,13 @@ var floor = &ScalarDefinition{
 			return types.NewDoubleValue(math.Floor(types.As[float64](args[0]))), nil
 		case types.IntegerValue:
 			return args[0], nil
+		case types.DecimalValue:
+			d := types.As[types.Decimal](args[0])
+			f := d.Truncate(0)
+			if f.Cmp(d) > 0 {
+				f = f.Sub(types.NewDecimalFromInt(1))
+			}
+			return types.NewDecimalValue(f), nil
 		default:
 			return nil, fmt.Errorf("floor(arg1) expects arg1 to be a number")
 		}
@@ -40,12 +48,20 @@ var floor = &ScalarDefinition{
 }
 
 var abs = &ScalarDefinition{
+	pkg:   "math",
 	name:  "abs",
 	arity: 1,
 	callFn: func(args ...types.Value) (types.Value, error) {
 		if args[0].Type() == types.NullValue {
 			return types.NewNullValue(), nil
 		}
+		if args[0].Type() == types.DecimalValue {
+			d := types.As[types.Decimal](args[0])
+			if d.Sign() < 0 {
+				d = d.Neg()
+			}
+			return types.NewDecimalValue(d), nil
+		}
 		v, err := document.CastAs(args[0], types.DoubleValue)
 		if err != nil {
 			return nil, err
@@ -59,6 +75,7 @@ var abs = &ScalarDefinition{
 }
 
 var acos = &ScalarDefinition{
+	pkg:   "math",
 	name:  "acos",
 	arity: 1,
 	callFn: func(args ...types.Value) (types.Value, error) {
@@ -79,6 +96,7 @@ var acos = &ScalarDefinition{
 }
 
 var acosh = &ScalarDefinition{
+	pkg:   "math",
 	name:  "acosh",
 	arity: 1,
 	callFn: func(args ...types.Value) (types.Value, error) {
@@ -99,6 +117,7 @@ var acosh = &ScalarDefinition{
 }
 
 var asin = &ScalarDefinition{
+	pkg:   "math",
 	name:  "asin",
 	arity: 1,
 	callFn: func(args ...types.Value) (types.Value, error) {
@@ -119,6 +138,7 @@ var asin = &ScalarDefinition{
 }
 
 var asinh = &ScalarDefinition{
+	pkg:   "math",
 	name:  "asinh",
 	arity: 1,
 	callFn: func(args ...types.Value) (types.Value, error) {
@@ -133,6 +153,7 @@ var asinh = &ScalarDefinition{
 }
 
 var atan = &ScalarDefinition{
+	pkg:   "math",
 	name:  "atan",
 	arity: 1,
 	callFn: func(args ...types.Value) (types.Value, error) {
@@ -147,6 +168,7 @@ var atan = &ScalarDefinition{
 }
 
 var atan2 = &ScalarDefinition{
+	pkg:   "math",
 	name:  "atan2",
 	arity: 2,
 	callFn: func(args ...types.Value) (types.Value, error) {
@@ -15,9 +15,15 @@ import (
 // This difference allows to simply define them with a CallFn function that takes multiple document.Value and
 // return another types.Value, rather than having to manually evaluate expressions (see Definition).
 type ScalarDefinition struct {
+	// pkg is the name of the package of the function, if any.
+	// It is used to generate the string representation of function calls.
+	pkg    string
 	name   string
 	arity  int
 	callFn func(...types.Value) (types.Value, error)
+	// volatile functions, like uuid(), may return a different value
+	// each time they are called with the same arguments.
+	volatile bool
 }
 
 func NewScalarDefinition(name string, arity int, callFn func(...types.Value) (types.Value, error)) *ScalarDefinition {
@@ -54,6 +60,25 @@ func (fd *ScalarDefinition) Arity() int {
 	return fd.arity
 }
 
+// newVariadicScalarDefinition returns the definition of a scalar function
+// whose last arguments are optional.
+// A negative maxArity means the function accepts any number of arguments.
+func newVariadicScalarDefinition(pkg, name string, minArity, maxArity int, callFn func(...types.Value) (types.Value, error)) *variadicDefinition {
+	def := ScalarDefinition{pkg: pkg, name: name, arity: minArity, callFn: callFn}
+
+	return &variadicDefinition{
+		name:     name,
+		minArity: minArity,
+		maxArity: maxArity,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &ScalarFunction{
+				params: args,
+				def:    &def,
+			}, nil
+		},
+	}
+}
+
 // A ScalarFunction is a function which operates on scalar values in contrast to other SQL functions
 // such as the SUM aggregator wich operates on expressions instead.
 type ScalarFunction struct {
@@ -84,12 +109,57 @@ func (sf *ScalarFunction) evalParams(env *environment.Environment) ([]types.Valu
 	return values, nil
 }
 
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (sf *ScalarFunction) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*ScalarFunction)
+	if !ok || sf.def != o.def || len(sf.params) != len(o.params) {
+		return false
+	}
+
+	for i := range sf.params {
+		if !expr.Equal(sf.params[i], o.params[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // String returns a string represention of the function expression and its arguments.
 func (sf *ScalarFunction) String() string {
-	return fmt.Sprintf("%s(%v)", sf.def.name, sf.params)
+	args := make([]string, 0, len(sf.params))
+	for _, param := range sf.params {
+		args = append(args, param.String())
+	}
+
+	name := sf.def.name
+	if sf.def.pkg != "" {
+		name = sf.def.pkg + "." + name
+	}
+
+	return fmt.Sprintf("%s(%s)", name, strings.Join(args, ", "))
 }
 
 // Params return the function arguments.
 func (sf *ScalarFunction) Params() []expr.Expr {
 	return sf.params
 }
+
+// IsDeterministic returns whether the function always returns the same value
+// when called with the same arguments.
+// Only deterministic functions can be used in index expressions.
+func IsDeterministic(f expr.Function) bool {
+	switch t := f.(type) {
+	case *ScalarFunction:
+		return !t.def.volatile
+	case *TypeOf, *Len, *Coalesce, *NullIf, *IfNull:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,448 @@
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+// StringsFunctions returns all strings package functions.
+func StringsFunctions() Definitions {
+	return stringsFunctions
+}
+
+var stringsFunctions = Definitions{
+	"lower":          lower,
+	"upper":          upper,
+	"trim":           trim,
+	"ltrim":          ltrim,
+	"rtrim":          rtrim,
+	"substr":         substr,
+	"replace":        replace,
+	"split":          split,
+	"starts_with":    startsWith,
+	"ends_with":      endsWith,
+	"position":       position,
+	"lpad":           lpad,
+	"rpad":           rpad,
+	"repeat":         repeat,
+	"reverse":        reverse,
+	"format":         format,
+	"regexp_replace": regexpReplace,
+	"regexp_match":   regexpMatch,
+}
+
+var lower = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "lower",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("lower(arg1)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		return types.NewTextValue(strings.ToLower(s[0])), nil
+	},
+}
+
+var upper = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "upper",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("upper(arg1)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		return types.NewTextValue(strings.ToUpper(s[0])), nil
+	},
+}
+
+var trim = newVariadicScalarDefinition("strings", "trim", 1, 2, func(args ...types.Value) (types.Value, error) {
+	s, err := textArgs("trim(arg1, [arg2])", 1, args...)
+	if err != nil || s == nil {
+		return types.NewNullValue(), err
+	}
+
+	if len(s) == 1 {
+		return types.NewTextValue(strings.TrimSpace(s[0])), nil
+	}
+	return types.NewTextValue(strings.Trim(s[0], s[1])), nil
+})
+
+var ltrim = newVariadicScalarDefinition("strings", "ltrim", 1, 2, func(args ...types.Value) (types.Value, error) {
+	s, err := textArgs("ltrim(arg1, [arg2])", 1, args...)
+	if err != nil || s == nil {
+		return types.NewNullValue(), err
+	}
+
+	if len(s) == 1 {
+		return types.NewTextValue(strings.TrimLeftFunc(s[0], unicode.IsSpace)), nil
+	}
+	return types.NewTextValue(strings.TrimLeft(s[0], s[1])), nil
+})
+
+var rtrim = newVariadicScalarDefinition("strings", "rtrim", 1, 2, func(args ...types.Value) (types.Value, error) {
+	s, err := textArgs("rtrim(arg1, [arg2])", 1, args...)
+	if err != nil || s == nil {
+		return types.NewNullValue(), err
+	}
+
+	if len(s) == 1 {
+		return types.NewTextValue(strings.TrimRightFunc(s[0], unicode.IsSpace)), nil
+	}
+	return types.NewTextValue(strings.TrimRight(s[0], s[1])), nil
+})
+
+var substr = newVariadicScalarDefinition("strings", "substr", 2, 3, func(args ...types.Value) (types.Value, error) {
+	s, err := textArgs("substr(arg1, arg2, [arg3])", 1, args[0])
+	if err != nil || s == nil {
+		return types.NewNullValue(), err
+	}
+	n, err := integerArgs("substr(arg1, arg2, [arg3])", 2, args[1:]...)
+	if err != nil || n == nil {
+		return types.NewNullValue(), err
+	}
+
+	runes := []rune(s[0])
+
+	// positions start at 1
+	start := n[0] - 1
+	end := int64(len(runes))
+	if len(n) > 1 {
+		if n[1] < 0 {
+			return nil, fmt.Errorf("substr(arg1, arg2, [arg3]) expects arg3 to be positive")
+		}
+		end = start + n[1]
+	}
+
+	start = clamp(start, 0, int64(len(runes)))
+	end = clamp(end, start, int64(len(runes)))
+
+	return types.NewTextValue(string(runes[start:end])), nil
+})
+
+var replace = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "replace",
+	arity: 3,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("replace(arg1, arg2, arg3)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		return types.NewTextValue(strings.ReplaceAll(s[0], s[1], s[2])), nil
+	},
+}
+
+var split = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "split",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("split(arg1, arg2)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		var vb document.ValueBuffer
+		for _, part := range strings.Split(s[0], s[1]) {
+			vb.Append(types.NewTextValue(part))
+		}
+
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+var startsWith = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "starts_with",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("starts_with(arg1, arg2)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		return types.NewBoolValue(strings.HasPrefix(s[0], s[1])), nil
+	},
+}
+
+var endsWith = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "ends_with",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("ends_with(arg1, arg2)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		return types.NewBoolValue(strings.HasSuffix(s[0], s[1])), nil
+	},
+}
+
+var position = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "position",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("position(arg1, arg2)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		// positions start at 1, 0 means not found
+		idx := strings.Index(s[0], s[1])
+		if idx < 0 {
+			return types.NewIntegerValue(0), nil
+		}
+
+		return types.NewIntegerValue(int64(utf8.RuneCountInString(s[0][:idx]) + 1)), nil
+	},
+}
+
+var lpad = newVariadicScalarDefinition("strings", "lpad", 2, 3, func(args ...types.Value) (types.Value, error) {
+	return pad("lpad(arg1, arg2, [arg3])", true, args...)
+})
+
+var rpad = newVariadicScalarDefinition("strings", "rpad", 2, 3, func(args ...types.Value) (types.Value, error) {
+	return pad("rpad(arg1, arg2, [arg3])", false, args...)
+})
+
+// maxTextLength is the maximum length of the strings
+// generated by the functions repeating or padding their argument.
+const maxTextLength = 64 << 20
+
+// pad fills the string with the fill string (a space by default) up to the given length.
+// If the string is longer than the length, it is truncated.
+func pad(fn string, left bool, args ...types.Value) (types.Value, error) {
+	s, err := textArgs(fn, 1, args[0])
+	if err != nil || s == nil {
+		return types.NewNullValue(), err
+	}
+	n, err := integerArgs(fn, 2, args[1])
+	if err != nil || n == nil {
+		return types.NewNullValue(), err
+	}
+	fill := []rune(" ")
+	if len(args) > 2 {
+		f, err := textArgs(fn, 3, args[2])
+		if err != nil || f == nil {
+			return types.NewNullValue(), err
+		}
+		fill = []rune(f[0])
+	}
+
+	runes := []rune(s[0])
+	length := int(n[0])
+	if length < 0 {
+		length = 0
+	}
+	if len(runes) >= length {
+		return types.NewTextValue(string(runes[:length])), nil
+	}
+	if len(fill) == 0 {
+		return types.NewTextValue(s[0]), nil
+	}
+	if n[0] > maxTextLength {
+		return nil, fmt.Errorf("%s result exceeds the maximum length of %d", fn, maxTextLength)
+	}
+
+	padding := make([]rune, length-len(runes))
+	for i := range padding {
+		padding[i] = fill[i%len(fill)]
+	}
+
+	if left {
+		return types.NewTextValue(string(padding) + s[0]), nil
+	}
+	return types.NewTextValue(s[0] + string(padding)), nil
+}
+
+var repeat = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "repeat",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("repeat(arg1, arg2)", 1, args[0])
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+		n, err := integerArgs("repeat(arg1, arg2)", 2, args[1])
+		if err != nil || n == nil {
+			return types.NewNullValue(), err
+		}
+
+		if n[0] <= 0 || len(s[0]) == 0 {
+			return types.NewTextValue(""), nil
+		}
+		if n[0] > int64(maxTextLength/len(s[0])) {
+			return nil, fmt.Errorf("repeat(arg1, arg2) result exceeds the maximum length of %d", maxTextLength)
+		}
+
+		return types.NewTextValue(strings.Repeat(s[0], int(n[0]))), nil
+	},
+}
+
+var reverse = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "reverse",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("reverse(arg1)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		runes := []rune(s[0])
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+
+		return types.NewTextValue(string(runes)), nil
+	},
+}
+
+// format replaces every %s of the format string with the next argument.
+// Text arguments are inserted as is, other values use their SQL representation.
+// %% is replaced by a single %.
+var format = newVariadicScalarDefinition("strings", "format", 1, -1, func(args ...types.Value) (types.Value, error) {
+	f, err := textArgs("format(arg1, ...)", 1, args[0])
+	if err != nil || f == nil {
+		return types.NewNullValue(), err
+	}
+
+	var sb strings.Builder
+	next := 1
+	for s := f[0]; s != ""; {
+		i := strings.IndexByte(s, '%')
+		if i < 0 || i == len(s)-1 {
+			sb.WriteString(s)
+			break
+		}
+
+		sb.WriteString(s[:i])
+		switch s[i+1] {
+		case '%':
+			sb.WriteByte('%')
+		case 's':
+			if next >= len(args) {
+				return nil, fmt.Errorf("format(arg1, ...) has not enough arguments")
+			}
+			if args[next].Type() == types.TextValue {
+				sb.WriteString(types.As[string](args[next]))
+			} else {
+				sb.WriteString(args[next].String())
+			}
+			next++
+		default:
+			return nil, fmt.Errorf("format(arg1, ...) unsupported verb %q", s[i:i+2])
+		}
+		s = s[i+2:]
+	}
+
+	return types.NewTextValue(sb.String()), nil
+})
+
+var regexpReplace = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "regexp_replace",
+	arity: 3,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("regexp_replace(arg1, arg2, arg3)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		re, err := regexp.Compile(s[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewTextValue(re.ReplaceAllString(s[0], s[2])), nil
+	},
+}
+
+var regexpMatch = &ScalarDefinition{
+	pkg:   "strings",
+	name:  "regexp_match",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, err := textArgs("regexp_match(arg1, arg2)", 1, args...)
+		if err != nil || s == nil {
+			return types.NewNullValue(), err
+		}
+
+		ok, err := regexp.MatchString(s[1], s[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewBoolValue(ok), nil
+	},
+}
+
+// textArgs returns the strings of the given arguments of fn,
+// starting at the n-th argument, or nil if one of them is NULL.
+func textArgs(fn string, n int, args ...types.Value) ([]string, error) {
+	s := make([]string, len(args))
+	var null bool
+
+	for i, arg := range args {
+		switch arg.Type() {
+		case types.NullValue:
+			null = true
+		case types.TextValue:
+			s[i] = types.As[string](arg)
+		default:
+			return nil, fmt.Errorf("%s expects arg%d to be a string", fn, n+i)
+		}
+	}
+
+	if null {
+		return nil, nil
+	}
+	return s, nil
+}
+
+// integerArgs returns the integers of the given arguments of fn,
+// starting at the n-th argument, or nil if one of them is NULL.
+func integerArgs(fn string, n int, args ...types.Value) ([]int64, error) {
+	res := make([]int64, len(args))
+	var null bool
+
+	for i, arg := range args {
+		switch arg.Type() {
+		case types.NullValue:
+			null = true
+		case types.IntegerValue:
+			res[i] = types.As[int64](arg)
+		default:
+			return nil, fmt.Errorf("%s expects arg%d to be an integer", fn, n+i)
+		}
+	}
+
+	if null {
+		return nil, nil
+	}
+	return res, nil
+}
+
+func clamp(n, min, max int64) int64 {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
@@ -0,0 +1,65 @@
+-- test: array_contains
+> array_contains([1, 2, 3], 2)
+true
+> array_contains([1, 2, 3], 4)
+false
+> array_contains([1, 'a', [2]], [2])
+true
+> array_contains(NULL, 1)
+NULL
+! array_contains(1, 1)
+'array_contains(arg1, arg2) expects arg1 to be an array'
+
+-- test: array_append
+> array_append([1, 2], 3)
+[1, 2, 3]
+> array_append([], 'a')
+['a']
+> array_append(NULL, 1)
+NULL
+! array_append('a', 1)
+'expects arg1 to be an array'
+
+-- test: array_remove
+> array_remove([1, 2, 1, 3], 1)
+[2, 3]
+> array_remove([1, 2], 4)
+[1, 2]
+> array_remove(NULL, 1)
+NULL
+! array_remove({}, 1)
+'expects arg1 to be an array'
+
+-- test: array_slice
+> array_slice([1, 2, 3, 4], 1, 3)
+[2, 3]
+> array_slice([1, 2, 3, 4], 2, 10)
+[3, 4]
+> array_slice([1, 2, 3, 4], -2, 4)
+[3, 4]
+> array_slice([1, 2, 3, 4], 3, 1)
+[]
+> array_slice(NULL, 0, 1)
+NULL
+! array_slice([1, 2], 'a', 1)
+'expects arg2 and arg3 to be integers'
+
+-- test: array_concat
+> array_concat([1, 2], [3])
+[1, 2, 3]
+> array_concat([], [])
+[]
+> array_concat([1], NULL)
+NULL
+! array_concat([1], 2)
+'array_concat(arg1, arg2) expects arg2 to be an array'
+
+-- test: array_distinct
+> array_distinct([1, 2, 1, 'a', 2, 'a'])
+[1, 2, 'a']
+> array_distinct([])
+[]
+> array_distinct(NULL)
+NULL
+! array_distinct(1)
+'expects arg1 to be an array'
@@ -33,3 +33,51 @@
 
 > typeof(NULL)
 'null'
+
+-- test: coalesce
+! coalesce()
+
+> coalesce(NULL)
+NULL
+
+> coalesce(1)
+1
+
+> coalesce(NULL, 1, 2)
+1
+
+> coalesce(NULL, NULL, 'a')
+'a'
+
+> coalesce(NULL, NULL)
+NULL
+
+-- test: nullif
+! nullif(1)
+
+> nullif(1, 1)
+NULL
+
+> nullif(1, 1.0)
+NULL
+
+> nullif(1, 2)
+1
+
+> nullif(NULL, 1)
+NULL
+
+> nullif(1, NULL)
+1
+
+-- test: ifnull
+! ifnull(1)
+
+> ifnull(NULL, 1)
+1
+
+> ifnull(2, 1)
+2
+
+> ifnull(NULL, NULL)
+NULL
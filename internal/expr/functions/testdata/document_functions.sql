@@ -0,0 +1,39 @@
+-- test: document_keys
+> document_keys({a: 1, b: 'foo'})
+['a', 'b']
+> document_keys({})
+[]
+> document_keys(NULL)
+NULL
+! document_keys([1])
+'document_keys(arg1) expects arg1 to be a document'
+
+-- test: document_values
+> document_values({a: 1, b: 'foo'})
+[1, 'foo']
+> document_values({})
+[]
+> document_values(NULL)
+NULL
+! document_values(1)
+'expects arg1 to be a document'
+
+-- test: document_merge
+> document_merge({a: 1, b: 2}, {b: 3, c: 4})
+{a: 1, b: 3, c: 4}
+> document_merge({}, {a: 1})
+{a: 1}
+> document_merge({a: 1}, NULL)
+NULL
+! document_merge({a: 1}, 1)
+'document_merge(arg1, arg2) expects arg2 to be a document'
+
+-- test: document_unset
+> document_unset({a: 1, b: 2}, 'a')
+{b: 2}
+> document_unset({a: 1}, 'b')
+{a: 1}
+> document_unset(NULL, 'a')
+NULL
+! document_unset({a: 1}, 1)
+'expects arg2 to be a string'
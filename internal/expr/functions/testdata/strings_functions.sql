@@ -0,0 +1,191 @@
+-- test: strings.lower
+> strings.lower('AbC')
+'abc'
+> strings.lower(NULL)
+NULL
+! strings.lower(1)
+'lower(arg1) expects arg1 to be a string'
+
+-- test: strings.upper
+> strings.upper('AbC')
+'ABC'
+> strings.upper(NULL)
+NULL
+! strings.upper(true)
+'upper(arg1) expects arg1 to be a string'
+
+-- test: strings.trim
+> strings.trim('  foo  ')
+'foo'
+> strings.trim('xxfooxx', 'x')
+'foo'
+> strings.trim(NULL)
+NULL
+! strings.trim('foo', 1)
+'trim(arg1, [arg2]) expects arg2 to be a string'
+! strings.trim('a', 'b', 'c')
+'trim() takes between 1 and 2 argument(s), not 3'
+
+-- test: strings.ltrim
+> strings.ltrim('  foo  ')
+'foo  '
+> strings.ltrim('xxfooxx', 'x')
+'fooxx'
+> strings.ltrim(NULL)
+NULL
+
+-- test: strings.rtrim
+> strings.rtrim('  foo  ')
+'  foo'
+> strings.rtrim('xxfooxx', 'x')
+'xxfoo'
+> strings.rtrim(NULL, 'x')
+NULL
+
+-- test: strings.substr
+> strings.substr('hello', 2)
+'ello'
+> strings.substr('hello', 2, 3)
+'ell'
+> strings.substr('hello', 0, 2)
+'h'
+> strings.substr('hello', 10)
+''
+> strings.substr('héllo', 2, 1)
+'é'
+> strings.substr(NULL, 1)
+NULL
+! strings.substr('hello', 'a')
+'substr(arg1, arg2, [arg3]) expects arg2 to be an integer'
+! strings.substr('hello', 1, -1)
+'expects arg3 to be positive'
+
+-- test: strings.replace
+> strings.replace('foo bar foo', 'foo', 'baz')
+'baz bar baz'
+> strings.replace('foo', 'x', 'y')
+'foo'
+> strings.replace('foo', NULL, 'y')
+NULL
+! strings.replace('foo', 'f', 1)
+'replace(arg1, arg2, arg3) expects arg3 to be a string'
+
+-- test: strings.split
+> strings.split('a,b,c', ',')
+['a', 'b', 'c']
+> strings.split('abc', ',')
+['abc']
+> strings.split(NULL, ',')
+NULL
+
+-- test: strings.starts_with
+> strings.starts_with('foobar', 'foo')
+true
+> strings.starts_with('foobar', 'bar')
+false
+> strings.starts_with(NULL, 'bar')
+NULL
+
+-- test: strings.ends_with
+> strings.ends_with('foobar', 'bar')
+true
+> strings.ends_with('foobar', 'foo')
+false
+> strings.ends_with('foobar', NULL)
+NULL
+
+-- test: strings.position
+> strings.position('foobar', 'bar')
+4
+> strings.position('héllo', 'llo')
+3
+> strings.position('foobar', 'baz')
+0
+> strings.position(NULL, 'baz')
+NULL
+
+-- test: strings.lpad
+> strings.lpad('42', 5, '0')
+'00042'
+> strings.lpad('42', 5)
+'   42'
+> strings.lpad('hello', 2)
+'he'
+> strings.lpad('a', 4, 'xy')
+'xyxa'
+> strings.lpad(NULL, 4)
+NULL
+! strings.lpad('a', 'b')
+'lpad(arg1, arg2, [arg3]) expects arg2 to be an integer'
+> strings.lpad('a', -1)
+''
+! strings.lpad('a', 9223372036854775807)
+'lpad(arg1, arg2, [arg3]) result exceeds the maximum length of 67108864'
+
+-- test: strings.rpad
+> strings.rpad('42', 5, '0')
+'42000'
+> strings.rpad('42', 3)
+'42 '
+> strings.rpad('hello', 2)
+'he'
+! strings.rpad('a', 2, 1)
+'rpad(arg1, arg2, [arg3]) expects arg3 to be a string'
+! strings.rpad('a', 100000000)
+'rpad(arg1, arg2, [arg3]) result exceeds the maximum length of 67108864'
+
+-- test: strings.repeat
+> strings.repeat('ab', 3)
+'ababab'
+> strings.repeat('ab', 0)
+''
+> strings.repeat(NULL, 3)
+NULL
+> strings.repeat('ab', -1)
+''
+> strings.repeat('', 9223372036854775807)
+''
+! strings.repeat('a', 100000000)
+'repeat(arg1, arg2) result exceeds the maximum length of 67108864'
+! strings.repeat('ab', 9223372036854775807)
+'repeat(arg1, arg2) result exceeds the maximum length of 67108864'
+
+-- test: strings.reverse
+> strings.reverse('abc')
+'cba'
+> strings.reverse('héllo')
+'olléh'
+> strings.reverse(NULL)
+NULL
+
+-- test: strings.format
+> strings.format('%s is %s years old', 'foo', 10)
+'foo is 10 years old'
+> strings.format('100%%')
+'100%'
+> strings.format('%s', [1, 2])
+'[1, 2]'
+> strings.format(NULL, 1)
+NULL
+! strings.format('%s %s', 'a')
+'not enough arguments'
+! strings.format('%d', 1)
+'unsupported verb'
+
+-- test: strings.regexp_replace
+> strings.regexp_replace('foo123bar45', '[0-9]+', '#')
+'foo#bar#'
+> strings.regexp_replace('john smith', '([a-z]+) ([a-z]+)', '$2 $1')
+'smith john'
+> strings.regexp_replace(NULL, 'a', 'b')
+NULL
+! strings.regexp_replace('a', '(', 'b')
+'error parsing regexp'
+
+-- test: strings.regexp_match
+> strings.regexp_match('foo123', '^[a-z]+[0-9]+$')
+true
+> strings.regexp_match('foo', '[0-9]')
+false
+> strings.regexp_match('foo', NULL)
+NULL
@@ -0,0 +1,115 @@
+-- test: time.date_trunc
+> time.date_trunc('second', TIMESTAMP '2023-03-15 10:20:30.123456')
+TIMESTAMP '2023-03-15T10:20:30Z'
+> time.date_trunc('minute', TIMESTAMP '2023-03-15 10:20:30')
+TIMESTAMP '2023-03-15T10:20:00Z'
+> time.date_trunc('hour', TIMESTAMP '2023-03-15 10:20:30')
+TIMESTAMP '2023-03-15T10:00:00Z'
+> time.date_trunc('DAY', TIMESTAMP '2023-03-15 10:20:30')
+TIMESTAMP '2023-03-15T00:00:00Z'
+> time.date_trunc('week', TIMESTAMP '2023-03-15 10:20:30')
+TIMESTAMP '2023-03-13T00:00:00Z'
+> time.date_trunc('month', TIMESTAMP '2023-03-15 10:20:30')
+TIMESTAMP '2023-03-01T00:00:00Z'
+> time.date_trunc('quarter', TIMESTAMP '2023-05-15 10:20:30')
+TIMESTAMP '2023-04-01T00:00:00Z'
+> time.date_trunc('year', TIMESTAMP '2023-03-15 10:20:30')
+TIMESTAMP '2023-01-01T00:00:00Z'
+> time.date_trunc('day', '2023-03-15T10:20:30+02:00')
+TIMESTAMP '2023-03-15T00:00:00Z'
+> time.date_trunc('day', NULL)
+NULL
+> time.date_trunc(NULL, TIMESTAMP '2023-03-15')
+NULL
+! time.date_trunc('decade', TIMESTAMP '2023-03-15')
+'date_trunc(arg1, arg2): unknown unit "decade"'
+! time.date_trunc('day', 1)
+'date_trunc(arg1, arg2) expects arg2 to be a timestamp'
+! time.date_trunc('day', 'foo')
+'cannot cast "foo" as timestamp'
+
+-- test: time.extract
+> time.extract('year', TIMESTAMP '2023-03-15 10:20:30.123456')
+2023
+> time.extract('quarter', TIMESTAMP '2023-03-15 10:20:30.123456')
+1
+> time.extract('month', TIMESTAMP '2023-03-15 10:20:30.123456')
+3
+> time.extract('week', TIMESTAMP '2023-03-15 10:20:30.123456')
+11
+> time.extract('day', TIMESTAMP '2023-03-15 10:20:30.123456')
+15
+> time.extract('dow', TIMESTAMP '2023-03-15 10:20:30.123456')
+3
+> time.extract('doy', TIMESTAMP '2023-03-15 10:20:30.123456')
+74
+> time.extract('hour', TIMESTAMP '2023-03-15 10:20:30.123456')
+10
+> time.extract('minute', TIMESTAMP '2023-03-15 10:20:30.123456')
+20
+> time.extract('second', TIMESTAMP '2023-03-15 10:20:30.123456')
+30
+> time.extract('millisecond', TIMESTAMP '2023-03-15 10:20:30.123456')
+30123
+> time.extract('microsecond', TIMESTAMP '2023-03-15 10:20:30.123456')
+30123456
+> time.extract('epoch', TIMESTAMP '1970-01-02')
+86400
+> time.extract('year', NULL)
+NULL
+! time.extract('century', TIMESTAMP '2023-03-15')
+'extract(arg1, arg2): unknown field "century"'
+! time.extract(1, TIMESTAMP '2023-03-15')
+'extract(arg1, arg2) expects arg1 to be a string'
+
+-- test: time.add
+> time.add(TIMESTAMP '2023-03-15 10:20:30', '1 day')
+TIMESTAMP '2023-03-16T10:20:30Z'
+> time.add(TIMESTAMP '2023-03-15 10:20:30', '1 year 2 months 3 weeks 4 days 5 hours 6 minutes 7 seconds')
+TIMESTAMP '2024-06-09T15:26:37Z'
+> time.add(TIMESTAMP '2023-01-31', '1 month')
+TIMESTAMP '2023-03-03T00:00:00Z'
+> time.add(TIMESTAMP '2023-03-15 10:20:30', '-2 hours')
+TIMESTAMP '2023-03-15T08:20:30Z'
+> time.add(TIMESTAMP '2023-03-15 10:20:30', '1h30m')
+TIMESTAMP '2023-03-15T11:50:30Z'
+> time.add(TIMESTAMP '2023-03-15 10:20:30', '500 milliseconds')
+TIMESTAMP '2023-03-15T10:20:30.5Z'
+> time.add(NULL, '1 day')
+NULL
+> time.add(TIMESTAMP '2023-03-15', NULL)
+NULL
+! time.add(TIMESTAMP '2023-03-15', '1 fortnight')
+'invalid interval "1 fortnight": unknown unit "fortnight"'
+! time.add(TIMESTAMP '2023-03-15', 'day')
+'invalid interval "day"'
+! time.add(TIMESTAMP '2023-03-15', 1)
+'add(arg1, arg2) expects arg2 to be a string'
+
+-- test: time.sub
+> time.sub(TIMESTAMP '2023-03-15 10:20:30', '1 day')
+TIMESTAMP '2023-03-14T10:20:30Z'
+> time.sub(TIMESTAMP '2023-03-15 10:20:30', '1 year 1 month')
+TIMESTAMP '2022-02-15T10:20:30Z'
+> time.sub(TIMESTAMP '2023-03-15 10:20:30', '30s')
+TIMESTAMP '2023-03-15T10:20:00Z'
+> time.sub(NULL, '1 day')
+NULL
+
+-- test: time.diff
+> time.diff(TIMESTAMP '2023-03-15 10:20:30', TIMESTAMP '2023-03-15 10:00:00')
+1230.0
+> time.diff(TIMESTAMP '2023-03-14', TIMESTAMP '2023-03-15')
+-86400.0
+> time.diff(TIMESTAMP '2023-03-15 00:00:00.5', '2023-03-15')
+0.5
+> time.diff(NULL, TIMESTAMP '2023-03-15')
+NULL
+! time.diff(TIMESTAMP '2023-03-15', 1)
+'diff(arg1, arg2) expects arg2 to be a timestamp'
+
+-- test: time.now
+> time.now() > TIMESTAMP '2023-01-01'
+true
+> time.extract('year', time.now()) >= 2023
+true
@@ -0,0 +1,39 @@
+-- test: uuid
+> typeof(uuid())
+'blob'
+> uuid_text(uuid()) =~ '^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$'
+true
+> uuid() = uuid()
+false
+
+-- test: ulid
+> typeof(ulid())
+'blob'
+> ulid_text(ulid()) =~ '^[0-7][0-9A-HJKMNP-TV-Z]{25}$'
+true
+> ulid() < ulid()
+true
+
+-- test: uuid_text
+> uuid_text(CAST('f81d4fae-7dec-11d0-a765-00a0c91e6bf6' AS BLOB))
+'f81d4fae-7dec-11d0-a765-00a0c91e6bf6'
+> uuid_text(CAST('01ARZ3NDEKTSV4RRFFQ69G5FAV' AS BLOB))
+'01563e3a-b5d3-d676-4c61-efb99302bd5b'
+> uuid_text(NULL)
+NULL
+! uuid_text('f81d4fae-7dec-11d0-a765-00a0c91e6bf6')
+'uuid_text(arg1) expects arg1 to be a 16-byte blob'
+! uuid_text(CAST('YXNkaW5l' AS BLOB))
+'uuid_text(arg1) expects arg1 to be a 16-byte blob'
+
+-- test: ulid_text
+> ulid_text(CAST('01arz3ndektsv4rrffq69g5fav' AS BLOB))
+'01ARZ3NDEKTSV4RRFFQ69G5FAV'
+> ulid_text(CAST('01563e3a-b5d3-d676-4c61-efb99302bd5b' AS BLOB))
+'01ARZ3NDEKTSV4RRFFQ69G5FAV'
+> ulid_text(CAST('ffffffff-ffff-ffff-ffff-ffffffffffff' AS BLOB))
+'7ZZZZZZZZZZZZZZZZZZZZZZZZZ'
+> ulid_text(NULL)
+NULL
+! ulid_text(1)
+'ulid_text(arg1) expects arg1 to be a 16-byte blob'
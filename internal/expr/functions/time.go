@@ -0,0 +1,281 @@
+package functions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+// TimeFunctions returns all time package functions.
+func TimeFunctions() Definitions {
+	return timeFunctions
+}
+
+var timeFunctions = Definitions{
+	"now":        timeNow,
+	"date_trunc": dateTrunc,
+	"extract":    extract,
+	"add":        timeAdd,
+	"sub":        timeSub,
+	"diff":       timeDiff,
+}
+
+var timeNow = &ScalarDefinition{
+	pkg:      "time",
+	name:     "now",
+	arity:    0,
+	volatile: true,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return types.NewTimestampValue(time.Now()), nil
+	},
+}
+
+var dateTrunc = &ScalarDefinition{
+	pkg:   "time",
+	name:  "date_trunc",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		unit, ts, err := unitAndTimestampArgs("date_trunc(arg1, arg2)", args...)
+		if err != nil || ts == nil {
+			return types.NewNullValue(), err
+		}
+		t := *ts
+
+		switch unit {
+		case "microsecond", "microseconds":
+			t = t.Truncate(time.Microsecond)
+		case "millisecond", "milliseconds":
+			t = t.Truncate(time.Millisecond)
+		case "second", "seconds":
+			t = t.Truncate(time.Second)
+		case "minute", "minutes":
+			t = t.Truncate(time.Minute)
+		case "hour", "hours":
+			t = t.Truncate(time.Hour)
+		case "day", "days":
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		case "week", "weeks":
+			// weeks start on monday
+			offset := (int(t.Weekday()) + 6) % 7
+			t = time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+		case "month", "months":
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		case "quarter", "quarters":
+			t = time.Date(t.Year(), t.Month()-(t.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+		case "year", "years":
+			t = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		default:
+			return nil, fmt.Errorf("date_trunc(arg1, arg2): unknown unit %q", unit)
+		}
+
+		return types.NewTimestampValue(t), nil
+	},
+}
+
+var extract = &ScalarDefinition{
+	pkg:   "time",
+	name:  "extract",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		field, t, err := unitAndTimestampArgs("extract(arg1, arg2)", args...)
+		if err != nil || t == nil {
+			return types.NewNullValue(), err
+		}
+
+		var x int
+		switch field {
+		case "microsecond", "microseconds":
+			x = t.Second()*1e6 + t.Nanosecond()/1e3
+		case "millisecond", "milliseconds":
+			x = t.Second()*1e3 + t.Nanosecond()/1e6
+		case "second", "seconds":
+			x = t.Second()
+		case "minute", "minutes":
+			x = t.Minute()
+		case "hour", "hours":
+			x = t.Hour()
+		case "day", "days":
+			x = t.Day()
+		case "dow":
+			x = int(t.Weekday())
+		case "doy":
+			x = t.YearDay()
+		case "week", "weeks":
+			_, x = t.ISOWeek()
+		case "month", "months":
+			x = int(t.Month())
+		case "quarter", "quarters":
+			x = (int(t.Month())-1)/3 + 1
+		case "year", "years":
+			x = t.Year()
+		case "epoch":
+			return types.NewIntegerValue(t.Unix()), nil
+		default:
+			return nil, fmt.Errorf("extract(arg1, arg2): unknown field %q", field)
+		}
+
+		return types.NewIntegerValue(int64(x)), nil
+	},
+}
+
+// timeAdd adds an interval to a timestamp.
+// There is no INTERVAL type: intervals are texts parsed by parseInterval,
+// e.g. time.add(at, '1 day 2 hours'), and timestamps don't support arithmetic operators.
+var timeAdd = &ScalarDefinition{
+	pkg:   "time",
+	name:  "add",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return addInterval("add(arg1, arg2)", 1, args...)
+	},
+}
+
+var timeSub = &ScalarDefinition{
+	pkg:   "time",
+	name:  "sub",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return addInterval("sub(arg1, arg2)", -1, args...)
+	},
+}
+
+var timeDiff = &ScalarDefinition{
+	pkg:   "time",
+	name:  "diff",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		t1, err := timestampArg("diff(arg1, arg2)", 1, args[0])
+		if err != nil || t1 == nil {
+			return types.NewNullValue(), err
+		}
+		t2, err := timestampArg("diff(arg1, arg2)", 2, args[1])
+		if err != nil || t2 == nil {
+			return types.NewNullValue(), err
+		}
+
+		return types.NewDoubleValue(t1.Sub(*t2).Seconds()), nil
+	},
+}
+
+// addInterval adds the interval of the second argument to the timestamp of the first one.
+// If sign is negative, the interval is subtracted.
+func addInterval(fn string, sign int, args ...types.Value) (types.Value, error) {
+	t, err := timestampArg(fn, 1, args[0])
+	if err != nil || t == nil {
+		return types.NewNullValue(), err
+	}
+
+	switch args[1].Type() {
+	case types.NullValue:
+		return types.NewNullValue(), nil
+	case types.TextValue:
+	default:
+		return nil, fmt.Errorf("%s expects arg2 to be a string", fn)
+	}
+
+	itv, err := parseInterval(types.As[string](args[1]))
+	if err != nil {
+		return nil, err
+	}
+
+	res := t.AddDate(sign*itv.years, sign*itv.months, sign*itv.days).Add(time.Duration(sign) * itv.duration)
+	return types.NewTimestampValue(res), nil
+}
+
+// interval represents an amount of time. Years, months and days are kept separate
+// from the duration since their length depends on the timestamp they are added to.
+type interval struct {
+	years, months, days int
+	duration            time.Duration
+}
+
+// parseInterval parses either a Go duration (e.g. "1h30m") or a list
+// of quantities followed by a unit (e.g. "1 day 2 hours").
+func parseInterval(s string) (*interval, error) {
+	var itv interval
+
+	d, err := time.ParseDuration(s)
+	if err == nil {
+		itv.duration = d
+		return &itv, nil
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return nil, fmt.Errorf("invalid interval %q", s)
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval %q", s)
+		}
+
+		switch strings.ToLower(fields[i+1]) {
+		case "microsecond", "microseconds":
+			itv.duration += time.Duration(n) * time.Microsecond
+		case "millisecond", "milliseconds":
+			itv.duration += time.Duration(n) * time.Millisecond
+		case "second", "seconds":
+			itv.duration += time.Duration(n) * time.Second
+		case "minute", "minutes":
+			itv.duration += time.Duration(n) * time.Minute
+		case "hour", "hours":
+			itv.duration += time.Duration(n) * time.Hour
+		case "day", "days":
+			itv.days += n
+		case "week", "weeks":
+			itv.days += 7 * n
+		case "month", "months":
+			itv.months += n
+		case "year", "years":
+			itv.years += n
+		default:
+			return nil, fmt.Errorf("invalid interval %q: unknown unit %q", s, fields[i+1])
+		}
+	}
+
+	return &itv, nil
+}
+
+// unitAndTimestampArgs returns the lowercased unit of the first argument
+// and the timestamp of the second argument of fn.
+// The timestamp is nil if one of the arguments is NULL.
+func unitAndTimestampArgs(fn string, args ...types.Value) (string, *time.Time, error) {
+	s, err := textArgs(fn, 1, args[0])
+	if err != nil || s == nil {
+		return "", nil, err
+	}
+
+	t, err := timestampArg(fn, 2, args[1])
+	if err != nil || t == nil {
+		return "", nil, err
+	}
+
+	return strings.ToLower(s[0]), t, nil
+}
+
+// timestampArg returns the timestamp of the n-th argument of fn,
+// or nil if the argument is NULL.
+// Texts are parsed using the same formats as CAST.
+func timestampArg(fn string, n int, v types.Value) (*time.Time, error) {
+	switch v.Type() {
+	case types.NullValue:
+		return nil, nil
+	case types.TimestampValue:
+		t := types.As[time.Time](v)
+		return &t, nil
+	case types.TextValue:
+		t, err := document.ParseTimestamp(types.As[string](v))
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	}
+
+	return nil, fmt.Errorf("%s expects arg%d to be a timestamp", fn, n)
+}
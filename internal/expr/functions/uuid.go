@@ -0,0 +1,137 @@
+package functions
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+// UUIDFunctions returns the functions generating unique identifiers.
+// They are registered as builtin functions and return 16-byte blobs,
+// which can be casted from their text representation and formatted
+// using the uuid_text and ulid_text functions.
+func UUIDFunctions() Definitions {
+	return uuidFunctions
+}
+
+var uuidFunctions = Definitions{
+	"uuid":      uuidFn,
+	"ulid":      ulidFn,
+	"uuid_text": uuidText,
+	"ulid_text": ulidText,
+}
+
+// uuidFn returns a random (version 4) UUID, as defined by RFC 4122.
+var uuidFn = &ScalarDefinition{
+	name:     "uuid",
+	arity:    0,
+	volatile: true,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		b := make([]byte, 16)
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+
+		b[6] = (b[6] & 0x0f) | 0x40 // version 4
+		b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+		return types.NewBlobValue(b), nil
+	},
+}
+
+// ulidFn returns a ULID: a 48-bit timestamp in milliseconds followed
+// by 80 random bits. ULIDs sort by creation time, which makes them
+// better suited than random UUIDs for primary keys.
+var ulidFn = &ScalarDefinition{
+	name:     "ulid",
+	arity:    0,
+	volatile: true,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		b, err := ulids.next(time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewBlobValue(b), nil
+	},
+}
+
+// uuidText returns the canonical text representation of a UUID.
+var uuidText = &ScalarDefinition{
+	name:  "uuid_text",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return formatID("uuid_text(arg1)", document.FormatUUID, args[0])
+	},
+}
+
+// ulidText returns the base32 text representation of a ULID.
+var ulidText = &ScalarDefinition{
+	name:  "ulid_text",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return formatID("ulid_text(arg1)", document.FormatULID, args[0])
+	},
+}
+
+func formatID(fn string, format func([]byte) string, v types.Value) (types.Value, error) {
+	if v.Type() == types.NullValue {
+		return types.NewNullValue(), nil
+	}
+
+	if v.Type() != types.BlobValue || len(types.As[[]byte](v)) != 16 {
+		return nil, fmt.Errorf("%s expects arg1 to be a 16-byte blob", fn)
+	}
+
+	return types.NewTextValue(format(types.As[[]byte](v))), nil
+}
+
+var ulids ulidGenerator
+
+// ulidGenerator generates monotonic ULIDs: the random part of ULIDs
+// generated during the same millisecond is incremented instead of being
+// drawn again, to guarantee they are sorted by generation order.
+type ulidGenerator struct {
+	mu   sync.Mutex
+	ms   uint64
+	last [10]byte
+}
+
+func (g *ulidGenerator) next(now time.Time) ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(now.UnixMilli())
+	if ms > g.ms {
+		_, err := rand.Read(g.last[:])
+		if err != nil {
+			return nil, err
+		}
+		g.ms = ms
+	} else {
+		// increment the random part, carrying over to the timestamp on overflow
+		i := len(g.last) - 1
+		for ; i >= 0; i-- {
+			g.last[i]++
+			if g.last[i] != 0 {
+				break
+			}
+		}
+		if i < 0 {
+			g.ms++
+		}
+	}
+
+	b := make([]byte, 16)
+	for i := 0; i < 6; i++ {
+		b[i] = byte(g.ms >> (40 - 8*i))
+	}
+	copy(b[6:], g.last[:])
+
+	return b, nil
+}
@@ -0,0 +1,492 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/types"
+)
+
+// A WindowFunction is a function that can only be used with an OVER clause.
+// It computes a value for every document of a partition.
+type WindowFunction interface {
+	expr.Function
+
+	EvalPartition(p *Partition) ([]types.Value, error)
+}
+
+// A Partition is the list of documents of a window sharing the same
+// PARTITION BY values, in the order of the window.
+type Partition struct {
+	// Envs holds the environment of each document of the partition.
+	Envs []*environment.Environment
+	// Peers holds the index of the peer group of each document.
+	// Documents are peers if they have the same ORDER BY value.
+	Peers []int
+	// Ordered is true if the window has an ORDER BY clause.
+	Ordered bool
+}
+
+// peerGroupBounds returns, for every document, the index of the first
+// and of the last document of its peer group.
+func (p *Partition) peerGroupBounds() (starts, ends []int) {
+	starts = make([]int, len(p.Peers))
+	ends = make([]int, len(p.Peers))
+
+	for i := range p.Peers {
+		if i > 0 && p.Peers[i-1] == p.Peers[i] {
+			starts[i] = starts[i-1]
+		} else {
+			starts[i] = i
+		}
+	}
+
+	for i := len(p.Peers) - 1; i >= 0; i-- {
+		if i < len(p.Peers)-1 && p.Peers[i+1] == p.Peers[i] {
+			ends[i] = ends[i+1]
+		} else {
+			ends[i] = i
+		}
+	}
+
+	return starts, ends
+}
+
+// A Window is a function evaluated over a window of documents:
+//   fn OVER ([PARTITION BY expr [, expr...]] [ORDER BY expr [ASC|DESC]])
+// The function is either a WindowFunction or an aggregate function.
+// Without ORDER BY, aggregate functions are computed over the whole partition,
+// otherwise they are computed from the start of the partition up to the
+// current document and its peers.
+type Window struct {
+	Fn          expr.Function
+	PartitionBy []expr.Expr
+	OrderBy     expr.Expr
+	Desc        bool
+}
+
+// Eval returns the value computed for the current document by the window operator.
+func (w *Window) Eval(env *environment.Environment) (types.Value, error) {
+	v, ok := env.Get(document.Path{document.PathFragment{FieldName: w.String()}})
+	if !ok {
+		return nil, fmt.Errorf("misuse of window function %s", w.Fn)
+	}
+
+	return v, nil
+}
+
+// EvalPartition computes the value of the function for every document of the partition.
+func (w *Window) EvalPartition(p *Partition) ([]types.Value, error) {
+	switch t := w.Fn.(type) {
+	case WindowFunction:
+		return t.EvalPartition(p)
+	case expr.AggregatorBuilder:
+		return w.evalAggregate(t, p)
+	}
+
+	return nil, fmt.Errorf("%s is not a window function", w.Fn)
+}
+
+func (w *Window) evalAggregate(b expr.AggregatorBuilder, p *Partition) ([]types.Value, error) {
+	values := make([]types.Value, len(p.Envs))
+	agg := b.Aggregator()
+
+	var starts, ends []int
+	if p.Ordered {
+		starts, ends = p.peerGroupBounds()
+	}
+
+	for i, env := range p.Envs {
+		err := agg.Aggregate(env)
+		if err != nil {
+			return nil, err
+		}
+
+		// wait until the last document of the peer group, or
+		// of the partition if the window is not ordered
+		end := len(p.Envs) - 1
+		if p.Ordered {
+			end = ends[i]
+		}
+		if i < end {
+			continue
+		}
+
+		v, err := agg.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		start := 0
+		if p.Ordered {
+			start = starts[i]
+		}
+		for j := start; j <= i; j++ {
+			values[j] = v
+		}
+	}
+
+	return values, nil
+}
+
+// SameWindow returns true if both windows partition and order documents the same way.
+func (w *Window) SameWindow(other *Window) bool {
+	if len(w.PartitionBy) != len(other.PartitionBy) {
+		return false
+	}
+
+	for i := range w.PartitionBy {
+		if !expr.Equal(w.PartitionBy[i], other.PartitionBy[i]) {
+			return false
+		}
+	}
+
+	return expr.Equal(w.OrderBy, other.OrderBy) && w.Desc == other.Desc
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (w *Window) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Window)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(w.Fn, o.Fn) && w.SameWindow(o)
+}
+
+func (w *Window) Params() []expr.Expr {
+	params := append([]expr.Expr{}, w.Fn.Params()...)
+	params = append(params, w.PartitionBy...)
+	if w.OrderBy != nil {
+		params = append(params, w.OrderBy)
+	}
+
+	return params
+}
+
+func (w *Window) String() string {
+	var parts []string
+
+	if len(w.PartitionBy) > 0 {
+		exprs := make([]string, len(w.PartitionBy))
+		for i, e := range w.PartitionBy {
+			exprs[i] = fmt.Sprintf("%v", e)
+		}
+		parts = append(parts, "PARTITION BY "+strings.Join(exprs, ", "))
+	}
+
+	if w.OrderBy != nil {
+		if w.Desc {
+			parts = append(parts, fmt.Sprintf("ORDER BY %v DESC", w.OrderBy))
+		} else {
+			parts = append(parts, fmt.Sprintf("ORDER BY %v", w.OrderBy))
+		}
+	}
+
+	return fmt.Sprintf("%v OVER (%s)", w.Fn, strings.Join(parts, " "))
+}
+
+// RowNumber is the ROW_NUMBER window function.
+// It returns the number of the document within its partition, starting at 1.
+type RowNumber struct{}
+
+// Eval returns an error: ROW_NUMBER() can only be evaluated by a window.
+func (r *RowNumber) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, errors.New("misuse of window function ROW_NUMBER()")
+}
+
+// EvalPartition implements the WindowFunction interface.
+func (r *RowNumber) EvalPartition(p *Partition) ([]types.Value, error) {
+	values := make([]types.Value, len(p.Envs))
+	for i := range values {
+		values[i] = types.NewIntegerValue(int64(i + 1))
+	}
+
+	return values, nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (r *RowNumber) IsEqual(other expr.Expr) bool {
+	_, ok := other.(*RowNumber)
+	return ok
+}
+
+func (r *RowNumber) Params() []expr.Expr { return nil }
+
+func (r *RowNumber) String() string {
+	return "ROW_NUMBER()"
+}
+
+// Rank is the RANK window function.
+// It returns the rank of the document within its partition, with gaps:
+// peers have the same rank, and the next document is ranked
+// after the number of documents that precede it.
+type Rank struct{}
+
+// Eval returns an error: RANK() can only be evaluated by a window.
+func (r *Rank) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, errors.New("misuse of window function RANK()")
+}
+
+// EvalPartition implements the WindowFunction interface.
+func (r *Rank) EvalPartition(p *Partition) ([]types.Value, error) {
+	values := make([]types.Value, len(p.Envs))
+	starts, _ := p.peerGroupBounds()
+	for i := range values {
+		values[i] = types.NewIntegerValue(int64(starts[i] + 1))
+	}
+
+	return values, nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (r *Rank) IsEqual(other expr.Expr) bool {
+	_, ok := other.(*Rank)
+	return ok
+}
+
+func (r *Rank) Params() []expr.Expr { return nil }
+
+func (r *Rank) String() string {
+	return "RANK()"
+}
+
+// DenseRank is the DENSE_RANK window function.
+// It returns the rank of the document within its partition, without gaps.
+type DenseRank struct{}
+
+// Eval returns an error: DENSE_RANK() can only be evaluated by a window.
+func (r *DenseRank) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, errors.New("misuse of window function DENSE_RANK()")
+}
+
+// EvalPartition implements the WindowFunction interface.
+func (r *DenseRank) EvalPartition(p *Partition) ([]types.Value, error) {
+	values := make([]types.Value, len(p.Envs))
+	for i := range values {
+		values[i] = types.NewIntegerValue(int64(p.Peers[i] + 1))
+	}
+
+	return values, nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (r *DenseRank) IsEqual(other expr.Expr) bool {
+	_, ok := other.(*DenseRank)
+	return ok
+}
+
+func (r *DenseRank) Params() []expr.Expr { return nil }
+
+func (r *DenseRank) String() string {
+	return "DENSE_RANK()"
+}
+
+// Lag is the LAG window function.
+// It returns the value of Expr for the document located Offset documents
+// before the current one within the partition, or Default if there is none.
+// Offset defaults to 1 and Default to NULL.
+type Lag struct {
+	Expr    expr.Expr
+	Offset  expr.Expr
+	Default expr.Expr
+}
+
+// Eval returns an error: LAG() can only be evaluated by a window.
+func (l *Lag) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, errors.New("misuse of window function LAG()")
+}
+
+// EvalPartition implements the WindowFunction interface.
+func (l *Lag) EvalPartition(p *Partition) ([]types.Value, error) {
+	return evalOffset(p, l.Expr, l.Offset, l.Default, -1)
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (l *Lag) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Lag)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(l.Expr, o.Expr) && expr.Equal(l.Offset, o.Offset) && expr.Equal(l.Default, o.Default)
+}
+
+func (l *Lag) Params() []expr.Expr { return offsetParams(l.Expr, l.Offset, l.Default) }
+
+func (l *Lag) String() string {
+	return fmt.Sprintf("LAG(%s)", joinParams(l.Params()))
+}
+
+// Lead is the LEAD window function.
+// It returns the value of Expr for the document located Offset documents
+// after the current one within the partition, or Default if there is none.
+// Offset defaults to 1 and Default to NULL.
+type Lead struct {
+	Expr    expr.Expr
+	Offset  expr.Expr
+	Default expr.Expr
+}
+
+// Eval returns an error: LEAD() can only be evaluated by a window.
+func (l *Lead) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, errors.New("misuse of window function LEAD()")
+}
+
+// EvalPartition implements the WindowFunction interface.
+func (l *Lead) EvalPartition(p *Partition) ([]types.Value, error) {
+	return evalOffset(p, l.Expr, l.Offset, l.Default, 1)
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (l *Lead) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Lead)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(l.Expr, o.Expr) && expr.Equal(l.Offset, o.Offset) && expr.Equal(l.Default, o.Default)
+}
+
+func (l *Lead) Params() []expr.Expr { return offsetParams(l.Expr, l.Offset, l.Default) }
+
+func (l *Lead) String() string {
+	return fmt.Sprintf("LEAD(%s)", joinParams(l.Params()))
+}
+
+// evalOffset evaluates e for the document located offset documents before (direction = -1)
+// or after (direction = 1) each document of the partition.
+func evalOffset(p *Partition, e, offset, def expr.Expr, direction int) ([]types.Value, error) {
+	values := make([]types.Value, len(p.Envs))
+
+	for i, env := range p.Envs {
+		n := int64(1)
+		if offset != nil {
+			v, err := offset.Eval(env)
+			if err != nil {
+				return nil, err
+			}
+			if v.Type() != types.IntegerValue {
+				return nil, fmt.Errorf("offset must be an integer, got %s", v.Type())
+			}
+			n = types.As[int64](v)
+		}
+
+		j := int64(i) + n*int64(direction)
+		if j >= 0 && j < int64(len(p.Envs)) {
+			v, err := e.Eval(p.Envs[j])
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+			continue
+		}
+
+		if def == nil {
+			values[i] = types.NewNullValue()
+			continue
+		}
+
+		v, err := def.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+func offsetParams(e, offset, def expr.Expr) []expr.Expr {
+	params := []expr.Expr{e}
+	if offset != nil {
+		params = append(params, offset)
+	}
+	if def != nil {
+		params = append(params, def)
+	}
+
+	return params
+}
+
+func joinParams(params []expr.Expr) string {
+	strs := make([]string, len(params))
+	for i, p := range params {
+		strs[i] = fmt.Sprintf("%v", p)
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// FirstValue is the FIRST_VALUE window function.
+// It returns the value of Expr for the first document of the partition.
+type FirstValue struct {
+	Expr expr.Expr
+}
+
+// Eval returns an error: FIRST_VALUE() can only be evaluated by a window.
+func (f *FirstValue) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, errors.New("misuse of window function FIRST_VALUE()")
+}
+
+// EvalPartition implements the WindowFunction interface.
+func (f *FirstValue) EvalPartition(p *Partition) ([]types.Value, error) {
+	values := make([]types.Value, len(p.Envs))
+	if len(p.Envs) == 0 {
+		return values, nil
+	}
+
+	v, err := f.Expr.Eval(p.Envs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range values {
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (f *FirstValue) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*FirstValue)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(f.Expr, o.Expr)
+}
+
+func (f *FirstValue) Params() []expr.Expr { return []expr.Expr{f.Expr} }
+
+func (f *FirstValue) String() string {
+	return fmt.Sprintf("FIRST_VALUE(%v)", f.Expr)
+}
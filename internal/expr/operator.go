@@ -115,6 +115,13 @@ func (op *ConcatOperator) Eval(env *environment.Environment) (types.Value, error
 type Cast struct {
 	Expr   Expr
 	CastAs types.ValueType
+
+	// Precision and Scale of DECIMAL casts.
+	// If Precision is zero, the decimal is not constrained.
+	Precision, Scale int
+
+	// Date is true for DATE casts, which truncate timestamps to midnight UTC.
+	Date bool
 }
 
 // Eval returns the primary key of the current document.
@@ -124,7 +131,21 @@ func (c Cast) Eval(env *environment.Environment) (types.Value, error) {
 		return v, err
 	}
 
-	return document.CastAs(v, c.CastAs)
+	if c.Date {
+		return document.CastAsDate(v)
+	}
+
+	v, err = document.CastAs(v, c.CastAs)
+	if err != nil || c.Precision == 0 || v.Type() != types.DecimalValue {
+		return v, err
+	}
+
+	d, err := types.As[types.Decimal](v).Fit(c.Precision, c.Scale)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewDecimalValue(d), nil
 }
 
 // IsEqual compares this expression with the other expression and returns
@@ -139,7 +160,7 @@ func (c Cast) IsEqual(other Expr) bool {
 		return false
 	}
 
-	if c.CastAs != o.CastAs {
+	if c.CastAs != o.CastAs || c.Precision != o.Precision || c.Scale != o.Scale || c.Date != o.Date {
 		return false
 	}
 
@@ -153,5 +174,13 @@ func (c Cast) IsEqual(other Expr) bool {
 func (c Cast) Params() []Expr { return []Expr{c.Expr} }
 
 func (c Cast) String() string {
+	if c.Date {
+		return fmt.Sprintf("CAST(%v AS DATE)", c.Expr)
+	}
+
+	if c.Precision != 0 {
+		return fmt.Sprintf("CAST(%v AS %v(%d, %d))", c.Expr, c.CastAs, c.Precision, c.Scale)
+	}
+
 	return fmt.Sprintf("CAST(%v AS %v)", c.Expr, c.CastAs)
 }
@@ -17,10 +17,6 @@ func (p Path) Eval(env *environment.Environment) (types.Value, error) {
 		return NullLiteral, nil
 	}
 
-	d, ok := env.GetDocument()
-	if !ok {
-		return NullLiteral, types.ErrFieldNotFound
-	}
 	dp := document.Path(p)
 
 	v, ok := env.Get(dp)
@@ -28,14 +24,122 @@ func (p Path) Eval(env *environment.Environment) (types.Value, error) {
 		return v, nil
 	}
 
+	d, ok := env.GetDocument()
+	if !ok {
+		return NullLiteral, types.ErrFieldNotFound
+	}
+
 	v, err := dp.GetValueFromDocument(d)
 	if errors.Is(err, types.ErrFieldNotFound) {
+		// the path might be qualified with the name of the table
+		if tn, ok := env.Get(environment.TableKey); ok && len(dp) > 1 && tn.Type() == types.TextValue && dp[0].FieldName == types.As[string](tn) {
+			v, err = dp[1:].GetValueFromDocument(d)
+			if err == nil {
+				return v, nil
+			}
+			if !errors.Is(err, types.ErrFieldNotFound) {
+				return nil, err
+			}
+		}
+
+		// when tables are joined, the path might reference
+		// a field of one of the joined tables without being qualified
+		if aliases, ok := env.GetAliases(); ok {
+			v, err := getValueFromJoinedDocuments(dp, d, aliases)
+			if err == nil {
+				return v, nil
+			}
+			if !errors.Is(err, types.ErrFieldNotFound) {
+				return nil, err
+			}
+		}
+
+		// within a subquery, a path qualified with the name or the alias of
+		// a table of the outer query references the document of the outer query.
+		// Since documents are schemaless, a missing unqualified field never does.
+		if outer, ok := env.GetOuterScope(); ok && len(dp) > 1 && isRelationOf(outer, dp[0].FieldName) {
+			env.SetReadsOuter()
+
+			v, err := p.Eval(outer)
+			if errors.Is(err, types.ErrFieldNotFound) {
+				return NullLiteral, nil
+			}
+			return v, err
+		}
+
 		return NullLiteral, nil
 	}
 
 	return v, err
 }
 
+// isRelationOf returns true if name is the name or the alias
+// of a table read by the query of env.
+func isRelationOf(env *environment.Environment, name string) bool {
+	if tn, ok := env.Get(environment.TableKey); ok && tn.Type() == types.TextValue && types.As[string](tn) == name {
+		return true
+	}
+
+	aliases, ok := env.GetAliases()
+	if !ok {
+		return false
+	}
+
+	var found bool
+	_ = aliases.Iterate(func(i int, a types.Value) error {
+		if a.Type() == types.TextValue && types.As[string](a) == name {
+			found = true
+		}
+		return nil
+	})
+
+	return found
+}
+
+// getValueFromJoinedDocuments looks for the path in the documents of every joined table.
+// It returns an error if the path is found in the documents of more than one table.
+func getValueFromJoinedDocuments(p document.Path, d types.Document, aliases types.Array) (types.Value, error) {
+	var found types.Value
+	var foundIn string
+
+	err := aliases.Iterate(func(i int, a types.Value) error {
+		alias := types.As[string](a)
+
+		v, err := d.GetByField(alias)
+		if err != nil {
+			if errors.Is(err, types.ErrFieldNotFound) {
+				return nil
+			}
+			return err
+		}
+		if v.Type() != types.DocumentValue {
+			return nil
+		}
+
+		v, err = p.GetValueFromDocument(types.As[types.Document](v))
+		if err != nil {
+			if errors.Is(err, types.ErrFieldNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if found != nil {
+			return errors.Errorf("ambiguous column %q, found in %q and %q", p, foundIn, alias)
+		}
+		found, foundIn = v, alias
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, types.ErrFieldNotFound
+	}
+
+	return found, nil
+}
+
 // IsEqual compares this expression with the other expression and returns
 // true if they are equal.
 func (p Path) IsEqual(other Expr) bool {
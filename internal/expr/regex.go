@@ -0,0 +1,82 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/types"
+)
+
+type RegexOperator struct {
+	*simpleOperator
+
+	// last compiled pattern, only used if the pattern is constant
+	re atomic.Value
+}
+
+// Regex creates an expression that evaluates to the result of a =~ b.
+// b is a regular expression using the syntax of the Go regexp package.
+func Regex(a, b Expr) Expr {
+	return &RegexOperator{simpleOperator: &simpleOperator{a, b, scanner.EQREGEX}}
+}
+
+func (op *RegexOperator) Eval(env *environment.Environment) (types.Value, error) {
+	return op.simpleOperator.eval(env, func(a, b types.Value) (types.Value, error) {
+		if a.Type() != types.TextValue || b.Type() != types.TextValue {
+			return NullLiteral, nil
+		}
+
+		re, err := op.compile(types.As[string](b))
+		if err != nil {
+			return NullLiteral, err
+		}
+
+		if re.MatchString(types.As[string](a)) {
+			return TrueLiteral, nil
+		}
+
+		return FalseLiteral, nil
+	})
+}
+
+// compile the pattern. If the pattern doesn't depend on the document,
+// it is only compiled once and reused for the next evaluations.
+func (op *RegexOperator) compile(pattern string) (*regexp.Regexp, error) {
+	switch op.b.(type) {
+	case LiteralValue, PositionalParam, NamedParam:
+	default:
+		return regexp.Compile(pattern)
+	}
+
+	if re, ok := op.re.Load().(*regexp.Regexp); ok && re.String() == pattern {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	op.re.Store(re)
+
+	return re, nil
+}
+
+type NotRegexOperator struct {
+	*RegexOperator
+}
+
+// NotRegex creates an expression that evaluates to the result of a !~ b.
+func NotRegex(a, b Expr) Expr {
+	return &NotRegexOperator{&RegexOperator{simpleOperator: &simpleOperator{a, b, scanner.NEQREGEX}}}
+}
+
+func (op *NotRegexOperator) Eval(env *environment.Environment) (types.Value, error) {
+	return invertBoolResult(op.RegexOperator.Eval)(env)
+}
+
+func (op *NotRegexOperator) String() string {
+	return fmt.Sprintf("%v !~ %v", op.a, op.b)
+}
@@ -0,0 +1,117 @@
+package planner
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/index"
+)
+
+// UseIndexOnlyScanRule turns the index.Scan selected by SelectIndex into an index-only scan
+// if every path used by the stream is stored in the index or in the primary key of the table.
+// The documents are then built from the index entries instead of being read from the table.
+// Given the following index:
+//   CREATE INDEX foo_a_b_idx ON foo (a, b)
+// and this query:
+//   SELECT a, b FROM foo WHERE a = 1
+//   index.Scan("foo_a_b_idx", [{"min": [1], "exact": true}]) | docs.Project(a, b)
+// the scan is replaced by:
+//   index.IndexOnlyScan("foo_a_b_idx", [{"min": [1], "exact": true}]) | docs.Project(a, b)
+// Only top-level fields can be read from the index. Multikey indexes, which don't store
+// the whole array, and streams using expressions that might read the whole document,
+// like wildcards or subqueries, are not selected.
+func UseIndexOnlyScanRule(sctx *StreamContext) error {
+	scan, ok := sctx.Stream.First().(*index.ScanOperator)
+	if !ok {
+		return nil
+	}
+
+	info, err := sctx.Catalog.GetIndexInfo(scan.IndexName)
+	if err != nil {
+		return err
+	}
+
+	if info.MultikeyPos() >= 0 {
+		return nil
+	}
+
+	ti, err := sctx.Catalog.GetTableInfo(info.Owner.TableName)
+	if err != nil {
+		return err
+	}
+
+	var paths document.Paths
+	paths = append(paths, info.Paths...)
+	if pk := ti.GetPrimaryKey(); pk != nil {
+		paths = append(paths, pk.Paths...)
+	}
+
+	// without projection, the scanned documents are returned as is
+	var projected bool
+
+	for n := scan.GetNext(); n != nil; n = n.GetNext() {
+		var exprs []expr.Expr
+
+		switch t := n.(type) {
+		case *docs.FilterOperator:
+			exprs = append(exprs, t.Expr)
+		case *docs.ProjectOperator:
+			projected = true
+			exprs = append(exprs, t.Exprs...)
+		case *docs.TempTreeSortOperator:
+			exprs = append(exprs, t.Expr)
+		case *docs.TakeOperator:
+			exprs = append(exprs, t.E)
+		case *docs.SkipOperator:
+			exprs = append(exprs, t.E)
+		case *docs.GroupAggregateOperator:
+			projected = true
+			exprs = append(exprs, t.E)
+			for _, b := range t.Builders {
+				exprs = append(exprs, b)
+			}
+		default:
+			return nil
+		}
+
+		for _, e := range exprs {
+			if !exprIsCovered(e, paths) {
+				return nil
+			}
+		}
+	}
+
+	scan.IndexOnly = projected
+	return nil
+}
+
+// exprIsCovered returns whether the expression only uses top-level paths
+// that are part of the given list.
+func exprIsCovered(e expr.Expr, paths document.Paths) bool {
+	covered := true
+
+	expr.Walk(e, func(e expr.Expr) bool {
+		switch t := e.(type) {
+		case expr.Path:
+			covered = len(t) == 1 && pathsContain(paths, document.Path(t))
+		case expr.LiteralValue, expr.LiteralExprList, *expr.KVPairs, expr.Parentheses, *expr.NamedExpr,
+			expr.NamedParam, expr.PositionalParam, expr.Operator, expr.Function:
+		default:
+			covered = false
+		}
+
+		return covered
+	})
+
+	return covered
+}
+
+func pathsContain(paths document.Paths, p document.Path) bool {
+	for _, pp := range paths {
+		if pp.IsEqual(p) {
+			return true
+		}
+	}
+
+	return false
+}
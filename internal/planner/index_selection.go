@@ -1,13 +1,17 @@
 package planner
 
 import (
+	"regexp/syntax"
+
 	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/types"
 )
 
 // SelectIndex attempts to replace a sequential scan by an index scan or a pk scan by
@@ -23,6 +27,11 @@ import (
 // path: path of a document
 // compatible operator: one of =, >, >=, <, <=, IN
 // expression: any expression
+// Regular expressions anchored at the beginning of the text and starting with
+// a literal prefix can also be selected:
+//   <path> =~ '^prefix...'
+// The index is then used to read the values starting with that prefix, and the
+// filter node is kept to match the rest of the pattern.
 //
 // Index compatibility.
 //
@@ -55,12 +64,40 @@ import (
 // foo_a_b_c_idx only matches with the first two filter nodes because while the first node uses the equal
 // operator, the second one doesn't, and thus the third node cannot be selected as well.
 //
+// Expression indexes.
+//
+// The path of a filter node can also be any expression using paths, like strings.lower(a).
+// Given the following index:
+//   CREATE INDEX foo_lower_a_idx ON foo (strings.lower(a))
+// the filter node strings.lower(a) = 'foo' can be selected because its expression
+// is structurally equal to the indexed expression.
+//
+// Multikey indexes.
+//
+// An index on the elements of an array stores one entry per element:
+//   CREATE INDEX foo_a_idx ON foo (a[*])
+// The path a[*] is associated with filter nodes comparing any element of the array,
+// like a[*] > 5, and with the membership of the array, 1 IN a, which is read as a[*] = 1.
+// Since a document can be read multiple times, index.Scan removes duplicates.
+//
+// Partial indexes.
+//
+// An index created with a WHERE clause only contains the documents matching its predicate.
+// It is only associated with filter nodes if the filters of the query imply the predicate,
+// see predicateIsImplied.
+//
 // Candidates and cost
 //
 // Because a table can have multiple indexes, we need to establish which of these
 // indexes should be used to run the query, if not all of them.
 // For that we generate a cost for each selected index and return the one with the cheapest cost.
 func SelectIndex(sctx *StreamContext) error {
+	// Select the indexes used to lookup joined tables, if any.
+	err := selectJoinIndexes(sctx)
+	if err != nil {
+		return err
+	}
+
 	// Lookup the seq scan node.
 	// We will assume that at this point
 	// if there is one it has to be the
@@ -75,11 +112,17 @@ func SelectIndex(sctx *StreamContext) error {
 	}
 
 	// ensure the table exists
-	_, err := sctx.Catalog.GetTableInfo(seq.TableName)
+	_, err = sctx.Catalog.GetTableInfo(seq.TableName)
 	if err != nil {
 		return err
 	}
 
+	// if the documents of the table are aliased, filter nodes
+	// reference aliased paths and cannot be associated with the indexes of the table
+	if _, ok := seq.GetNext().(*docs.AliasOperator); ok {
+		return nil
+	}
+
 	// ensure the list of filter nodes is not empty
 	if len(sctx.Filters) == 0 && len(sctx.TempTreeSorts) == 0 {
 		return nil
@@ -141,7 +184,7 @@ func (i *indexSelector) selectIndex() error {
 	}
 	pk := tb.GetPrimaryKey()
 	if pk != nil {
-		selected = i.associateIndexWithNodes(tb.TableName, false, false, pk.Paths, nodes)
+		selected = i.associateIndexWithNodes(tb.TableName, false, false, pk.Paths, pathsToExprs(pk.Paths), nodes)
 		if selected != nil {
 			cost = selected.Cost()
 		}
@@ -155,7 +198,13 @@ func (i *indexSelector) selectIndex() error {
 			return err
 		}
 
-		candidate := i.associateIndexWithNodes(idxInfo.IndexName, true, idxInfo.Unique, idxInfo.Paths, nodes)
+		// a partial index can only be used if it contains every document
+		// returned by the query
+		if !i.predicateIsImplied(tb, idxInfo) {
+			continue
+		}
+
+		candidate := i.associateIndexWithNodes(idxInfo.IndexName, true, idxInfo.Unique, idxInfo.Paths, indexKeys(idxInfo), nodes)
 
 		if candidate == nil {
 			continue
@@ -183,7 +232,11 @@ func (i *indexSelector) selectIndex() error {
 	for _, f := range selected.nodes {
 		switch tp := f.node.(type) {
 		case *docs.FilterOperator:
-			i.sctx.removeFilterNode(tp)
+			// the index only returns the values matching the prefix of the
+			// regular expression, the filter must still be applied
+			if f.operator != scanner.EQREGEX {
+				i.sctx.removeFilterNode(tp)
+			}
 			if f.orderBy != nil {
 				i.sctx.removeTempTreeNodeNode(f.orderBy.node.(*docs.TempTreeSortOperator))
 			}
@@ -207,6 +260,160 @@ func (i *indexSelector) selectIndex() error {
 	return nil
 }
 
+// predicateIsImplied returns whether the filters of the query imply the predicate of the index.
+// Every condition of the predicate combined with AND must be implied by one of the filter nodes,
+// see filterImplies.
+// Given the following index:
+//   CREATE INDEX foo_a_idx ON foo (a) WHERE b = 1 AND c > 2
+// it can be selected for this query:
+//   SELECT * FROM foo WHERE a > 5 AND c > 10 AND b = 1
+// but not for this one:
+//   SELECT * FROM foo WHERE a > 5 AND c > 1 AND b = 1
+func (i *indexSelector) predicateIsImplied(tb *database.TableInfo, info *database.IndexInfo) bool {
+	if info.Predicate == nil {
+		return true
+	}
+
+	ce, ok := info.Predicate.(*expr.ConstraintExpr)
+	if !ok {
+		return false
+	}
+
+	for _, cond := range splitANDExpr(ce.Expr) {
+		var found bool
+		for _, f := range i.sctx.Filters {
+			if filterImplies(tb, f.Expr, cond) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterImplies returns whether every document matching the filter matches the condition.
+// It is the case if:
+//   - the filter and the condition are equal
+//   - both compare the same path with a literal and the range of the filter is included
+//     in the range of the condition: a = 20 and a > 20 imply a > 10
+//   - the condition is a path and the filter compares it with true: a = true implies a
+//   - the condition compares a BOOL field with true and the filter is its path: a implies a = true
+func filterImplies(tb *database.TableInfo, f, cond expr.Expr) bool {
+	if expr.Equal(f, cond) {
+		return true
+	}
+
+	fp, ftok, fv, ok := pathComparison(f)
+	if !ok {
+		// a BOOL field is only truthy if it is true
+		p, ok := f.(expr.Path)
+		if !ok {
+			return false
+		}
+		fc := tb.FieldConstraints.GetFieldConstraintForPath(document.Path(p))
+		if fc == nil || fc.Type != types.BooleanValue {
+			return false
+		}
+		fp, ftok, fv = document.Path(p), scanner.EQ, types.NewBoolValue(true)
+	}
+
+	if p, ok := cond.(expr.Path); ok {
+		return ftok == scanner.EQ && fp.IsEqual(document.Path(p)) && fv.Type() == types.BooleanValue && types.As[bool](fv)
+	}
+
+	cp, ctok, cv, ok := pathComparison(cond)
+	if !ok || !fp.IsEqual(cp) {
+		return false
+	}
+
+	// only values of the same type are ordered
+	if fv.Type() != cv.Type() && !(fv.Type().IsNumber() && cv.Type().IsNumber()) {
+		return false
+	}
+
+	switch {
+	case ftok == scanner.EQ:
+		return compareValues(ctok, fv, cv)
+	case ftok == scanner.GT && (ctok == scanner.GT || ctok == scanner.GTE),
+		ftok == scanner.GTE && ctok == scanner.GTE:
+		return compareValues(scanner.GTE, fv, cv)
+	case ftok == scanner.GTE && ctok == scanner.GT:
+		return compareValues(scanner.GT, fv, cv)
+	case ftok == scanner.LT && (ctok == scanner.LT || ctok == scanner.LTE),
+		ftok == scanner.LTE && ctok == scanner.LTE:
+		return compareValues(scanner.LTE, fv, cv)
+	case ftok == scanner.LTE && ctok == scanner.LT:
+		return compareValues(scanner.LT, fv, cv)
+	}
+
+	return false
+}
+
+// pathComparison returns the path, the operator and the value of a comparison
+// between a path and a literal. The operator is reversed if the literal is the left operand.
+func pathComparison(e expr.Expr) (document.Path, scanner.Token, types.Value, bool) {
+	op, ok := e.(expr.Operator)
+	if !ok {
+		return nil, 0, nil, false
+	}
+
+	tok := op.Token()
+	switch tok {
+	case scanner.EQ, scanner.GT, scanner.GTE, scanner.LT, scanner.LTE:
+	default:
+		return nil, 0, nil, false
+	}
+
+	p, ok := op.LeftHand().(expr.Path)
+	lv, isLiteral := op.RightHand().(expr.LiteralValue)
+	if !ok || !isLiteral {
+		p, ok = op.RightHand().(expr.Path)
+		lv, isLiteral = op.LeftHand().(expr.LiteralValue)
+		if !ok || !isLiteral {
+			return nil, 0, nil, false
+		}
+
+		switch tok {
+		case scanner.GT:
+			tok = scanner.LT
+		case scanner.GTE:
+			tok = scanner.LTE
+		case scanner.LT:
+			tok = scanner.GT
+		case scanner.LTE:
+			tok = scanner.GTE
+		}
+	}
+
+	return document.Path(p), tok, lv.Value, true
+}
+
+// compareValues returns the result of the comparison of a and b with the given operator.
+func compareValues(tok scanner.Token, a, b types.Value) bool {
+	var ok bool
+	var err error
+
+	switch tok {
+	case scanner.EQ:
+		ok, err = types.IsEqual(a, b)
+	case scanner.GT:
+		ok, err = types.IsGreaterThan(a, b)
+	case scanner.GTE:
+		ok, err = types.IsGreaterThanOrEqual(a, b)
+	case scanner.LT:
+		ok, err = types.IsLesserThan(a, b)
+	case scanner.LTE:
+		ok, err = types.IsLesserThanOrEqual(a, b)
+	}
+
+	return ok && err == nil
+}
+
 func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode {
 	// only operators can associate this node to an index
 	op, ok := f.Expr.(expr.Operator)
@@ -220,15 +427,22 @@ func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode
 	}
 
 	// determine if the operator could benefit from an index
-	ok, path, e := operatorCanUseIndex(op)
+	ok, indexed, e := operatorCanUseIndex(op)
 	if !ok {
 		return nil
 	}
 
+	// the membership of an array (1 IN a) is an equality
+	// on the elements of the array (a[*] = 1)
+	operator := op.Token()
+	if _, ok := e.(expr.LiteralExprList); operator == scanner.IN && !ok {
+		operator = scanner.EQ
+	}
+
 	node := indexableNode{
 		node:     f,
-		path:     path,
-		operator: op.Token(),
+		indexed:  indexed,
+		operator: operator,
 		operand:  e,
 	}
 
@@ -237,21 +451,20 @@ func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode
 
 func (i *indexSelector) isTempTreeSortIndexable(n *docs.TempTreeSortOperator) *indexableNode {
 	// only paths can be associated with an index
-	path, ok := n.Expr.(expr.Path)
-	if !ok {
+	if _, ok := n.Expr.(expr.Path); !ok {
 		return nil
 	}
 
 	return &indexableNode{
 		node:     n,
-		path:     document.Path(path),
+		indexed:  n.Expr,
 		desc:     n.Desc,
 		operator: scanner.ORDER,
 	}
 }
 
 // for a given index, select all filter nodes that match according to the following rules:
-// - from left to right, associate each indexed path to a filter node and stop when there is no
+// - from left to right, associate each indexed path or expression to a filter node and stop when there is no
 // node available or the node is not compatible
 // - for n associated nodes, the n - 1 first must all use the = operator, only the last one
 // can be any of =, >, >=, <, <=
@@ -264,14 +477,15 @@ func (i *indexSelector) isTempTreeSortIndexable(n *docs.TempTreeSortOperator) *i
 //   -> range = {min: [3], exact: true}
 //  docs.Filter(a IN (1, 2))
 //   -> ranges = [1], [2]
-func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, isUnique bool, paths []document.Path, nodes indexableNodes) *candidate {
+// The keys are the indexed expressions, which are the paths of the index, except for expression indexes.
+func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, isUnique bool, paths []document.Path, keys []expr.Expr, nodes indexableNodes) *candidate {
 	found := make([]*indexableNode, 0, len(paths))
 	var desc bool
 
 	var hasIn bool
 	var sorter *indexableNode
-	for _, p := range paths {
-		ns := nodes.getByPath(p)
+	for _, k := range keys {
+		ns := nodes.getByExpr(k)
 		if len(ns) == 0 {
 			break
 		}
@@ -369,7 +583,7 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 	var ranges stream.Ranges
 
 	if !hasIn {
-		ranges = stream.Ranges{i.buildRangeFromFilterNodes(found...)}
+		ranges = stream.Ranges{i.buildRangeFromFilterNodes(paths[:len(found)], found...)}
 	} else {
 		ranges = i.buildRangesFromFilterNodes(paths, found)
 	}
@@ -465,12 +679,10 @@ func (i *indexSelector) walkExpr(l [][]expr.Expr, fn func(row []expr.Expr)) {
 	}
 }
 
-func (i *indexSelector) buildRangeFromFilterNodes(filters ...*indexableNode) stream.Range {
-	// first, generate a list of paths and a list of expressions
-	paths := make([]document.Path, 0, len(filters))
+func (i *indexSelector) buildRangeFromFilterNodes(paths []document.Path, filters ...*indexableNode) stream.Range {
+	// first, generate a list of expressions
 	el := make(expr.LiteralExprList, 0, len(filters))
 	for i := range filters {
-		paths = append(paths, filters[i].path)
 		el = append(el, filters[i].operand)
 	}
 
@@ -501,7 +713,7 @@ func (i *indexSelector) buildRangeFromOperator(lastOp scanner.Token, paths []doc
 		rng.Max = el
 	case scanner.LTE:
 		rng.Max = el
-	case scanner.BETWEEN:
+	case scanner.BETWEEN, scanner.EQREGEX:
 		/* example:
 		CREATE TABLE test(a int, b int, c int, d int, e int);
 		CREATE INDEX on test(a, b, c, d);
@@ -539,21 +751,23 @@ type indexableNode struct {
 	// For filter nodes
 	// the expression of the node
 	// has been broken into
-	// <path> <operator> <operand>
+	// <indexed> <operator> <operand>
 	// Ex:   WHERE a.b[0] > 5 + 5
 	// Gives:
-	// - path: a.b[0]
+	// - indexed: a.b[0]
 	// - operator: scanner.GT
 	// - operand: 5 + 5
+	// The indexed expression is either a path or
+	// an expression using paths, like strings.lower(a).
 	// For TempTreeSort nodes
 	// the expression of the node
 	// has been broken into
-	// <path> <direction>
+	// <indexed> <direction>
 	// Ex:  ORDER BY a.b[0] ASC
 	// Gives:
-	// - path: a.b[0]
+	// - indexed: a.b[0]
 	// - desc: false
-	path     document.Path
+	indexed  expr.Expr
 	operator scanner.Token
 	operand  expr.Expr
 	desc     bool
@@ -565,13 +779,13 @@ type indexableNode struct {
 
 type indexableNodes []*indexableNode
 
-// getByPath returns all indexable nodes for the given path.
+// getByExpr returns all indexable nodes for the given path or expression.
 // TODO(asdine): add a rule that merges nodes that point to the
 // same path.
-func (n indexableNodes) getByPath(p document.Path) []*indexableNode {
+func (n indexableNodes) getByExpr(e expr.Expr) []*indexableNode {
 	var nodes []*indexableNode
 	for _, fn := range n {
-		if fn.path.IsEqual(p) {
+		if expr.Equal(fn.indexed, e) {
 			nodes = append(nodes, fn)
 		}
 	}
@@ -617,59 +831,112 @@ func (c *candidate) Cost() int {
 // operatorIsIndexCompatible returns whether the operator can be used to read from an index.
 func operatorIsIndexCompatible(op expr.Operator) bool {
 	switch op.Token() {
-	case scanner.EQ, scanner.GT, scanner.GTE, scanner.LT, scanner.LTE, scanner.IN, scanner.BETWEEN:
+	case scanner.EQ, scanner.GT, scanner.GTE, scanner.LT, scanner.LTE, scanner.IN, scanner.BETWEEN, scanner.EQREGEX:
 		return true
 	}
 
 	return false
 }
 
-func operatorCanUseIndex(op expr.Operator) (bool, document.Path, expr.Expr) {
-	lf, leftIsPath := op.LeftHand().(expr.Path)
-	rf, rightIsPath := op.RightHand().(expr.Path)
+// operatorCanUseIndex returns whether the operator can be used to read from an index
+// and breaks it into the indexed expression and the operand.
+// The indexed expression is a path or an expression using paths, the operand must not use any path.
+func operatorCanUseIndex(op expr.Operator) (bool, expr.Expr, expr.Expr) {
+	lh, rh := op.LeftHand(), op.RightHand()
+	leftHasPath := exprContainsPath(lh)
+	rightHasPath := exprContainsPath(rh)
 
 	// Special case for IN operator: only left operand is valid for index usage
 	// valid:   a IN [1, 2, 3]
-	// invalid: 1 IN a
 	// invalid: a IN (b + 1, 2)
+	// The membership of an array can only use a multikey index:
+	// 1 IN a is read from an index on a[*] as a[*] = 1
 	if op.Token() == scanner.IN {
-		if leftIsPath && !rightIsPath && !exprContainsPath(op.RightHand()) {
-			rh := op.RightHand()
+		if leftHasPath && !rightHasPath {
 			// The IN operator can use indexes only if the right hand side is an expression list.
 			if _, ok := rh.(expr.LiteralExprList); !ok {
 				return false, nil, nil
 			}
-			return true, document.Path(lf), rh
+			return true, lh, rh
+		}
+
+		if p, ok := rh.(expr.Path); ok && !leftHasPath {
+			if _, ok := document.Path(p).ElementsOf(); !ok {
+				return true, expr.Path(document.Path(p).ExtendIndex(document.AnyIndex)), lh
+			}
 		}
 
 		return false, nil, nil
 	}
 
 	// Special case for BETWEEN operator: Given this expression (x BETWEEN a AND b),
-	// we can only use the index if the "x" is a path and "a" and "b" don't contain path expressions.
+	// we can only use the index if "x" uses paths and "a" and "b" don't contain path expressions.
 	if op.Token() == scanner.BETWEEN {
 		bt := op.(*expr.BetweenOperator)
-		x, xIsPath := bt.X.(expr.Path)
-		if !xIsPath || exprContainsPath(bt.LeftHand()) || exprContainsPath(bt.RightHand()) {
+		if !exprContainsPath(bt.X) || leftHasPath || rightHasPath {
 			return false, nil, nil
 		}
 
-		return true, document.Path(x), expr.LiteralExprList{bt.LeftHand(), bt.RightHand()}
+		return true, bt.X, expr.LiteralExprList{lh, rh}
+	}
+
+	// Special case for the =~ operator: given this expression (x =~ '^abc.*'),
+	// we can only use the index if "x" uses paths and the pattern is a text literal
+	// that starts with a literal prefix anchored at the beginning of the text.
+	// The index is then read between the prefix and its successor.
+	if op.Token() == scanner.EQREGEX {
+		lv, ok := rh.(expr.LiteralValue)
+		if !leftHasPath || !ok || lv.Value.Type() != types.TextValue {
+			return false, nil, nil
+		}
+
+		min, max, ok := regexPrefixRange(types.As[string](lv.Value))
+		if !ok {
+			return false, nil, nil
+		}
+
+		return true, lh, expr.LiteralExprList{
+			expr.LiteralValue{Value: types.NewTextValue(min)},
+			expr.LiteralValue{Value: types.NewTextValue(max)},
+		}
 	}
 
 	// path OP expr
-	if leftIsPath && !rightIsPath && !exprContainsPath(op.RightHand()) {
-		return true, document.Path(lf), op.RightHand()
+	if leftHasPath && !rightHasPath {
+		return true, lh, rh
 	}
 
 	// expr OP path
-	if rightIsPath && !leftIsPath && !exprContainsPath(op.LeftHand()) {
-		return true, document.Path(rf), op.LeftHand()
+	if rightHasPath && !leftHasPath {
+		return true, rh, lh
 	}
 
 	return false, nil, nil
 }
 
+// pathsToExprs returns the paths as expressions.
+func pathsToExprs(paths []document.Path) []expr.Expr {
+	keys := make([]expr.Expr, len(paths))
+	for i, p := range paths {
+		keys[i] = expr.Path(p)
+	}
+
+	return keys
+}
+
+// indexKeys returns the expressions indexed by the index:
+// its paths, or the expressions of an expression index.
+func indexKeys(info *database.IndexInfo) []expr.Expr {
+	keys := pathsToExprs(info.Paths)
+	for i := range keys {
+		if ce, ok := info.ExprAt(i).(*expr.ConstraintExpr); ok {
+			keys[i] = ce.Expr
+		}
+	}
+
+	return keys
+}
+
 func exprContainsPath(e expr.Expr) bool {
 	var hasPath bool
 
@@ -683,3 +950,38 @@ func exprContainsPath(e expr.Expr) bool {
 
 	return hasPath
 }
+
+// regexPrefixRange returns the range of text values that can match
+// a regular expression anchored at the beginning of the text, based on its literal prefix.
+// Ex: '^abc.*' -> ['abc', 'abd']
+// It returns false if the pattern is not anchored or doesn't start with a literal.
+func regexPrefixRange(pattern string) (string, string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", "", false
+	}
+
+	if re.Op != syntax.OpConcat || len(re.Sub) < 2 || re.Sub[0].Op != syntax.OpBeginText {
+		return "", "", false
+	}
+
+	lit := re.Sub[1]
+	if lit.Op != syntax.OpLiteral || lit.Flags&syntax.FoldCase != 0 {
+		return "", "", false
+	}
+
+	prefix := string(lit.Rune)
+
+	// the upper bound is the smallest text greater than every text
+	// starting with the prefix
+	max := []byte(prefix)
+	for len(max) > 0 && max[len(max)-1] == 0xff {
+		max = max[:len(max)-1]
+	}
+	if len(max) == 0 {
+		return "", "", false
+	}
+	max[len(max)-1]++
+
+	return prefix, string(max), true
+}
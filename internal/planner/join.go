@@ -0,0 +1,162 @@
+package planner
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/index"
+	"github.com/genjidb/genji/internal/stream/table"
+)
+
+// selectJoinIndexes analyses the join condition of every nested loop join of the stream
+// and, if possible, uses the primary key or an index of the joined table
+// to lookup the matching documents instead of scanning the whole table.
+//
+// For a join condition to be selected, it must be of the following form:
+//   <alias>.<path> = <expression>
+// or
+//   <expression> = <alias>.<path>
+// where alias is the alias of the joined table and expression doesn't reference it.
+// Given the following index:
+//   CREATE INDEX bar_foo_id_idx ON bar (foo_id)
+// and this query:
+//   SELECT * FROM foo JOIN bar ON foo.id = bar.foo_id
+//   table.Scan("foo") | docs.Alias("foo") | nestedLoopJoin("bar", table.Scan("bar"), foo.id = bar.foo_id) | docs.Project(*)
+// the nested loop join is replaced by:
+//   index.Join("bar_foo_id_idx", "bar", foo.id, foo.id = bar.foo_id)
+// If the path is the primary key of the joined table, the table scan of the nested loop is
+// given a range instead:
+//   nestedLoopJoin("bar", table.Scan("bar", [{"min": [foo.bar_id], "exact": true}]), foo.bar_id = bar.id)
+func selectJoinIndexes(sctx *StreamContext) error {
+	for n := sctx.Stream.First(); n != nil; n = n.GetNext() {
+		j, ok := n.(*stream.NestedLoopJoinOperator)
+		if !ok {
+			continue
+		}
+
+		op, err := selectJoinIndex(sctx.Catalog, j)
+		if err != nil {
+			return err
+		}
+		if op == nil {
+			continue
+		}
+
+		stream.InsertBefore(j, op)
+		sctx.Stream.Remove(j)
+		n = op
+	}
+
+	return nil
+}
+
+func selectJoinIndex(catalog *database.Catalog, j *stream.NestedLoopJoinOperator) (stream.Operator, error) {
+	// only nested loops over a whole table can be optimized
+	seq, ok := j.Right.Op.(*table.ScanOperator)
+	if !ok || seq.GetPrev() != nil || len(seq.Ranges) > 0 || seq.Reverse {
+		return nil, nil
+	}
+
+	info, err := catalog.GetTableInfo(seq.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cond := range splitANDExpr(j.On) {
+		p, e := joinConditionOperands(j.Alias, cond)
+		if p == nil {
+			continue
+		}
+
+		// the primary key is always preferred
+		pk := info.GetPrimaryKey()
+		if pk != nil && len(pk.Paths) == 1 && pk.Paths[0].IsEqual(p) {
+			seq.Ranges = stream.Ranges{
+				{Min: expr.LiteralExprList{e}, Paths: pk.Paths, Exact: true},
+			}
+			return nil, nil
+		}
+
+		var selected *database.IndexInfo
+		for _, idxName := range catalog.ListIndexes(seq.TableName) {
+			idxInfo, err := catalog.GetIndexInfo(idxName)
+			if err != nil {
+				return nil, err
+			}
+
+			// partial indexes don't contain every document of the table
+			if idxInfo.Predicate != nil || !idxInfo.Paths[0].IsEqual(p) {
+				continue
+			}
+
+			if selected == nil || (idxInfo.Unique && !selected.Unique) {
+				selected = idxInfo
+			}
+		}
+
+		if selected == nil {
+			continue
+		}
+
+		if j.Left {
+			return index.LeftJoin(selected.IndexName, j.Alias, e, j.On), nil
+		}
+
+		return index.Join(selected.IndexName, j.Alias, e, j.On), nil
+	}
+
+	return nil, nil
+}
+
+// joinConditionOperands returns the path of the joined table compared by the condition,
+// without the alias, as well as the expression it is compared to.
+// It returns a nil path if the condition cannot be used to lookup the joined table.
+func joinConditionOperands(alias string, cond expr.Expr) (document.Path, expr.Expr) {
+	op, ok := cond.(expr.Operator)
+	if !ok || op.Token() != scanner.EQ {
+		return nil, nil
+	}
+
+	lh, rh := op.LeftHand(), op.RightHand()
+
+	if p := aliasedPath(alias, lh); p != nil && !exprReferencesAlias(alias, rh) {
+		return p, rh
+	}
+
+	if p := aliasedPath(alias, rh); p != nil && !exprReferencesAlias(alias, lh) {
+		return p, lh
+	}
+
+	return nil, nil
+}
+
+// aliasedPath returns the path relative to the alias if e is a path starting with the alias.
+func aliasedPath(alias string, e expr.Expr) document.Path {
+	p, ok := e.(expr.Path)
+	if !ok || len(p) < 2 || p[0].FieldName != alias {
+		return nil
+	}
+
+	return document.Path(p[1:])
+}
+
+func exprReferencesAlias(alias string, e expr.Expr) bool {
+	var found bool
+
+	expr.Walk(e, func(e expr.Expr) bool {
+		switch t := e.(type) {
+		case expr.Path:
+			if t[0].FieldName == alias {
+				found = true
+			}
+		case expr.Wildcard:
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
@@ -19,6 +20,7 @@ var optimizerRules = []func(sctx *StreamContext) error{
 	RemoveUnnecessaryFilterNodesRule,
 	RemoveUnnecessaryTempSortNodesRule,
 	SelectIndex,
+	UseIndexOnlyScanRule,
 }
 
 // Optimize takes a tree, applies a list of optimization rules
@@ -26,33 +28,30 @@ var optimizerRules = []func(sctx *StreamContext) error{
 // Depending on the rule, the tree may be modified in place or
 // replaced by a new one.
 func Optimize(s *stream.Stream, catalog *database.Catalog) (*stream.Stream, error) {
-	if firstNode, ok := s.First().(*stream.ConcatOperator); ok {
-		// If the first operation is a concat, optimize all streams individually.
-		for i, st := range firstNode.Streams {
-			ss, err := Optimize(st, catalog)
-			if err != nil {
-				return nil, err
-			}
-			firstNode.Streams[i] = ss
-		}
-
-		return s, nil
+	// If the first operation combines multiple streams, optimize all streams individually.
+	var streams []*stream.Stream
+	switch firstNode := s.First().(type) {
+	case *stream.ConcatOperator:
+		streams = firstNode.Streams
+	case *stream.UnionOperator:
+		streams = firstNode.Streams
+	case *stream.IntersectOperator:
+		streams = firstNode.Streams
+	case *stream.ExceptOperator:
+		streams = firstNode.Streams
+	default:
+		return optimize(s, catalog)
 	}
 
-	if firstNode, ok := s.First().(*stream.UnionOperator); ok {
-		// If the first operation is a union, optimize all streams individually.
-		for i, st := range firstNode.Streams {
-			ss, err := Optimize(st, catalog)
-			if err != nil {
-				return nil, err
-			}
-			firstNode.Streams[i] = ss
+	for i, st := range streams {
+		ss, err := Optimize(st, catalog)
+		if err != nil {
+			return nil, err
 		}
-
-		return s, nil
+		streams[i] = ss
 	}
 
-	return optimize(s, catalog)
+	return s, nil
 }
 
 type StreamContext struct {
@@ -71,6 +70,7 @@ func NewStreamContext(s *stream.Stream) *StreamContext {
 	n := s.First()
 
 	prevIsFilter := false
+	windowed := false
 
 	for n != nil {
 		switch t := n.(type) {
@@ -83,7 +83,14 @@ func NewStreamContext(s *stream.Stream) *StreamContext {
 			sctx.Projections = append(sctx.Projections, t)
 			prevIsFilter = false
 		case *docs.TempTreeSortOperator:
-			sctx.TempTreeSorts = append(sctx.TempTreeSorts, t)
+			// window functions depend on the order of the documents,
+			// sorting nodes feeding a window or following one must be kept as is
+			if _, ok := t.GetNext().(*docs.WindowOperator); !ok && !windowed {
+				sctx.TempTreeSorts = append(sctx.TempTreeSorts, t)
+			}
+			prevIsFilter = false
+		case *docs.WindowOperator:
+			windowed = true
 			prevIsFilter = false
 		}
 
@@ -310,6 +317,76 @@ func precalculateExpr(e expr.Expr) (expr.Expr, error) {
 
 			return expr.LiteralValue{Value: types.NewDocumentValue(&fb)}, nil
 		}
+	case *expr.Case:
+		return precalculateCase(t)
+	case *functions.Coalesce:
+		// NULL arguments can be removed, and the first constant
+		// non-NULL argument ends the list
+		var exprs []expr.Expr
+		for _, te := range t.Exprs {
+			newExpr, err := precalculateExpr(te)
+			if err != nil {
+				return nil, err
+			}
+
+			lv, ok := newExpr.(expr.LiteralValue)
+			if ok && lv.Value.Type() == types.NullValue {
+				continue
+			}
+
+			exprs = append(exprs, newExpr)
+			if ok {
+				break
+			}
+		}
+
+		switch len(exprs) {
+		case 0:
+			return expr.LiteralValue{Value: types.NewNullValue()}, nil
+		case 1:
+			return exprs[0], nil
+		}
+
+		t.Exprs = exprs
+	case *functions.IfNull:
+		var err error
+		t.Expr, err = precalculateExpr(t.Expr)
+		if err != nil {
+			return nil, err
+		}
+		t.Default, err = precalculateExpr(t.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		if lv, ok := t.Expr.(expr.LiteralValue); ok {
+			if lv.Value.Type() == types.NullValue {
+				return t.Default, nil
+			}
+
+			return lv, nil
+		}
+	case *functions.NullIf:
+		var err error
+		t.Expr, err = precalculateExpr(t.Expr)
+		if err != nil {
+			return nil, err
+		}
+		t.Other, err = precalculateExpr(t.Other)
+		if err != nil {
+			return nil, err
+		}
+
+		_, leftIsLit := t.Expr.(expr.LiteralValue)
+		_, rightIsLit := t.Other.(expr.LiteralValue)
+		if leftIsLit && rightIsLit {
+			v, err := t.Eval(&environment.Environment{})
+			if err != nil {
+				return nil, err
+			}
+
+			return expr.LiteralValue{Value: v}, nil
+		}
 	case expr.Operator:
 		// since expr.Operator is an interface,
 		// this optimization must only be applied to
@@ -361,6 +438,83 @@ func precalculateExpr(e expr.Expr) (expr.Expr, error) {
 	return e, nil
 }
 
+// precalculateCase simplifies the clauses of a CASE expression.
+// WHEN clauses whose condition is constant and doesn't match are removed,
+// and the first one whose condition always matches becomes the ELSE clause.
+// If the first remaining clause always matches, or if only the ELSE clause remains,
+// the whole expression is replaced by its result.
+// Examples:
+//   CASE WHEN 1 > 2 THEN a WHEN b THEN c ELSE d END --> CASE WHEN b THEN c ELSE d END
+//   CASE 1 WHEN 2 THEN a WHEN 1 THEN b END --> b
+func precalculateCase(c *expr.Case) (expr.Expr, error) {
+	var err error
+
+	if c.Operand != nil {
+		c.Operand, err = precalculateExpr(c.Operand)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, w := range c.Whens {
+		w.Cond, err = precalculateExpr(w.Cond)
+		if err != nil {
+			return nil, err
+		}
+		w.Then, err = precalculateExpr(w.Then)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.Else != nil {
+		c.Else, err = precalculateExpr(c.Else)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// clauses can only be evaluated if the operand is constant
+	var operand types.Value
+	if c.Operand != nil {
+		lv, ok := c.Operand.(expr.LiteralValue)
+		if !ok {
+			return c, nil
+		}
+		operand = lv.Value
+	}
+
+	var whens []*expr.When
+	for _, w := range c.Whens {
+		lv, ok := w.Cond.(expr.LiteralValue)
+		if !ok {
+			whens = append(whens, w)
+			continue
+		}
+
+		ok, err := c.Matches(operand, lv.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		// the following clauses will never be evaluated
+		c.Else = w.Then
+		break
+	}
+
+	if len(whens) == 0 {
+		if c.Else == nil {
+			return expr.LiteralValue{Value: types.NewNullValue()}, nil
+		}
+
+		return c.Else, nil
+	}
+
+	c.Whens = whens
+	return c, nil
+}
+
 // RemoveUnnecessaryFilterNodesRule removes any filter node whose
 // condition is a constant expression that evaluates to a truthy value.
 // if it evaluates to a falsy value, it considers that the tree
@@ -164,6 +164,51 @@ func TestPrecalculateExprRule(t *testing.T) {
 				Add("b", types.NewDoubleValue(-39)),
 			)},
 		},
+		{
+			"CASE with constant branches: CASE WHEN 1 > 2 THEN a WHEN b THEN c WHEN 2 > 1 THEN d WHEN e THEN f END -> CASE WHEN b THEN c ELSE d END",
+			parser.MustParseExpr("CASE WHEN 1 > 2 THEN a WHEN b THEN c WHEN 2 > 1 THEN d WHEN e THEN f END"),
+			parser.MustParseExpr("CASE WHEN b THEN c ELSE d END"),
+		},
+		{
+			"CASE with a matching first branch: CASE WHEN 1 THEN a + 1 * 2 ELSE b END -> a + 2",
+			parser.MustParseExpr("CASE WHEN 1 THEN a + 1 * 2 ELSE b END"),
+			parser.MustParseExpr("a + 2"),
+		},
+		{
+			"simple CASE with constant operand: CASE 1 + 1 WHEN 1 THEN a WHEN 2 THEN b END -> b",
+			parser.MustParseExpr("CASE 1 + 1 WHEN 1 THEN a WHEN 2 THEN b END"),
+			parser.MustParseExpr("b"),
+		},
+		{
+			"simple CASE without match: CASE 3 WHEN 1 THEN a END -> NULL",
+			parser.MustParseExpr("CASE 3 WHEN 1 THEN a END"),
+			parser.MustParseExpr("NULL"),
+		},
+		{
+			"simple CASE with non-constant operand: CASE a WHEN 1 - 1 THEN b END -> CASE a WHEN 0 THEN b END",
+			parser.MustParseExpr("CASE a WHEN 1 - 1 THEN b END"),
+			parser.MustParseExpr("CASE a WHEN 0 THEN b END"),
+		},
+		{
+			"COALESCE: COALESCE(NULL, a, 1 + 1, b) -> COALESCE(a, 2)",
+			parser.MustParseExpr("COALESCE(NULL, a, 1 + 1, b)"),
+			parser.MustParseExpr("COALESCE(a, 2)"),
+		},
+		{
+			"constant COALESCE: COALESCE(NULL, 1, a) -> 1",
+			parser.MustParseExpr("COALESCE(NULL, 1, a)"),
+			parser.MustParseExpr("1"),
+		},
+		{
+			"constant IFNULL: IFNULL(NULL, a) -> a",
+			parser.MustParseExpr("IFNULL(NULL, a)"),
+			parser.MustParseExpr("a"),
+		},
+		{
+			"constant NULLIF: NULLIF(1, 1) -> NULL",
+			parser.MustParseExpr("NULLIF(1, 1)"),
+			parser.MustParseExpr("NULL"),
+		},
 	}
 
 	for _, test := range tests {
@@ -2,8 +2,14 @@ package statement
 
 import (
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/index"
+	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/types"
 )
 
 // AlterStmt is a DSL that allows creating a full ALTER TABLE query.
@@ -38,6 +44,38 @@ func (stmt AlterStmt) Run(ctx *Context) (Result, error) {
 	return res, err
 }
 
+// AlterViewStmt is a DSL that allows creating an ALTER VIEW ... RENAME TO query.
+type AlterViewStmt struct {
+	ViewName    string
+	NewViewName string
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterViewStmt) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER VIEW statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterViewStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.ViewName == "" {
+		return res, errors.New("missing view name")
+	}
+
+	if stmt.NewViewName == "" {
+		return res, errors.New("missing new view name")
+	}
+
+	if stmt.ViewName == stmt.NewViewName {
+		return res, errs.AlreadyExistsError{Name: stmt.NewViewName}
+	}
+
+	err := ctx.Catalog.RenameView(ctx.Tx, stmt.ViewName, stmt.NewViewName)
+	return res, err
+}
+
 type AlterTableAddField struct {
 	Info database.TableInfo
 }
@@ -58,5 +96,188 @@ func (stmt AlterTableAddField) Run(ctx *Context) (Result, error) {
 	}
 
 	err := ctx.Catalog.AddFieldConstraint(ctx.Tx, stmt.Info.TableName, fc, stmt.Info.TableConstraints)
+	if err != nil {
+		return res, err
+	}
+
+	info, err := ctx.Catalog.GetTableInfo(stmt.Info.TableName)
+	if err != nil {
+		return res, err
+	}
+
+	return res, buildConstraintIndexes(ctx, info, stmt.Info.TableConstraints)
+}
+
+// AlterTableAddConstraint is a DSL that allows creating an ALTER TABLE ... ADD CONSTRAINT query.
+type AlterTableAddConstraint struct {
+	TableName  string
+	Constraint *database.TableConstraint
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableAddConstraint) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE ADD CONSTRAINT statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterTableAddConstraint) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	info, err := ctx.Catalog.GetTableInfo(stmt.TableName)
+	if err != nil {
+		return res, err
+	}
+
+	// the paths of the foreign key are already indexed
+	tc := stmt.Constraint
+	indexed := tc.ForeignKey != nil && info.HasForeignKey(tc.Paths)
+
+	err = ctx.Catalog.AddFieldConstraint(ctx.Tx, stmt.TableName, nil, database.TableConstraints{tc})
+	if err != nil || indexed {
+		return res, err
+	}
+
+	info, err = ctx.Catalog.GetTableInfo(stmt.TableName)
+	if err != nil {
+		return res, err
+	}
+
+	return res, buildConstraintIndexes(ctx, info, database.TableConstraints{tc})
+}
+
+// buildConstraintIndexes creates the indexes of the given constraints
+// and indexes all the documents of the table.
+// It returns an error if a unique index contains duplicate values.
+func buildConstraintIndexes(ctx *Context, info *database.TableInfo, tcs database.TableConstraints) error {
+	indexes, err := createConstraintIndexes(ctx, info, tcs)
+	if err != nil {
+		return err
+	}
+
+	for _, idx := range indexes {
+		s := stream.New(table.Scan(info.TableName))
+		if idx.Unique {
+			s = s.Pipe(index.Validate(idx.IndexName))
+		}
+		s = s.Pipe(index.IndexInsert(idx.IndexName)).
+			Pipe(stream.Discard())
+
+		it := StreamStmtIterator{
+			Stream:  s,
+			Context: ctx,
+		}
+		err = it.Iterate(func(d types.Document) error { return nil })
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AlterTableDropConstraint is a DSL that allows creating an ALTER TABLE ... DROP CONSTRAINT query.
+type AlterTableDropConstraint struct {
+	TableName      string
+	ConstraintName string
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableDropConstraint) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE DROP CONSTRAINT statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterTableDropConstraint) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	err := ctx.Catalog.DropTableConstraint(ctx.Tx, stmt.TableName, stmt.ConstraintName)
+	return res, err
+}
+
+// AlterTableAlterFieldType is a DSL that allows creating an ALTER TABLE ... ALTER FIELD ... TYPE query.
+type AlterTableAlterFieldType struct {
+	TableName string
+	Path      document.Path
+	Type      types.ValueType
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableAlterFieldType) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE ALTER FIELD statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterTableAlterFieldType) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	err := ctx.Catalog.AlterFieldType(ctx.Tx, stmt.TableName, stmt.Path, stmt.Type)
+	return res, err
+}
+
+// AlterTableDropField is a DSL that allows creating an ALTER TABLE ... DROP FIELD query.
+type AlterTableDropField struct {
+	TableName string
+	Path      document.Path
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableDropField) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE DROP FIELD statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterTableDropField) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	err := ctx.Catalog.DropField(ctx.Tx, stmt.TableName, stmt.Path)
 	return res, err
 }
+
+// AlterTableRenameField is a DSL that allows creating an ALTER TABLE ... RENAME FIELD query.
+type AlterTableRenameField struct {
+	TableName string
+	Path      document.Path
+	NewName   string
+	// ParseExpr parses the CHECK constraints of the table
+	// to rename the field in a copy of their expressions.
+	ParseExpr func(s string) (expr.Expr, error)
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableRenameField) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE RENAME FIELD statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterTableRenameField) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.NewName == "" {
+		return res, errors.New("missing new field name")
+	}
+
+	err := ctx.Catalog.RenameField(ctx.Tx, stmt.TableName, stmt.Path, stmt.NewName, stmt.renameCheck)
+	return res, err
+}
+
+// renameCheck returns a copy of the CHECK constraint expression using the renamed field.
+func (stmt AlterTableRenameField) renameCheck(check database.TableExpression) (database.TableExpression, error) {
+	e, err := stmt.ParseExpr(check.String())
+	if err != nil {
+		return nil, err
+	}
+
+	expr.Walk(e, func(e expr.Expr) bool {
+		if p, ok := e.(expr.Path); ok && document.Path(p).HasPrefix(stmt.Path) {
+			p[len(stmt.Path)-1].FieldName = stmt.NewName
+		}
+		return true
+	})
+
+	return expr.Constraint(e), nil
+}
@@ -51,25 +51,60 @@ func (stmt *CreateTableStmt) Run(ctx *Context) (Result, error) {
 			return res, nil
 		}
 	}
+	if err != nil {
+		return res, err
+	}
+
+	_, err = createConstraintIndexes(ctx, &stmt.Info, stmt.Info.TableConstraints)
+	return res, err
+}
+
+// createConstraintIndexes creates an index for the given UNIQUE and FOREIGN KEY constraints
+// of the table and returns them.
+func createConstraintIndexes(ctx *Context, info *database.TableInfo, tcs database.TableConstraints) ([]*database.IndexInfo, error) {
+	var indexes []*database.IndexInfo
 
 	// create a unique index for every unique constraint
-	for _, tc := range stmt.Info.TableConstraints {
+	for _, tc := range tcs {
 		if tc.Unique {
-			err = ctx.Catalog.CreateIndex(ctx.Tx, &database.IndexInfo{
+			idx := database.IndexInfo{
 				Paths:  tc.Paths,
 				Unique: true,
 				Owner: database.Owner{
-					TableName: stmt.Info.TableName,
+					TableName: info.TableName,
 					Paths:     tc.Paths,
 				},
-			})
+			}
+			err := ctx.Catalog.CreateIndex(ctx.Tx, &idx)
 			if err != nil {
-				return res, err
+				return nil, err
 			}
+			indexes = append(indexes, &idx)
 		}
 	}
 
-	return res, err
+	// create an index for every foreign key, unless its paths are already indexed,
+	// to quickly find the documents referencing a deleted or updated document
+	for _, tc := range tcs {
+		if tc.ForeignKey == nil || info.HasUniqueConstraint(tc.Paths) {
+			continue
+		}
+
+		idx := database.IndexInfo{
+			Paths: tc.Paths,
+			Owner: database.Owner{
+				TableName: info.TableName,
+				Paths:     tc.Paths,
+			},
+		}
+		err := ctx.Catalog.CreateIndex(ctx.Tx, &idx)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, &idx)
+	}
+
+	return indexes, nil
 }
 
 // CreateIndexStmt represents a parsed CREATE INDEX statement.
@@ -0,0 +1,177 @@
+package statement
+
+import (
+	"fmt"
+
+	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/table"
+)
+
+// A CommonTableExpr is a named SELECT statement declared in the WITH clause
+// of a query. It can be referenced like a table by the statements that follow it.
+type CommonTableExpr struct {
+	Name      string
+	Stmt      *SelectStmt
+	Recursive bool
+
+	// stream of a non-recursive expression, set when the expression is prepared.
+	stream *stream.Stream
+
+	// state of a recursive expression, set when the expression is prepared.
+	anchor    *stream.Stream
+	recursive *stream.Stream
+	wt        *stream.WorkingTable
+	all       bool
+	// set while preparing the recursive term, so that references
+	// to the expression read the working table.
+	recursing bool
+}
+
+// Prepare the statement of the common table expression.
+// It must be called with the context the expression will be used in,
+// after every common table expression declared before it has been prepared.
+func (c *CommonTableExpr) Prepare(ctx *Context) (readOnly bool, err error) {
+	if !c.Recursive || !c.isRecursive() {
+		stmt, err := c.Stmt.Prepare(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		st := stmt.(*PreparedStreamStmt)
+		c.stream = st.Stream
+		return st.ReadOnly, nil
+	}
+
+	// a recursive expression is made of an anchor, which is evaluated once,
+	// and a recursive term, which is evaluated until it stops returning documents
+	if len(c.Stmt.CompoundSelect) != 2 || c.Stmt.CompoundOperators[0].Tok != scanner.UNION {
+		return false, fmt.Errorf("recursive query %q must be of the form: anchor UNION [ALL] recursive_term", c.Name)
+	}
+	if c.Stmt.OrderBy != nil || c.Stmt.LimitExpr != nil || c.Stmt.OffsetExpr != nil {
+		return false, fmt.Errorf("ORDER BY, LIMIT and OFFSET are not supported in recursive query %q", c.Name)
+	}
+	if c.references(c.Stmt.CompoundSelect[0]) {
+		return false, fmt.Errorf("recursive reference to query %q must not appear within its anchor", c.Name)
+	}
+
+	anchor := NewSelectStatement()
+	anchor.CompoundSelect = c.Stmt.CompoundSelect[:1]
+	stmt, err := anchor.Prepare(ctx)
+	if err != nil {
+		return false, err
+	}
+	st := stmt.(*PreparedStreamStmt)
+	c.anchor = st.Stream
+	readOnly = st.ReadOnly
+
+	c.wt = new(stream.WorkingTable)
+	c.all = c.Stmt.CompoundOperators[0].All
+
+	// the recursive term can reference the expression itself
+	ctx.CTEs = append(ctx.CTEs, c)
+	defer func() {
+		ctx.CTEs = ctx.CTEs[:len(ctx.CTEs)-1]
+	}()
+
+	c.recursing = true
+	defer func() {
+		c.recursing = false
+	}()
+
+	rec := NewSelectStatement()
+	rec.CompoundSelect = c.Stmt.CompoundSelect[1:]
+	stmt, err = rec.Prepare(ctx)
+	if err != nil {
+		return false, err
+	}
+	st = stmt.(*PreparedStreamStmt)
+	c.recursive = st.Stream
+
+	return readOnly && st.ReadOnly, nil
+}
+
+// isRecursive reports whether the statement of the expression references the expression itself.
+func (c *CommonTableExpr) isRecursive() bool {
+	for _, core := range c.Stmt.CompoundSelect {
+		if c.references(core) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *CommonTableExpr) references(core *SelectCoreStmt) bool {
+	if core.TableName == c.Name {
+		return true
+	}
+
+	for _, j := range core.Joins {
+		if j.TableName == c.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newStream returns a stream iterating over the documents of the expression.
+func (c *CommonTableExpr) newStream() *stream.Stream {
+	switch {
+	case c.recursing:
+		return stream.New(stream.WorkingTableScan(c.Name, c.wt))
+	case c.stream != nil:
+		return stream.New(stream.CTE(c.Name, c.stream))
+	case c.all:
+		return stream.New(stream.RecursiveConcat(c.Name, c.wt, c.anchor, c.recursive))
+	default:
+		return stream.New(stream.RecursiveUnion(c.Name, c.wt, c.anchor, c.recursive))
+	}
+}
+
+// prepareCTEs prepares the given common table expressions in order and
+// makes them available to the rest of the statement through the context.
+// Each expression can only reference the expressions declared before it.
+func prepareCTEs(ctx *Context, ctes []*CommonTableExpr) (readOnly bool, err error) {
+	readOnly = true
+
+	names := make(map[string]struct{}, len(ctes))
+	for _, c := range ctes {
+		if _, ok := names[c.Name]; ok {
+			return false, fmt.Errorf("WITH query name %q specified more than once", c.Name)
+		}
+		names[c.Name] = struct{}{}
+
+		ro, err := c.Prepare(ctx)
+		if err != nil {
+			return false, err
+		}
+		if !ro {
+			readOnly = false
+		}
+
+		ctx.CTEs = append(ctx.CTEs, c)
+	}
+
+	return readOnly, nil
+}
+
+// tableStream returns a stream iterating over the documents of the table
+// or the view with the given name.
+func tableStream(ctx *Context, name string) (*stream.Stream, error) {
+	for i := len(ctx.CTEs) - 1; i >= 0; i-- {
+		if ctx.CTEs[i].Name == name {
+			return ctx.CTEs[i].newStream(), nil
+		}
+	}
+
+	if ctx.Catalog != nil {
+		info, err := ctx.Catalog.GetViewInfo(name)
+		if err == nil {
+			return viewStream(ctx, info)
+		}
+	}
+
+	return stream.New(table.Scan(name)), nil
+}
@@ -19,6 +19,7 @@ type DeleteStmt struct {
 	OrderBy          expr.Path
 	LimitExpr        expr.Expr
 	OrderByDirection scanner.Token
+	Returning        []expr.Expr
 }
 
 func NewDeleteStatement() *DeleteStmt {
@@ -33,6 +34,17 @@ func NewDeleteStatement() *DeleteStmt {
 }
 
 func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
+	err := checkNotView(c, stmt.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := append([]expr.Expr{stmt.WhereExpr}, stmt.Returning...)
+	_, err = prepareSubqueries(c, exprs...)
+	if err != nil {
+		return nil, err
+	}
+
 	s := stream.New(table.Scan(stmt.TableName))
 
 	if stmt.WhereExpr != nil {
@@ -60,9 +72,13 @@ func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
 		s = s.Pipe(index.Delete(indexName))
 	}
 
-	s = s.Pipe(table.Delete(stmt.TableName))
-
-	s = s.Pipe(stream.Discard())
+	if len(stmt.Returning) > 0 {
+		s = s.Pipe(table.DeleteReturning(stmt.TableName))
+		s = s.Pipe(docs.Project(stmt.Returning...))
+	} else {
+		s = s.Pipe(table.Delete(stmt.TableName))
+		s = s.Pipe(stream.Discard())
+	}
 
 	st := StreamStmt{
 		Stream:   s,
@@ -2,6 +2,7 @@ package statement
 
 import (
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/stream"
@@ -19,8 +20,20 @@ type InsertStmt struct {
 	Values     []expr.Expr
 	Fields     []string
 	SelectStmt Preparer
-	Returning  []expr.Expr
 	OnConflict database.OnConflictAction
+
+	// Returning is evaluated against the inserted documents only:
+	// documents updated or replaced by the ON CONFLICT clause are not returned.
+	Returning []expr.Expr
+
+	// OnConflictPaths restricts the ON CONFLICT clause to conflicts
+	// on the primary key or the unique constraint of these paths.
+	OnConflictPaths document.Paths
+
+	// OnConflictSetPairs and OnConflictWhere are used along with the DO UPDATE action.
+	// The document that could not be inserted is accessible using the EXCLUDED variable.
+	OnConflictSetPairs []UpdateSetPair
+	OnConflictWhere    expr.Expr
 }
 
 func NewInsertStatement() *InsertStmt {
@@ -35,6 +48,11 @@ func NewInsertStatement() *InsertStmt {
 }
 
 func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
+	err := checkNotView(c, stmt.TableName)
+	if err != nil {
+		return nil, err
+	}
+
 	var s *stream.Stream
 
 	if stmt.Values != nil {
@@ -62,6 +80,12 @@ func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
 				}
 			}
 		}
+
+		_, err := prepareSubqueries(c, stmt.Values...)
+		if err != nil {
+			return nil, err
+		}
+
 		s = stream.New(docs.Emit(stmt.Values...))
 	} else {
 		selectStream, err := stmt.SelectStmt.Prepare(c)
@@ -85,36 +109,63 @@ func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
 	// validate document
 	s = s.Pipe(table.Validate(stmt.TableName))
 
+	indexNames := c.Catalog.ListIndexes(stmt.TableName)
+
+	if len(stmt.OnConflictPaths) > 0 {
+		err := stmt.checkOnConflictPaths(c, indexNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if stmt.OnConflict != 0 {
 		switch stmt.OnConflict {
 		case database.OnConflictDoNothing:
-			s = s.Pipe(stream.OnConflict(nil))
+			s = s.Pipe(stream.OnConflict(nil, stmt.OnConflictPaths...))
 		case database.OnConflictDoReplace:
-			s = s.Pipe(stream.OnConflict(stream.New(table.Replace(stmt.TableName))))
+			s = s.Pipe(stream.OnConflict(stream.New(table.Replace(stmt.TableName)), stmt.OnConflictPaths...))
+		case database.OnConflictDoUpdate:
+			onConflict, err := stmt.prepareOnConflictUpdate(c, indexNames)
+			if err != nil {
+				return nil, err
+			}
+			s = s.Pipe(stream.OnConflict(onConflict, stmt.OnConflictPaths...))
 		default:
 			panic("unreachable")
 		}
 	}
 
 	// check unique constraints
-	indexNames := c.Catalog.ListIndexes(stmt.TableName)
-	for _, indexName := range indexNames {
-		info, err := c.Catalog.GetIndexInfo(indexName)
+	uniqueIndexes, err := stmt.orderUniqueIndexes(c, indexNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// a conflict on the primary key targeted by the ON CONFLICT clause
+	// must be detected before the conflicts on other unique indexes
+	if len(stmt.OnConflictPaths) > 0 {
+		ti, err := c.Catalog.GetTableInfo(stmt.TableName)
 		if err != nil {
 			return nil, err
 		}
 
-		if info.Unique {
-			s = s.Pipe(index.Validate(indexName))
+		if pk := ti.GetPrimaryKey(); pk != nil && stmt.OnConflictPaths.IsEqual(pk.Paths) {
+			s = s.Pipe(table.ValidatePrimaryKey(stmt.TableName))
 		}
 	}
 
+	for _, indexName := range uniqueIndexes {
+		s = s.Pipe(index.Validate(indexName))
+	}
+
 	s = s.Pipe(table.Insert(stmt.TableName))
 
 	for _, indexName := range indexNames {
 		s = s.Pipe(index.IndexInsert(indexName))
 	}
 
+	// the ON CONFLICT clause runs its own stream, which doesn't reach this point:
+	// only inserted documents are returned
 	if len(stmt.Returning) > 0 {
 		s = s.Pipe(docs.Project(stmt.Returning...))
 	} else {
@@ -128,3 +179,104 @@ func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
 
 	return st.Prepare(c)
 }
+
+// orderUniqueIndexes returns the unique indexes among indexNames.
+// The index targeted by the ON CONFLICT clause, if any, is returned first
+// so that its conflicts are detected before those on other unique indexes.
+func (stmt *InsertStmt) orderUniqueIndexes(c *Context, indexNames []string) ([]string, error) {
+	var uniqueIndexes []string
+
+	for _, indexName := range indexNames {
+		info, err := c.Catalog.GetIndexInfo(indexName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.Unique {
+			continue
+		}
+
+		if len(stmt.OnConflictPaths) > 0 && stmt.OnConflictPaths.IsEqual(info.Paths) {
+			uniqueIndexes = append([]string{indexName}, uniqueIndexes...)
+		} else {
+			uniqueIndexes = append(uniqueIndexes, indexName)
+		}
+	}
+
+	return uniqueIndexes, nil
+}
+
+// checkOnConflictPaths ensures the paths of the ON CONFLICT clause are the primary key
+// of the table or have a unique constraint.
+func (stmt *InsertStmt) checkOnConflictPaths(c *Context, indexNames []string) error {
+	ti, err := c.Catalog.GetTableInfo(stmt.TableName)
+	if err != nil {
+		return err
+	}
+
+	if ti.HasUniqueConstraint(stmt.OnConflictPaths) {
+		return nil
+	}
+
+	for _, indexName := range indexNames {
+		info, err := c.Catalog.GetIndexInfo(indexName)
+		if err != nil {
+			return err
+		}
+
+		if info.Unique && stmt.OnConflictPaths.IsEqual(info.Paths) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("no primary key or unique constraint on %q matches the ON CONFLICT clause", stmt.OnConflictPaths)
+}
+
+// prepareOnConflictUpdate returns the stream updating the conflicting document.
+func (stmt *InsertStmt) prepareOnConflictUpdate(c *Context, indexNames []string) (*stream.Stream, error) {
+	exprs := []expr.Expr{stmt.OnConflictWhere}
+	for _, pair := range stmt.OnConflictSetPairs {
+		exprs = append(exprs, pair.E)
+	}
+	_, err := prepareSubqueries(c, exprs...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := stream.New(table.Lookup(stmt.TableName))
+
+	if stmt.OnConflictWhere != nil {
+		s = s.Pipe(docs.Filter(stmt.OnConflictWhere))
+	}
+
+	for _, pair := range stmt.OnConflictSetPairs {
+		s = s.Pipe(path.Set(pair.Path, pair.E))
+	}
+
+	// validate document
+	s = s.Pipe(table.Validate(stmt.TableName))
+
+	for _, indexName := range indexNames {
+		s = s.Pipe(index.Delete(indexName))
+	}
+
+	// ensure the updated document doesn't conflict with another one
+	for _, indexName := range indexNames {
+		info, err := c.Catalog.GetIndexInfo(indexName)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Unique {
+			s = s.Pipe(index.Validate(indexName))
+		}
+	}
+
+	s = s.Pipe(table.Replace(stmt.TableName))
+
+	for _, indexName := range indexNames {
+		s = s.Pipe(index.IndexInsert(indexName))
+	}
+
+	return s, nil
+}
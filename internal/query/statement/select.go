@@ -6,35 +6,58 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
-	"github.com/genjidb/genji/internal/stream/table"
 )
 
 type SelectCoreStmt struct {
 	TableName       string
+	TableAlias      string
+	Joins           []*JoinClause
 	Distinct        bool
 	WhereExpr       expr.Expr
-	GroupByExpr     expr.Expr
+	GroupByExprs    []expr.Expr
+	HavingExpr      expr.Expr
 	ProjectionExprs []expr.Expr
 }
 
-func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
-	isReadOnly := true
+func (stmt *SelectCoreStmt) Prepare(ctx *Context) (*StreamStmt, error) {
+	exprs := append([]expr.Expr{stmt.WhereExpr, stmt.HavingExpr}, stmt.GroupByExprs...)
+	exprs = append(exprs, stmt.ProjectionExprs...)
+	for _, j := range stmt.Joins {
+		exprs = append(exprs, j.On, j.Unnest)
+	}
+	isReadOnly, err := prepareSubqueries(ctx, exprs...)
+	if err != nil {
+		return nil, err
+	}
 
 	var s *stream.Stream
 
 	if stmt.TableName != "" {
-		s = s.Pipe(table.Scan(stmt.TableName))
+		s, err = tableStream(ctx, stmt.TableName)
+		if err != nil {
+			return nil, err
+		}
+
+		// when using aliases or joins, the documents of each table
+		// are stored under a field named after their alias
+		if stmt.TableAlias != "" || len(stmt.Joins) > 0 {
+			s, err = stmt.prepareJoins(ctx, s)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if stmt.WhereExpr != nil {
 		s = s.Pipe(docs.Filter(stmt.WhereExpr))
 	}
 
-	// when using GROUP BY, only aggregation functions or GroupByExpr can be selected
-	if stmt.GroupByExpr != nil {
+	// when using GROUP BY, only aggregation functions or GroupByExprs can be selected
+	if len(stmt.GroupByExprs) > 0 {
 		var invalidProjectedField expr.Expr
 		var aggregators []expr.AggregatorBuilder
 
@@ -52,8 +75,8 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 				continue
 			}
 
-			// check if this is the same expression as the one used in the GROUP BY clause
-			if expr.Equal(e, stmt.GroupByExpr) {
+			// check if this is the same expression as one of those used in the GROUP BY clause
+			if stmt.isGroupByExpr(e) {
 				// if so, replace the expression with a path expression
 				stmt.ProjectionExprs[i] = &expr.NamedExpr{
 					ExprName: ne.ExprName,
@@ -70,9 +93,24 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 		if invalidProjectedField != nil {
 			return nil, fmt.Errorf("field %q must appear in the GROUP BY clause or be used in an aggregate function", invalidProjectedField)
 		}
+
+		if stmt.HavingExpr != nil {
+			stmt.HavingExpr, err = stmt.prepareHaving(stmt.HavingExpr, &aggregators)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// add Aggregation node
-		s = s.Pipe(docs.TempTreeSort(stmt.GroupByExpr))
-		s = s.Pipe(docs.GroupAggregate(stmt.GroupByExpr, aggregators...))
+		if len(stmt.GroupByExprs) == 1 {
+			s = s.Pipe(docs.TempTreeSort(stmt.GroupByExprs[0]))
+			s = s.Pipe(docs.GroupAggregate(stmt.GroupByExprs[0], aggregators...))
+		} else {
+			// when grouping by multiple expressions, documents are grouped by the tuple
+			groupBy := expr.LiteralExprList(stmt.GroupByExprs)
+			s = s.Pipe(docs.TempTreeSort(groupBy))
+			s = s.Pipe(docs.GroupAggregateTuple(groupBy, aggregators...))
+		}
 	} else if stmt.TableName != "" {
 		// if there is no GROUP BY clause, check if there are any aggregation function
 		// and if so add an aggregation node
@@ -91,10 +129,65 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 			}
 		}
 
+		// without GROUP BY, HAVING considers the whole table as a single group
+		// and only aggregation functions can be projected
+		if stmt.HavingExpr != nil {
+			for _, pe := range stmt.ProjectionExprs {
+				if ne, ok := pe.(*expr.NamedExpr); ok {
+					if _, ok := ne.Expr.(expr.AggregatorBuilder); ok {
+						continue
+					}
+				}
+
+				return nil, fmt.Errorf("field %q must appear in the GROUP BY clause or be used in an aggregate function", pe)
+			}
+
+			stmt.HavingExpr, err = stmt.prepareHaving(stmt.HavingExpr, &aggregators)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// add Aggregation node
 		if len(aggregators) > 0 {
 			s = s.Pipe(docs.GroupAggregate(nil, aggregators...))
 		}
+	} else if stmt.HavingExpr != nil {
+		return nil, errors.New("HAVING clause requires a FROM clause")
+	}
+
+	// HAVING filters the groups once they have been aggregated
+	if stmt.HavingExpr != nil {
+		s = s.Pipe(docs.Filter(stmt.HavingExpr))
+	}
+
+	// window functions are computed on the filtered and grouped documents,
+	// each window sorts the documents by partition, then by order
+	windows := stmt.windows()
+	if len(windows) > 0 && stmt.TableName == "" {
+		return nil, errors.New("no tables specified")
+	}
+	for _, ws := range windows {
+		w := ws[0]
+		sortBy := append([]expr.Expr{}, w.PartitionBy...)
+		if w.OrderBy != nil {
+			sortBy = append(sortBy, w.OrderBy)
+		}
+
+		if len(sortBy) > 0 {
+			var e expr.Expr = expr.LiteralExprList(sortBy)
+			if len(sortBy) == 1 {
+				e = sortBy[0]
+			}
+
+			if w.Desc {
+				s = s.Pipe(docs.TempTreeSortReverse(e))
+			} else {
+				s = s.Pipe(docs.TempTreeSort(e))
+			}
+		}
+
+		s = s.Pipe(docs.Window(ws...))
 	}
 
 	// If there is no FROM clause ensure there is no wildcard or path
@@ -142,18 +235,234 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 	}, nil
 }
 
+// windows returns the window functions used by the projected expressions,
+// grouped by window: the functions of a group partition and order documents the same way.
+func (stmt *SelectCoreStmt) windows() [][]*functions.Window {
+	var windows [][]*functions.Window
+
+	for _, pe := range stmt.ProjectionExprs {
+		expr.Walk(pe, func(e expr.Expr) bool {
+			w, ok := e.(*functions.Window)
+			if !ok {
+				return true
+			}
+
+			for i, ws := range windows {
+				if !ws[0].SameWindow(w) {
+					continue
+				}
+
+				for _, other := range ws {
+					if expr.Equal(w, other) {
+						return true
+					}
+				}
+
+				windows[i] = append(ws, w)
+				return true
+			}
+
+			windows = append(windows, []*functions.Window{w})
+			return true
+		})
+	}
+
+	return windows
+}
+
+func (stmt *SelectCoreStmt) isGroupByExpr(e expr.Expr) bool {
+	for _, g := range stmt.GroupByExprs {
+		if expr.Equal(e, g) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prepareHaving rewrites the HAVING expression so that it can be evaluated
+// against the documents returned by the aggregation node.
+// Expressions of the GROUP BY clause are replaced by a path to the field of
+// the same name, and aggregation functions that are not already
+// part of the aggregators are added to them, including those used
+// as parameters of other functions.
+// Any other path is an error.
+func (stmt *SelectCoreStmt) prepareHaving(e expr.Expr, aggregators *[]expr.AggregatorBuilder) (expr.Expr, error) {
+	if agg, ok := e.(expr.AggregatorBuilder); ok {
+		for _, a := range *aggregators {
+			if expr.Equal(a, agg) {
+				return e, nil
+			}
+		}
+
+		*aggregators = append(*aggregators, agg)
+		return e, nil
+	}
+
+	if stmt.isGroupByExpr(e) {
+		return expr.Path(document.NewPath(e.String())), nil
+	}
+
+	var err error
+	switch t := e.(type) {
+	case expr.Path, expr.Wildcard:
+		return nil, fmt.Errorf("field %q must appear in the GROUP BY clause or be used in an aggregate function", e)
+	case expr.Parentheses:
+		t.E, err = stmt.prepareHaving(t.E, aggregators)
+		return t, err
+	case expr.Operator:
+		if bt, ok := t.(*expr.BetweenOperator); ok {
+			bt.X, err = stmt.prepareHaving(bt.X, aggregators)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		lh, err := stmt.prepareHaving(t.LeftHand(), aggregators)
+		if err != nil {
+			return nil, err
+		}
+		t.SetLeftHandExpr(lh)
+
+		rh, err := stmt.prepareHaving(t.RightHand(), aggregators)
+		if err != nil {
+			return nil, err
+		}
+		t.SetRightHandExpr(rh)
+
+		return t, nil
+	case expr.LiteralExprList:
+		for i := range t {
+			t[i], err = stmt.prepareHaving(t[i], aggregators)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return t, nil
+	case expr.Cast:
+		t.Expr, err = stmt.prepareHaving(t.Expr, aggregators)
+		return t, err
+	case expr.Function:
+		params := t.Params()
+		for i := range params {
+			params[i], err = stmt.prepareHaving(params[i], aggregators)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// some functions return a copy of their parameters,
+		// in which case they can't be rewritten
+		for i, p := range t.Params() {
+			if !expr.Equal(p, params[i]) {
+				return nil, fmt.Errorf("expression %q of the GROUP BY clause cannot be used in %s", p, t)
+			}
+		}
+
+		return t, nil
+	}
+
+	// other expressions can only be used
+	// if they don't reference any field
+	expr.Walk(e, func(e expr.Expr) bool {
+		switch e.(type) {
+		case expr.Path, expr.Wildcard:
+			err = fmt.Errorf("field %q must appear in the GROUP BY clause or be used in an aggregate function", e)
+			return false
+		}
+
+		return true
+	})
+
+	return e, err
+}
+
+func (stmt *SelectCoreStmt) prepareJoins(ctx *Context, s *stream.Stream) (*stream.Stream, error) {
+	alias := stmt.TableAlias
+	if alias == "" {
+		alias = stmt.TableName
+	}
+
+	s = s.Pipe(docs.Alias(alias))
+
+	aliases := map[string]struct{}{
+		alias: {},
+	}
+
+	for _, j := range stmt.Joins {
+		alias := j.Alias
+		if alias == "" {
+			alias = j.TableName
+		}
+
+		if _, ok := aliases[alias]; ok {
+			return nil, fmt.Errorf("table name or alias %q specified more than once", alias)
+		}
+		aliases[alias] = struct{}{}
+
+		if j.Unnest != nil {
+			if j.OffsetAlias != "" {
+				if _, ok := aliases[j.OffsetAlias]; ok {
+					return nil, fmt.Errorf("table name or alias %q specified more than once", j.OffsetAlias)
+				}
+				aliases[j.OffsetAlias] = struct{}{}
+			}
+
+			s = s.Pipe(docs.Unnest(j.Unnest, alias, j.OffsetAlias))
+			continue
+		}
+
+		right, err := tableStream(ctx, j.TableName)
+		if err != nil {
+			return nil, err
+		}
+		if j.Type == scanner.LEFT {
+			s = s.Pipe(stream.NestedLoopLeftJoin(alias, right, j.On))
+		} else {
+			s = s.Pipe(stream.NestedLoopJoin(alias, right, j.On))
+		}
+	}
+
+	return s, nil
+}
+
+// A JoinClause describes a table joined with the tables of the FROM clause.
+// If Unnest is set, the clause joins every document with the elements
+// of the array returned by the expression instead of a table.
+type JoinClause struct {
+	// Type is either scanner.INNER or scanner.LEFT.
+	Type      scanner.Token
+	TableName string
+	Alias     string
+	On        expr.Expr
+
+	Unnest expr.Expr
+	// OffsetAlias is the name of the field holding the position
+	// of the unnested element in the array.
+	OffsetAlias string
+}
+
 // SelectStmt holds SELECT configuration.
 type SelectStmt struct {
 	basePreparedStatement
 
+	CTEs              []*CommonTableExpr
 	CompoundSelect    []*SelectCoreStmt
-	CompoundOperators []scanner.Token
+	CompoundOperators []CompoundOperator
 	OrderBy           expr.Path
 	OrderByDirection  scanner.Token
 	OffsetExpr        expr.Expr
 	LimitExpr         expr.Expr
 }
 
+// CompoundOperator is the operator used to combine the results
+// of two SELECT statements: UNION, INTERSECT or EXCEPT, optionally followed by ALL.
+type CompoundOperator struct {
+	Tok scanner.Token
+	All bool
+}
+
 func NewSelectStatement() *SelectStmt {
 	var p SelectStmt
 
@@ -169,11 +478,27 @@ func NewSelectStatement() *SelectStmt {
 func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 	var s *stream.Stream
 
-	var prev scanner.Token
+	var prev CompoundOperator
 
 	var coreStmts []*stream.Stream
 	var readOnly bool = true
 
+	// common table expressions are only visible to this statement
+	cteReadOnly := true
+	if len(stmt.CTEs) > 0 {
+		ctes := ctx.CTEs
+		ctx.CTEs = ctes[:len(ctes):len(ctes)]
+		defer func() {
+			ctx.CTEs = ctes
+		}()
+
+		var err error
+		cteReadOnly, err = prepareCTEs(ctx, stmt.CTEs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for i, coreSelect := range stmt.CompoundSelect {
 		coreStmt, err := coreSelect.Prepare(ctx)
 		if err != nil {
@@ -192,23 +517,31 @@ func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 			readOnly = false
 		}
 
-		var tok scanner.Token
+		var op CompoundOperator
 		if i < len(stmt.CompoundOperators) {
-			tok = stmt.CompoundOperators[i]
+			op = stmt.CompoundOperators[i]
 		}
 
-		if prev != 0 && prev != tok {
-			switch prev {
-			case scanner.UNION:
+		if prev.Tok != 0 && prev != op {
+			switch {
+			case prev.Tok == scanner.UNION && !prev.All:
 				s = stream.New(stream.Union(coreStmts...))
-			case scanner.ALL:
+			case prev.Tok == scanner.UNION:
 				s = stream.New(stream.Concat(coreStmts...))
+			case prev.Tok == scanner.INTERSECT && !prev.All:
+				s = stream.New(stream.Intersect(coreStmts...))
+			case prev.Tok == scanner.INTERSECT:
+				s = stream.New(stream.IntersectAll(coreStmts...))
+			case prev.Tok == scanner.EXCEPT && !prev.All:
+				s = stream.New(stream.Except(coreStmts...))
+			case prev.Tok == scanner.EXCEPT:
+				s = stream.New(stream.ExceptAll(coreStmts...))
 			}
 
 			coreStmts = []*stream.Stream{s}
 		}
 
-		prev = tok
+		prev = op
 	}
 
 	if stmt.OrderBy != nil {
@@ -229,7 +562,7 @@ func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 
 	st := StreamStmt{
 		Stream:   s,
-		ReadOnly: readOnly,
+		ReadOnly: readOnly && cteReadOnly,
 	}
 
 	return st.Prepare(ctx)
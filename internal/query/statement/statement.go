@@ -37,6 +37,17 @@ type Context struct {
 	Tx      *database.Transaction
 	Catalog *database.Catalog
 	Params  []environment.Param
+
+	// CTEs holds the common table expressions in scope.
+	CTEs []*CommonTableExpr
+
+	// Vars holds the variables accessible to the statement,
+	// such as the OLD and NEW documents of a trigger.
+	Vars *document.FieldBuffer
+
+	// TriggerDepth is the number of triggers being executed
+	// when the statement is run by a trigger.
+	TriggerDepth int
 }
 
 type Preparer interface {
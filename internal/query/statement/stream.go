@@ -65,6 +65,8 @@ func (s *StreamStmtIterator) Iterate(fn func(d types.Document) error) error {
 	env.Tx = s.Context.Tx
 	env.Catalog = s.Context.Catalog
 	env.SetParams(s.Context.Params)
+	env.Vars = s.Context.Vars
+	env.TriggerDepth = s.Context.TriggerDepth
 
 	err := s.Stream.Iterate(&env, func(env *environment.Environment) error {
 		// if there is no doc in this specific environment,
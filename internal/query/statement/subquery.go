@@ -0,0 +1,228 @@
+package statement
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
+)
+
+// SubqueryKind determines how the result of a subquery is evaluated.
+type SubqueryKind int
+
+const (
+	// ScalarSubquery evaluates to the value of the first field
+	// of the only document returned by the subquery, or NULL if
+	// the subquery returns nothing.
+	ScalarSubquery SubqueryKind = iota
+	// ListSubquery evaluates to an array containing the value
+	// of the first field of every document returned by the subquery.
+	// It is used by the IN and NOT IN operators.
+	ListSubquery
+	// ExistsSubquery evaluates to true if the subquery returns
+	// at least one document.
+	ExistsSubquery
+)
+
+// A Subquery is an expression that runs a SELECT statement.
+// Subqueries are evaluated in their own scope: paths qualified with the name or the alias
+// of a table of the outer query, and that can't be found in the documents of the subquery,
+// are looked up in the document of the outer query.
+// Subqueries that never read from the outer query are only evaluated once per statement.
+type Subquery struct {
+	Stmt *SelectStmt
+	Kind SubqueryKind
+
+	// Stream is set when the subquery is prepared.
+	Stream   *stream.Stream
+	readOnly bool
+}
+
+// Prepare the subquery statement and creates its stream.
+func (s *Subquery) Prepare(ctx *Context) error {
+	stmt, err := s.Stmt.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	st := stmt.(*PreparedStreamStmt)
+	s.Stream = st.Stream
+	s.readOnly = st.ReadOnly
+	return nil
+}
+
+// Eval runs the subquery.
+// It implements the expr.Expr interface.
+func (s *Subquery) Eval(env *environment.Environment) (types.Value, error) {
+	if s.Stream == nil {
+		return nil, errors.New("subquery not prepared")
+	}
+
+	if v, ok := env.GetCachedValue(s); ok {
+		return v, nil
+	}
+
+	scope := environment.NewScope(env)
+
+	var v types.Value
+	var err error
+	switch s.Kind {
+	case ScalarSubquery:
+		v, err = s.evalScalar(scope)
+	case ListSubquery:
+		v, err = s.evalList(scope)
+	case ExistsSubquery:
+		v, err = s.evalExists(scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// if the subquery doesn't depend on the outer query,
+	// its result can be reused for the rest of the statement
+	if !scope.ReadsOuter() {
+		env.SetCachedValue(s, v)
+	}
+
+	return v, nil
+}
+
+func (s *Subquery) evalScalar(env *environment.Environment) (types.Value, error) {
+	var v types.Value
+
+	err := s.Stream.Iterate(env, func(out *environment.Environment) error {
+		if v != nil {
+			return errors.New("subquery returned more than one document")
+		}
+
+		var err error
+		v, err = firstValue(out)
+		return err
+	})
+	if err != nil && !errors.Is(err, stream.ErrStreamClosed) {
+		return nil, err
+	}
+
+	if v == nil {
+		return expr.NullLiteral, nil
+	}
+
+	return v, nil
+}
+
+func (s *Subquery) evalList(env *environment.Environment) (types.Value, error) {
+	var vb document.ValueBuffer
+
+	err := s.Stream.Iterate(env, func(out *environment.Environment) error {
+		v, err := firstValue(out)
+		if err != nil {
+			return err
+		}
+
+		vb.Append(v)
+		return nil
+	})
+	if err != nil && !errors.Is(err, stream.ErrStreamClosed) {
+		return nil, err
+	}
+
+	return types.NewArrayValue(&vb), nil
+}
+
+func (s *Subquery) evalExists(env *environment.Environment) (types.Value, error) {
+	var found bool
+
+	err := s.Stream.Iterate(env, func(out *environment.Environment) error {
+		found = true
+		return errors.WithStack(stream.ErrStreamClosed)
+	})
+	if err != nil && !errors.Is(err, stream.ErrStreamClosed) {
+		return nil, err
+	}
+
+	return types.NewBoolValue(found), nil
+}
+
+// firstValue returns a copy of the value of the only field
+// of the document of the environment.
+func firstValue(env *environment.Environment) (types.Value, error) {
+	d, ok := env.GetDocument()
+	if !ok {
+		return nil, errors.New("missing document")
+	}
+
+	var v types.Value
+	err := d.Iterate(func(field string, value types.Value) error {
+		if v != nil {
+			return errors.New("subquery must return only one field")
+		}
+
+		v = value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if v == nil {
+		return expr.NullLiteral, nil
+	}
+
+	// the value might be bound to the lifetime of the document
+	return document.CloneValue(v)
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (s *Subquery) IsEqual(other expr.Expr) bool {
+	o, ok := other.(*Subquery)
+	return ok && o == s
+}
+
+func (s *Subquery) String() string {
+	var str string
+	if s.Stream != nil {
+		str = s.Stream.String()
+	}
+
+	if s.Kind == ExistsSubquery {
+		return fmt.Sprintf("EXISTS (%s)", str)
+	}
+
+	return fmt.Sprintf("(%s)", str)
+}
+
+// prepareSubqueries prepares all the subqueries found in the given expressions.
+// It returns false if any of them is not read-only.
+func prepareSubqueries(ctx *Context, exprs ...expr.Expr) (bool, error) {
+	readOnly := true
+
+	var err error
+	for _, e := range exprs {
+		expr.Walk(e, func(e expr.Expr) bool {
+			sq, ok := e.(*Subquery)
+			if !ok {
+				return true
+			}
+
+			err = sq.Prepare(ctx)
+			if err != nil {
+				return false
+			}
+
+			if !sq.readOnly {
+				readOnly = false
+			}
+			return true
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return readOnly, nil
+}
@@ -0,0 +1,187 @@
+package statement
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/types"
+)
+
+// maxTriggerDepth is the maximum number of nested executions of a trigger.
+// It prevents triggers from firing each other indefinitely.
+const maxTriggerDepth = 32
+
+// TriggerBody contains the statements executed by a trigger.
+// It implements the database.TriggerBody interface.
+type TriggerBody struct {
+	// SQL is the text of the statements, separated by semicolons.
+	SQL string
+	// Parse returns new statements parsed from SQL.
+	// Since preparing a statement modifies it, the statements
+	// are parsed again every time they must be prepared again.
+	Parse func() ([]Statement, error)
+
+	mu sync.Mutex
+	// stmts holds the statements parsed when the trigger was created
+	// or loaded, until they are prepared.
+	stmts []Statement
+	// prepared holds the statements prepared for the given
+	// version of the catalog.
+	prepared []Statement
+	version  int64
+	// running is true while the prepared statements are executed.
+	running bool
+}
+
+// NewTriggerBody returns a TriggerBody for the given statements, parsed from sql.
+func NewTriggerBody(sql string, stmts []Statement, parse func() ([]Statement, error)) *TriggerBody {
+	return &TriggerBody{
+		SQL:   sql,
+		Parse: parse,
+		stmts: stmts,
+	}
+}
+
+// Run executes the statements of the trigger. The old and new documents
+// are accessible from the statements using the OLD and NEW variables.
+func (b *TriggerBody) Run(db *database.Database, tx *database.Transaction, catalog *database.Catalog, depth int, oldDoc, newDoc types.Document) error {
+	if depth >= maxTriggerDepth {
+		return errors.New("too many levels of trigger recursion")
+	}
+
+	vars := document.NewFieldBuffer()
+	if oldDoc != nil {
+		vars.Add("OLD", types.NewDocumentValue(oldDoc))
+	}
+	if newDoc != nil {
+		vars.Add("NEW", types.NewDocumentValue(newDoc))
+	}
+
+	ctx := Context{
+		DB:           db,
+		Tx:           tx,
+		Catalog:      catalog,
+		Vars:         vars,
+		TriggerDepth: depth + 1,
+	}
+
+	stmts, release, err := b.prepare(&ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	for _, stmt := range stmts {
+		res, err := stmt.Run(&ctx)
+		if err != nil {
+			return err
+		}
+
+		err = res.Iterate(func(d types.Document) error { return nil })
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// prepare returns the prepared statements of the trigger and a function
+// to call once they are executed. The statements are prepared the first time
+// the trigger fires and reused until the catalog changes.
+// Since a stream cannot be iterated by nested executions of the same trigger,
+// a trigger fired by its own statements prepares a copy of them.
+func (b *TriggerBody) prepare(ctx *Context) ([]Statement, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.running {
+		stmts, err := b.prepareStatements(ctx, nil)
+		return stmts, func() {}, err
+	}
+
+	version := ctx.Catalog.Cache.Version()
+	if b.prepared == nil || b.version != version {
+		stmts, err := b.prepareStatements(ctx, b.stmts)
+		b.stmts = nil
+		if err != nil {
+			return nil, nil, err
+		}
+
+		b.prepared = stmts
+		b.version = version
+	}
+
+	b.running = true
+	return b.prepared, func() {
+		b.mu.Lock()
+		b.running = false
+		b.mu.Unlock()
+	}, nil
+}
+
+// prepareStatements prepares the given statements, or new statements parsed from SQL if stmts is nil.
+func (b *TriggerBody) prepareStatements(ctx *Context, stmts []Statement) ([]Statement, error) {
+	if stmts == nil {
+		var err error
+		stmts, err = b.Parse()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prepared := make([]Statement, len(stmts))
+	for i, stmt := range stmts {
+		p, ok := stmt.(Preparer)
+		if !ok {
+			prepared[i] = stmt
+			continue
+		}
+
+		st, err := p.Prepare(ctx)
+		if err != nil {
+			return nil, err
+		}
+		prepared[i] = st
+	}
+
+	return prepared, nil
+}
+
+func (b *TriggerBody) String() string {
+	return b.SQL
+}
+
+// CreateTriggerStmt represents a parsed CREATE TRIGGER statement.
+type CreateTriggerStmt struct {
+	IfNotExists bool
+	Info        database.TriggerInfo
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt *CreateTriggerStmt) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the Create trigger statement in the given transaction.
+// It implements the Statement interface.
+func (stmt *CreateTriggerStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.IfNotExists {
+		_, err := ctx.Catalog.GetTriggerInfo(stmt.Info.TriggerName)
+		if err == nil {
+			return res, nil
+		}
+	}
+
+	err := ctx.Catalog.CreateTrigger(ctx.Tx, &stmt.Info)
+	if stmt.IfNotExists && errs.IsAlreadyExistsError(err) {
+		return res, nil
+	}
+
+	return res, err
+}
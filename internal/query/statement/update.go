@@ -27,6 +27,11 @@ type UpdateStmt struct {
 	UnsetFields []string
 
 	WhereExpr expr.Expr
+
+	// Returning is evaluated against the updated documents.
+	// The documents before and after the update are accessible using
+	// the OLD and NEW variables.
+	Returning []expr.Expr
 }
 
 func NewUpdateStatement() *UpdateStmt {
@@ -47,31 +52,39 @@ type UpdateSetPair struct {
 
 // Prepare implements the Preparer interface.
 func (stmt *UpdateStmt) Prepare(c *Context) (Statement, error) {
+	err := checkNotView(c, stmt.TableName)
+	if err != nil {
+		return nil, err
+	}
+
 	ti, err := c.Catalog.GetTableInfo(stmt.TableName)
 	if err != nil {
 		return nil, err
 	}
 	pk := ti.GetPrimaryKey()
 
+	exprs := []expr.Expr{stmt.WhereExpr}
+	for _, pair := range stmt.SetPairs {
+		exprs = append(exprs, pair.E)
+	}
+	exprs = append(exprs, stmt.Returning...)
+	_, err = prepareSubqueries(c, exprs...)
+	if err != nil {
+		return nil, err
+	}
+
 	s := stream.New(table.Scan(stmt.TableName))
 
 	if stmt.WhereExpr != nil {
 		s = s.Pipe(docs.Filter(stmt.WhereExpr))
 	}
 
-	var pkModified bool
+	if len(stmt.Returning) > 0 {
+		s = s.Pipe(docs.StoreVar("OLD"))
+	}
+
 	if stmt.SetPairs != nil {
 		for _, pair := range stmt.SetPairs {
-			// if we modify the primary key,
-			// we must remove the old document and create an new one
-			if pk != nil && !pkModified {
-				for _, p := range pk.Paths {
-					if p.IsEqual(pair.Path) {
-						pkModified = true
-						break
-					}
-				}
-			}
 			s = s.Pipe(path.Set(pair.Path, pair.E))
 		}
 	} else if stmt.UnsetFields != nil {
@@ -100,18 +113,19 @@ func (stmt *UpdateStmt) Prepare(c *Context) (Statement, error) {
 		s = s.Pipe(index.Delete(indexName))
 	}
 
-	if pkModified {
-		s = s.Pipe(table.Delete(stmt.TableName))
-		s = s.Pipe(table.Insert(stmt.TableName))
-	} else {
-		s = s.Pipe(table.Replace(stmt.TableName))
-	}
+	// if the primary key is modified, the document is moved to its new key
+	s = s.Pipe(table.Replace(stmt.TableName))
 
 	for _, indexName := range indexNames {
 		s = s.Pipe(index.IndexInsert(indexName))
 	}
 
-	s = s.Pipe(stream.Discard())
+	if len(stmt.Returning) > 0 {
+		s = s.Pipe(docs.StoreVar("NEW"))
+		s = s.Pipe(docs.Project(stmt.Returning...))
+	} else {
+		s = s.Pipe(stream.Discard())
+	}
 
 	st := StreamStmt{
 		Stream:   s,
@@ -0,0 +1,191 @@
+package statement
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/database"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+)
+
+// ViewQuery is the SELECT statement of a view.
+// It implements the database.ViewQuery interface.
+type ViewQuery struct {
+	// SQL is the text of the SELECT statement.
+	SQL string
+	// Parse returns a new statement parsed from SQL.
+	// Since preparing a statement modifies it, the query of a view
+	// is parsed every time the view is used.
+	Parse func() (*SelectStmt, error)
+
+	relations []string
+}
+
+// NewViewQuery returns a ViewQuery for the given statement, parsed from sql.
+func NewViewQuery(sql string, stmt *SelectStmt, parse func() (*SelectStmt, error)) *ViewQuery {
+	return &ViewQuery{
+		SQL:       sql,
+		Parse:     parse,
+		relations: relationNames(stmt),
+	}
+}
+
+// Relations returns the names of the tables and views read by the query.
+func (q *ViewQuery) Relations() []string {
+	return q.relations
+}
+
+func (q *ViewQuery) String() string {
+	return q.SQL
+}
+
+// relationNames returns the sorted names of the tables and views read by the statement,
+// ignoring common table expressions.
+func relationNames(stmt *SelectStmt) []string {
+	set := make(map[string]struct{})
+	collectRelationNames(stmt, nil, set)
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func collectRelationNames(stmt *SelectStmt, ctes []string, set map[string]struct{}) {
+	scope := ctes[:len(ctes):len(ctes)]
+	for _, c := range stmt.CTEs {
+		inner := scope
+		if c.Recursive {
+			inner = append(inner[:len(inner):len(inner)], c.Name)
+		}
+		collectRelationNames(c.Stmt, inner, set)
+
+		scope = append(scope, c.Name)
+	}
+
+	add := func(name string) {
+		for _, c := range scope {
+			if c == name {
+				return
+			}
+		}
+
+		set[name] = struct{}{}
+	}
+
+	for _, core := range stmt.CompoundSelect {
+		exprs := append([]expr.Expr{core.WhereExpr, core.HavingExpr}, core.GroupByExprs...)
+		exprs = append(exprs, core.ProjectionExprs...)
+
+		if core.TableName != "" {
+			add(core.TableName)
+		}
+		for _, j := range core.Joins {
+			if j.TableName != "" {
+				add(j.TableName)
+			}
+			exprs = append(exprs, j.On, j.Unnest)
+		}
+
+		for _, e := range exprs {
+			expr.Walk(e, func(e expr.Expr) bool {
+				if sq, ok := e.(*Subquery); ok {
+					collectRelationNames(sq.Stmt, scope, set)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// viewStream returns a stream iterating over the documents of the view.
+func viewStream(ctx *Context, info *database.ViewInfo) (*stream.Stream, error) {
+	st, err := prepareView(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream.New(stream.View(info.ViewName, st.Stream)), nil
+}
+
+// prepareView parses and prepares the query of the view in its own scope:
+// common table expressions of the statement using the view are not visible.
+func prepareView(ctx *Context, info *database.ViewInfo) (*PreparedStreamStmt, error) {
+	q, ok := info.Query.(*ViewQuery)
+	if !ok {
+		return nil, errors.Errorf("invalid query for view %s", info.ViewName)
+	}
+
+	stmt, err := q.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	ctes := ctx.CTEs
+	ctx.CTEs = nil
+	defer func() {
+		ctx.CTEs = ctes
+	}()
+
+	st, err := stmt.Prepare(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to prepare view %s", info.ViewName)
+	}
+
+	return st.(*PreparedStreamStmt), nil
+}
+
+// checkNotView returns an error if name refers to a view,
+// which cannot be the target of INSERT, UPDATE or DELETE statements.
+func checkNotView(ctx *Context, name string) error {
+	if _, err := ctx.Catalog.GetViewInfo(name); err == nil {
+		return errors.Errorf("cannot modify view %s", name)
+	}
+
+	return nil
+}
+
+// CreateViewStmt represents a parsed CREATE VIEW statement.
+type CreateViewStmt struct {
+	IfNotExists bool
+	Info        database.ViewInfo
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt *CreateViewStmt) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the Create view statement in the given transaction.
+// It implements the Statement interface.
+func (stmt *CreateViewStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.IfNotExists {
+		_, err := ctx.Catalog.GetViewInfo(stmt.Info.ViewName)
+		if err == nil {
+			return res, nil
+		}
+	}
+
+	// ensure the query of the view is valid
+	st, err := prepareView(ctx, &stmt.Info)
+	if err != nil {
+		return res, err
+	}
+	if !st.ReadOnly {
+		return res, errors.Errorf("the query of view %s must be read-only", stmt.Info.ViewName)
+	}
+
+	err = ctx.Catalog.CreateView(ctx.Tx, &stmt.Info)
+	if stmt.IfNotExists && errs.IsAlreadyExistsError(err) {
+		return res, nil
+	}
+
+	return res, err
+}
@@ -1,16 +1,23 @@
 package parser
 
 import (
+	"strings"
+
 	"github.com/cockroachdb/errors"
 
+	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/scanner"
 )
 
-func (p *Parser) parseAlterTableRenameStatement(tableName string) (_ statement.AlterStmt, err error) {
+func (p *Parser) parseAlterTableRenameStatement(tableName string) (_ statement.Statement, err error) {
 	var stmt statement.AlterStmt
 	stmt.TableName = tableName
 
+	if ok, _ := p.parseOptional(scanner.FIELD); ok {
+		return p.parseAlterTableRenameFieldStatement(tableName)
+	}
+
 	// Parse "TO".
 	if err := p.parseTokens(scanner.TO); err != nil {
 		return stmt, err
@@ -25,15 +32,62 @@ func (p *Parser) parseAlterTableRenameStatement(tableName string) (_ statement.A
 	return stmt, nil
 }
 
-func (p *Parser) parseAlterTableAddFieldStatement(tableName string) (_ statement.AlterTableAddField, err error) {
-	var stmt statement.AlterTableAddField
-	stmt.Info.TableName = tableName
+// parseAlterTableRenameFieldStatement parses the path of the field and its new name.
+// This function assumes the RENAME FIELD tokens have already been consumed.
+func (p *Parser) parseAlterTableRenameFieldStatement(tableName string) (_ statement.AlterTableRenameField, err error) {
+	var stmt statement.AlterTableRenameField
+	stmt.TableName = tableName
+	opts := Options{Packages: p.packagesTable}
+	stmt.ParseExpr = func(s string) (expr.Expr, error) {
+		return NewParserWithOptions(strings.NewReader(s), &opts).ParseExpr()
+	}
+
+	// Parse path of the field.
+	stmt.Path, err = p.parsePath()
+	if err != nil {
+		return stmt, err
+	}
+
+	// Parse "TO".
+	if err := p.parseTokens(scanner.TO); err != nil {
+		return stmt, err
+	}
+
+	// Parse new field name.
+	stmt.NewName, err = p.parseIdent()
+	if err != nil {
+		return stmt, err
+	}
+
+	return stmt, nil
+}
+
+// parseAlterTableDropFieldStatement parses the path of the field to drop.
+// This function assumes the DROP token has already been consumed.
+func (p *Parser) parseAlterTableDropFieldStatement(tableName string) (_ statement.AlterTableDropField, err error) {
+	var stmt statement.AlterTableDropField
+	stmt.TableName = tableName
 
 	// Parse "FIELD".
 	if err := p.parseTokens(scanner.FIELD); err != nil {
 		return stmt, err
 	}
 
+	// Parse path of the field.
+	stmt.Path, err = p.parsePath()
+	if err != nil {
+		return stmt, err
+	}
+
+	return stmt, nil
+}
+
+// parseAlterTableAddFieldStatement parses the definition of the new field.
+// This function assumes the ADD FIELD tokens have already been consumed.
+func (p *Parser) parseAlterTableAddFieldStatement(tableName string) (_ statement.AlterTableAddField, err error) {
+	var stmt statement.AlterTableAddField
+	stmt.Info.TableName = tableName
+
 	// Parse new field definition.
 	fc, tcs, err := p.parseFieldDefinition(nil)
 	if err != nil {
@@ -63,15 +117,98 @@ func (p *Parser) parseAlterTableAddFieldStatement(tableName string) (_ statement
 	return stmt, nil
 }
 
+// parseAlterTableAddConstraintStatement parses the definition of the new table constraint.
+// This function assumes the ADD token has already been consumed.
+func (p *Parser) parseAlterTableAddConstraintStatement(tableName string) (_ statement.AlterTableAddConstraint, err error) {
+	var stmt statement.AlterTableAddConstraint
+	stmt.TableName = tableName
+
+	// Parse table constraint.
+	stmt.Constraint, err = p.parseTableConstraint(nil)
+	if err != nil {
+		return stmt, err
+	}
+	if stmt.Constraint == nil {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		return stmt, newParseError(scanner.Tokstr(tok, lit), []string{"FIELD", "CONSTRAINT", "UNIQUE", "CHECK", "FOREIGN"}, pos)
+	}
+
+	if stmt.Constraint.PrimaryKey {
+		return stmt, &ParseError{Message: "cannot add a PRIMARY KEY constraint"}
+	}
+
+	return stmt, nil
+}
+
+// parseAlterTableDropConstraintStatement parses the name of the constraint to drop.
+// This function assumes the DROP CONSTRAINT tokens have already been consumed.
+func (p *Parser) parseAlterTableDropConstraintStatement(tableName string) (_ statement.AlterTableDropConstraint, err error) {
+	var stmt statement.AlterTableDropConstraint
+	stmt.TableName = tableName
+
+	// Parse constraint name.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.IDENT, scanner.STRING:
+		stmt.ConstraintName = lit
+	default:
+		return stmt, newParseError(scanner.Tokstr(tok, lit), []string{"IDENT", "STRING"}, pos)
+	}
+
+	return stmt, nil
+}
+
+// parseAlterTableAlterFieldStatement parses the path of the field and its new type.
+// This function assumes the ALTER token has already been consumed.
+func (p *Parser) parseAlterTableAlterFieldStatement(tableName string) (_ statement.AlterTableAlterFieldType, err error) {
+	var stmt statement.AlterTableAlterFieldType
+	stmt.TableName = tableName
+
+	// Parse "FIELD".
+	if err := p.parseTokens(scanner.FIELD); err != nil {
+		return stmt, err
+	}
+
+	// Parse path of the field.
+	stmt.Path, err = p.parsePath()
+	if err != nil {
+		return stmt, err
+	}
+
+	// Parse "TYPE".
+	// TYPE is not a keyword to allow using it as a field name.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok != scanner.IDENT || !strings.EqualFold(lit, "type") {
+		return stmt, newParseError(scanner.Tokstr(tok, lit), []string{"TYPE"}, pos)
+	}
+
+	// Parse new type.
+	stmt.Type, err = p.parseType()
+	if err != nil {
+		return stmt, err
+	}
+
+	return stmt, nil
+}
+
 // parseAlterStatement parses a Alter query string and returns a Statement AST object.
 func (p *Parser) parseAlterStatement() (statement.Statement, error) {
 	var err error
 
-	// Parse "TABLE".
-	if err := p.parseTokens(scanner.ALTER, scanner.TABLE); err != nil {
+	// Parse "ALTER".
+	if err := p.parseTokens(scanner.ALTER); err != nil {
 		return nil, err
 	}
 
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.TABLE:
+	case scanner.VIEW:
+		return p.parseAlterViewStatement()
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"TABLE", "VIEW"}, pos)
+	}
+
 	// Parse table name.
 	tableName, err := p.parseIdent()
 	if err != nil {
@@ -80,13 +217,50 @@ func (p *Parser) parseAlterStatement() (statement.Statement, error) {
 		return nil, pErr
 	}
 
-	tok, pos, lit := p.ScanIgnoreWhitespace()
+	tok, pos, lit = p.ScanIgnoreWhitespace()
 	switch tok {
 	case scanner.RENAME:
 		return p.parseAlterTableRenameStatement(tableName)
 	case scanner.ADD_KEYWORD:
-		return p.parseAlterTableAddFieldStatement(tableName)
+		if ok, _ := p.parseOptional(scanner.FIELD); ok {
+			return p.parseAlterTableAddFieldStatement(tableName)
+		}
+		return p.parseAlterTableAddConstraintStatement(tableName)
+	case scanner.DROP:
+		if ok, _ := p.parseOptional(scanner.CONSTRAINT); ok {
+			return p.parseAlterTableDropConstraintStatement(tableName)
+		}
+		return p.parseAlterTableDropFieldStatement(tableName)
+	case scanner.ALTER:
+		return p.parseAlterTableAlterFieldStatement(tableName)
 	}
 
-	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"ADD", "RENAME"}, pos)
+	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"ADD", "ALTER", "DROP", "RENAME"}, pos)
+}
+
+// parseAlterViewStatement parses an ALTER VIEW ... RENAME TO string and returns a Statement AST object.
+// This function assumes the ALTER VIEW tokens have already been consumed.
+func (p *Parser) parseAlterViewStatement() (_ statement.AlterViewStmt, err error) {
+	var stmt statement.AlterViewStmt
+
+	// Parse view name.
+	stmt.ViewName, err = p.parseIdent()
+	if err != nil {
+		pErr := errors.Unwrap(err).(*ParseError)
+		pErr.Expected = []string{"view_name"}
+		return stmt, pErr
+	}
+
+	// Parse "RENAME TO".
+	if err := p.parseTokens(scanner.RENAME, scanner.TO); err != nil {
+		return stmt, err
+	}
+
+	// Parse new view name.
+	stmt.NewViewName, err = p.parseIdent()
+	if err != nil {
+		return stmt, err
+	}
+
+	return stmt, nil
 }
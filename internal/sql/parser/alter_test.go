@@ -3,10 +3,12 @@ package parser_test
 import (
 	"testing"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/genjidb/genji/types"
 	"github.com/stretchr/testify/require"
@@ -39,6 +41,32 @@ func TestParserAlterTable(t *testing.T) {
 	}
 }
 
+func TestParserAlterView(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER VIEW foo RENAME TO bar", statement.AlterViewStmt{ViewName: "foo", NewViewName: "bar"}, false},
+		{"With error / missing RENAME", "ALTER VIEW foo TO bar", statement.AlterViewStmt{}, true},
+		{"With error / two identifiers for new view name", "ALTER VIEW foo RENAME TO bar baz", statement.AlterViewStmt{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
 func TestParserAlterTableAddField(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -100,3 +128,177 @@ func TestParserAlterTableAddField(t *testing.T) {
 		})
 	}
 }
+
+func TestParserAlterTableDropField(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER TABLE foo DROP FIELD bar", statement.AlterTableDropField{TableName: "foo", Path: document.NewPath("bar")}, false},
+		{"Nested", "ALTER TABLE foo DROP FIELD bar.baz", statement.AlterTableDropField{TableName: "foo", Path: document.NewPath("bar", "baz")}, false},
+		{"With error / missing FIELD keyword", "ALTER TABLE foo DROP bar", nil, true},
+		{"With error / missing path", "ALTER TABLE foo DROP FIELD", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
+func TestParserAlterTableRenameField(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		path    document.Path
+		newName string
+		errored bool
+	}{
+		{"Basic", "ALTER TABLE foo RENAME FIELD bar TO baz", document.NewPath("bar"), "baz", false},
+		{"Nested", "ALTER TABLE foo RENAME FIELD bar.baz TO qux", document.NewPath("bar", "baz"), "qux", false},
+		{"With error / missing TO", "ALTER TABLE foo RENAME FIELD bar baz", nil, "", true},
+		{"With error / path as new name", "ALTER TABLE foo RENAME FIELD bar TO baz.qux", nil, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			stmt := q.Statements[0].(statement.AlterTableRenameField)
+			require.Equal(t, "foo", stmt.TableName)
+			require.Equal(t, test.path, stmt.Path)
+			require.Equal(t, test.newName, stmt.NewName)
+
+			e, err := stmt.ParseExpr("bar > 1")
+			assert.NoError(t, err)
+			require.Equal(t, "bar > 1", e.String())
+		})
+	}
+}
+
+func TestParserAlterTableAddConstraint(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Unique", "ALTER TABLE foo ADD UNIQUE (bar)", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				Paths:  document.Paths{document.NewPath("bar")},
+				Unique: true,
+			},
+		}, false},
+		{"With name", "ALTER TABLE foo ADD CONSTRAINT baz UNIQUE (bar)", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				Name:   "baz",
+				Paths:  document.Paths{document.NewPath("bar")},
+				Unique: true,
+			},
+		}, false},
+		{"Check", "ALTER TABLE foo ADD CHECK (bar > 0)", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				Paths: document.Paths{document.NewPath("bar")},
+				Check: expr.Constraint(expr.Gt(expr.Path(document.NewPath("bar")), testutil.IntegerValue(0))),
+			},
+		}, false},
+		{"Foreign key", "ALTER TABLE foo ADD FOREIGN KEY (bar) REFERENCES baz ON DELETE CASCADE", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				Paths: document.Paths{document.NewPath("bar")},
+				ForeignKey: &database.ForeignKey{
+					Table:    "baz",
+					OnDelete: database.ForeignKeyCascade,
+				},
+			},
+		}, false},
+		{"With error / primary key", "ALTER TABLE foo ADD PRIMARY KEY (bar)", nil, true},
+		{"With error / missing constraint", "ALTER TABLE foo ADD CONSTRAINT baz", nil, true},
+		{"With error / missing paths", "ALTER TABLE foo ADD UNIQUE", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
+func TestParserAlterTableDropConstraint(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER TABLE foo DROP CONSTRAINT bar", statement.AlterTableDropConstraint{TableName: "foo", ConstraintName: "bar"}, false},
+		{"With string", `ALTER TABLE foo DROP CONSTRAINT "bar baz"`, statement.AlterTableDropConstraint{TableName: "foo", ConstraintName: "bar baz"}, false},
+		{"With error / missing name", "ALTER TABLE foo DROP CONSTRAINT", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
+func TestParserAlterTableAlterFieldType(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER TABLE foo ALTER FIELD bar TYPE INTEGER", statement.AlterTableAlterFieldType{TableName: "foo", Path: document.NewPath("bar"), Type: types.IntegerValue}, false},
+		{"Nested", "ALTER TABLE foo ALTER FIELD bar.baz type text", statement.AlterTableAlterFieldType{TableName: "foo", Path: document.NewPath("bar", "baz"), Type: types.TextValue}, false},
+		{"With error / missing TYPE", "ALTER TABLE foo ALTER FIELD bar INTEGER", nil, true},
+		{"With error / missing type", "ALTER TABLE foo ALTER FIELD bar TYPE", nil, true},
+		{"With error / missing FIELD", "ALTER TABLE foo ALTER bar TYPE INTEGER", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
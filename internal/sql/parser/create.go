@@ -3,10 +3,12 @@ package parser
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/types"
@@ -33,9 +35,13 @@ func (p *Parser) parseCreateStatement() (statement.Statement, error) {
 		return p.parseCreateIndexStatement(false)
 	case scanner.SEQUENCE:
 		return p.parseCreateSequenceStatement()
+	case scanner.VIEW:
+		return p.parseCreateViewStatement()
+	case scanner.TRIGGER:
+		return p.parseCreateTriggerStatement()
 	}
 
-	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"TABLE", "INDEX", "SEQUENCE"}, pos)
+	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"TABLE", "INDEX", "SEQUENCE", "VIEW", "TRIGGER"}, pos)
 }
 
 // parseCreateTableStatement parses a create table string and returns a Statement AST object.
@@ -158,11 +164,19 @@ func (p *Parser) parseFieldDefinition(parent document.Path) (*database.FieldCons
 		return nil, nil, err
 	}
 
+	fc.IsDate = p.peekDate()
 	fc.Type, err = p.parseType()
 	if err != nil {
 		p.Unscan()
 	}
 
+	if fc.Type == types.DecimalValue {
+		fc.Precision, fc.Scale, err = p.parseDecimalParams()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	path := parent.ExtendField(fc.Field)
 
 	var tcs []*database.TableConstraint
@@ -252,11 +266,30 @@ LOOP:
 				scanner.LPAREN,   // only opening parenthesis are necessary
 				scanner.LBRACKET, // only opening brackets are necessary
 				scanner.NEXT,
+				scanner.IDENT, // functions
 			)
 			if err != nil {
 				return nil, nil, err
 			}
 
+			// only scalar functions, e.g. strings.lower('A'), can be called
+			// and the value must not depend on the document
+			expr.Walk(e, func(e expr.Expr) bool {
+				switch e.(type) {
+				case expr.Path:
+					err = fmt.Errorf("paths are not allowed in DEFAULT clause: %s", e)
+					return false
+				case *functions.ScalarFunction:
+				case expr.Function:
+					err = fmt.Errorf("function %s is not allowed in DEFAULT clause", e)
+					return false
+				}
+				return true
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+
 			fc.DefaultValue = expr.Constraint(e)
 
 			if withParentheses {
@@ -280,6 +313,16 @@ LOOP:
 				Check: expr.Constraint(e),
 				Paths: paths,
 			})
+		case scanner.REFERENCES:
+			fk, err := p.parseForeignKeyReference()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			tcs = append(tcs, &database.TableConstraint{
+				Paths:      document.Paths{path},
+				ForeignKey: fk,
+			})
 		default:
 			p.Unscan()
 			break LOOP
@@ -390,9 +433,33 @@ func (p *Parser) parseTableConstraint(stmt *statement.CreateTableStmt) (*databas
 
 		tc.Check = expr.Constraint(e)
 		tc.Paths = paths
+	case scanner.FOREIGN:
+		// Parse "KEY ("
+		err = p.parseTokens(scanner.KEY)
+		if err != nil {
+			return nil, err
+		}
+
+		tc.Paths, err = p.parsePathList()
+		if err != nil {
+			return nil, err
+		}
+		if len(tc.Paths) == 0 {
+			tok, pos, lit := p.ScanIgnoreWhitespace()
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"PATHS"}, pos)
+		}
+
+		if err := p.parseTokens(scanner.REFERENCES); err != nil {
+			return nil, err
+		}
+
+		tc.ForeignKey, err = p.parseForeignKeyReference()
+		if err != nil {
+			return nil, err
+		}
 	default:
 		if requiresTc {
-			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"PRIMARY", "UNIQUE", "CHECK"}, pos)
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"PRIMARY", "UNIQUE", "CHECK", "FOREIGN"}, pos)
 		}
 
 		p.Unscan()
@@ -402,6 +469,61 @@ func (p *Parser) parseTableConstraint(stmt *statement.CreateTableStmt) (*databas
 	return &tc, nil
 }
 
+// parseForeignKeyReference parses the table and paths referenced by a foreign key,
+// followed by its optional actions.
+// It assumes the REFERENCES token has already been consumed.
+func (p *Parser) parseForeignKeyReference() (*database.ForeignKey, error) {
+	var fk database.ForeignKey
+	var err error
+
+	fk.Table, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	fk.Paths, err = p.parsePathList()
+	if err != nil {
+		return nil, err
+	}
+
+	var hasOnDelete, hasOnUpdate bool
+	for {
+		if ok, _ := p.parseOptional(scanner.ON); !ok {
+			break
+		}
+
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		var action *database.ForeignKeyAction
+		switch {
+		case tok == scanner.DELETE && !hasOnDelete:
+			hasOnDelete = true
+			action = &fk.OnDelete
+		case tok == scanner.UPDATE && !hasOnUpdate:
+			hasOnUpdate = true
+			action = &fk.OnUpdate
+		default:
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"DELETE", "UPDATE"}, pos)
+		}
+
+		tok, pos, lit = p.ScanIgnoreWhitespace()
+		switch tok {
+		case scanner.CASCADE:
+			*action = database.ForeignKeyCascade
+		case scanner.RESTRICT:
+			*action = database.ForeignKeyRestrict
+		case scanner.SET:
+			if err := p.parseTokens(scanner.NULL); err != nil {
+				return nil, err
+			}
+			*action = database.ForeignKeySetNull
+		default:
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"CASCADE", "RESTRICT", "SET"}, pos)
+		}
+	}
+
+	return &fk, nil
+}
+
 // parseCreateIndexStatement parses a create index string and returns a Statement AST object.
 // This function assumes the CREATE INDEX or CREATE UNIQUE INDEX tokens have already been consumed.
 func (p *Parser) parseCreateIndexStatement(unique bool) (*statement.CreateIndexStmt, error) {
@@ -437,20 +559,120 @@ func (p *Parser) parseCreateIndexStatement(unique bool) (*statement.CreateIndexS
 		return nil, err
 	}
 
-	paths, err := p.parsePathList()
-	if err != nil {
+	// Parse "("
+	if err := p.parseTokens(scanner.LPAREN); err != nil {
 		return nil, err
 	}
-	if len(paths) == 0 {
-		tok, pos, lit := p.ScanIgnoreWhitespace()
-		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"("}, pos)
+
+	// Parse the list of indexed paths and expressions
+	for {
+		e, err := p.parseIndexedExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if path, ok := e.(expr.Path); ok {
+			// multikey indexes store one entry per element of a single array
+			if _, ok := document.Path(path).ElementsOf(); ok && stmt.Info.MultikeyPos() >= 0 {
+				return nil, fmt.Errorf("cannot index the elements of more than one array")
+			}
+
+			stmt.Info.Paths = append(stmt.Info.Paths, document.Path(path))
+			if stmt.Info.Exprs != nil {
+				stmt.Info.Exprs = append(stmt.Info.Exprs, nil)
+			}
+		} else {
+			err = checkIndexExpr(e)
+			if err != nil {
+				return nil, err
+			}
+
+			if stmt.Info.Exprs == nil {
+				stmt.Info.Exprs = make([]database.TableExpression, len(stmt.Info.Paths))
+			}
+			stmt.Info.Paths = append(stmt.Info.Paths, nil)
+			stmt.Info.Exprs = append(stmt.Info.Exprs, expr.Constraint(e))
+			stmt.Info.ExprPaths = appendPaths(stmt.Info.ExprPaths, exprPaths(e)...)
+		}
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
+	}
+
+	// Parse ")"
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return nil, err
 	}
 
-	stmt.Info.Paths = paths
+	// Parse optional WHERE clause of partial indexes
+	e, err := p.parseCondition()
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		err = checkIndexExpr(e)
+		if err != nil {
+			return nil, err
+		}
+
+		stmt.Info.Predicate = expr.Constraint(e)
+		stmt.Info.ExprPaths = appendPaths(stmt.Info.ExprPaths, exprPaths(e)...)
+	}
 
 	return &stmt, nil
 }
 
+// parseIndexedExpr parses an indexed path or expression.
+// Unlike other expressions, it can be a path ending with [*],
+// to index every element of an array.
+func (p *Parser) parseIndexedExpr() (expr.Expr, error) {
+	_, pos, _ := p.ScanIgnoreWhitespace()
+	p.Unscan()
+
+	e, err := p.parseExprWithMinPrecedence(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if isAnyElement(e) {
+		return e, nil
+	}
+
+	err = checkAnyElement(e, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// checkIndexExpr returns an error if the value of the expression
+// doesn't only depend on the indexed document.
+func checkIndexExpr(e expr.Expr) error {
+	var err error
+	expr.Walk(e, func(e expr.Expr) bool {
+		switch t := e.(type) {
+		case expr.NamedParam, expr.PositionalParam, expr.NextValueFor, *statement.Subquery:
+			err = fmt.Errorf("%s is not allowed in index expressions", e)
+		case expr.Cast, *expr.Case:
+		case expr.Path:
+			if _, ok := document.Path(t).ElementsOf(); ok {
+				err = fmt.Errorf("%s is not allowed in index expressions", e)
+			}
+		case expr.Function:
+			if !functions.IsDeterministic(t) {
+				err = fmt.Errorf("function %s is not allowed in index expressions", e)
+			}
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
 // This function assumes the CREATE SEQUENCE tokens have already been consumed.
 func (p *Parser) parseCreateSequenceStatement() (*statement.CreateSequenceStmt, error) {
 	var stmt statement.CreateSequenceStmt
@@ -679,6 +901,208 @@ func (p *Parser) parseCreateSequenceStatement() (*statement.CreateSequenceStmt,
 	return &stmt, err
 }
 
+// parseCreateViewStatement parses a create view string and returns a Statement AST object.
+// This function assumes the CREATE VIEW tokens have already been consumed.
+func (p *Parser) parseCreateViewStatement() (*statement.CreateViewStmt, error) {
+	var stmt statement.CreateViewStmt
+	var err error
+
+	// Parse IF NOT EXISTS
+	stmt.IfNotExists, err = p.parseOptional(scanner.IF, scanner.NOT, scanner.EXISTS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse view name
+	stmt.Info.ViewName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse "AS"
+	if err := p.parseTokens(scanner.AS); err != nil {
+		return nil, err
+	}
+
+	stmt.Info.Query, err = p.parseViewQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	return &stmt, nil
+}
+
+// parseViewQuery parses the SELECT statement of a view, and keeps its text
+// so that it can be stored in the catalog and parsed again every time the view is used.
+func (p *Parser) parseViewQuery() (*statement.ViewQuery, error) {
+	tok, start, lit := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	if tok != scanner.SELECT && tok != scanner.WITH {
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"SELECT", "WITH"}, start)
+	}
+
+	params := p.orderedParams + p.namedParams
+
+	stmt, err := p.ParseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.orderedParams+p.namedParams != params {
+		return nil, &ParseError{Message: "views cannot use parameters"}
+	}
+
+	_, end, _ := p.ScanIgnoreWhitespace()
+	p.Unscan()
+
+	sql := p.sourceText(start, end)
+	opts := Options{Packages: p.packagesTable}
+
+	return statement.NewViewQuery(sql, stmt.(*statement.SelectStmt), func() (*statement.SelectStmt, error) {
+		stmt, err := NewParserWithOptions(strings.NewReader(sql), &opts).ParseStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		return stmt.(*statement.SelectStmt), nil
+	}), nil
+}
+
+// parseCreateTriggerStatement parses a create trigger string and returns a Statement AST object.
+// This function assumes the CREATE TRIGGER tokens have already been consumed.
+func (p *Parser) parseCreateTriggerStatement() (*statement.CreateTriggerStmt, error) {
+	var stmt statement.CreateTriggerStmt
+	var err error
+
+	// Parse IF NOT EXISTS
+	stmt.IfNotExists, err = p.parseOptional(scanner.IF, scanner.NOT, scanner.EXISTS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse trigger name
+	stmt.Info.TriggerName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse BEFORE or AFTER
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.BEFORE:
+		stmt.Info.Timing = database.TriggerBefore
+	case scanner.AFTER:
+		stmt.Info.Timing = database.TriggerAfter
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"BEFORE", "AFTER"}, pos)
+	}
+
+	// Parse INSERT, UPDATE or DELETE
+	tok, pos, lit = p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.INSERT:
+		stmt.Info.Event = database.TriggerInsert
+	case scanner.UPDATE:
+		stmt.Info.Event = database.TriggerUpdate
+	case scanner.DELETE:
+		stmt.Info.Event = database.TriggerDelete
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "UPDATE", "DELETE"}, pos)
+	}
+
+	// Parse "ON"
+	if err := p.parseTokens(scanner.ON); err != nil {
+		return nil, err
+	}
+
+	// Parse table name
+	stmt.Info.TableName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse optional FOR EACH ROW, which is the only supported mode
+	_, err = p.parseOptional(scanner.FOR, scanner.EACH, scanner.ROW)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt.Info.Body, err = p.parseTriggerBody()
+	if err != nil {
+		return nil, err
+	}
+
+	return &stmt, nil
+}
+
+// parseTriggerBody parses the statements executed by a trigger, either a single statement
+// or a list of statements terminated by semicolons between BEGIN and END.
+// The text of the statements is kept so that it can be stored in the catalog
+// and parsed again when the statements must be prepared again.
+func (p *Parser) parseTriggerBody() (*statement.TriggerBody, error) {
+	block, err := p.parseOptional(scanner.BEGIN)
+	if err != nil {
+		return nil, err
+	}
+
+	params := p.orderedParams + p.namedParams
+
+	var stmts []statement.Statement
+	var texts []string
+	for {
+		tok, start, lit := p.ScanIgnoreWhitespace()
+		p.Unscan()
+		switch tok {
+		case scanner.INSERT, scanner.UPDATE, scanner.DELETE, scanner.SELECT, scanner.WITH:
+		default:
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "UPDATE", "DELETE", "SELECT", "WITH"}, start)
+		}
+
+		stmt, err := p.ParseStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		_, end, _ := p.ScanIgnoreWhitespace()
+		p.Unscan()
+
+		stmts = append(stmts, stmt)
+		texts = append(texts, p.sourceText(start, end))
+
+		if !block {
+			break
+		}
+
+		if err := p.parseTokens(scanner.SEMICOLON); err != nil {
+			return nil, err
+		}
+
+		done, err := p.parseOptional(scanner.END)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			break
+		}
+	}
+
+	if p.orderedParams+p.namedParams != params {
+		return nil, &ParseError{Message: "triggers cannot use parameters"}
+	}
+
+	sql := strings.Join(texts, "; ")
+	opts := Options{Packages: p.packagesTable}
+
+	return statement.NewTriggerBody(sql, stmts, func() ([]statement.Statement, error) {
+		q, err := NewParserWithOptions(strings.NewReader(sql), &opts).ParseQuery()
+		if err != nil {
+			return nil, err
+		}
+
+		return q.Statements, nil
+	}), nil
+}
+
 // parseCheckConstraint parses a check constraint.
 // it assumes the CHECK token has already been parsed.
 func (p *Parser) parseCheckConstraint() (expr.Expr, []document.Path, error) {
@@ -693,33 +1117,46 @@ func (p *Parser) parseCheckConstraint() (expr.Expr, []document.Path, error) {
 		return nil, nil, err
 	}
 
+	paths := exprPaths(e)
+
+	// Parse ")"
+	err = p.parseTokens(scanner.RPAREN)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return e, paths, nil
+}
+
+// exprPaths returns all the distinct paths used by the expression.
+func exprPaths(e expr.Expr) []document.Path {
 	var paths []document.Path
-	// extract all the paths from the expression
 	expr.Walk(e, func(e expr.Expr) bool {
 		switch t := e.(type) {
 		case expr.Path:
-			pt := document.Path(t)
-			// ensure that the path is not already in the list
-			found := false
-			for _, p := range paths {
-				if p.IsEqual(pt) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				paths = append(paths, document.Path(t))
-			}
+			paths = appendPaths(paths, document.Path(t))
 		}
 
 		return true
 	})
 
-	// Parse ")"
-	err = p.parseTokens(scanner.RPAREN)
-	if err != nil {
-		return nil, nil, err
+	return paths
+}
+
+// appendPaths appends the paths that are not already in the list.
+func appendPaths(paths []document.Path, others ...document.Path) []document.Path {
+	for _, o := range others {
+		found := false
+		for _, p := range paths {
+			if p.IsEqual(o) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			paths = append(paths, o)
+		}
 	}
 
-	return e, paths, nil
+	return paths
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/testutil"
@@ -48,6 +49,34 @@ func TestParserCreateIndex(t *testing.T) {
 			},
 			false},
 		{"No fields", "CREATE INDEX idx ON test", nil, true},
+		{"Partial", "CREATE UNIQUE INDEX idx ON test (foo) WHERE bar > 10 AND baz.a", &statement.CreateIndexStmt{
+			Info: database.IndexInfo{
+				IndexName: "idx",
+				Owner:     database.Owner{TableName: "test"},
+				Paths:     []document.Path{document.Path(testutil.ParseDocumentPath(t, "foo"))},
+				Unique:    true,
+				Predicate: expr.Constraint(parser.MustParseExpr("bar > 10 AND baz.a")),
+				ExprPaths: []document.Path{document.Path(testutil.ParseDocumentPath(t, "bar")), document.Path(testutil.ParseDocumentPath(t, "baz.a"))},
+			}}, false},
+		{"Partial without predicate", "CREATE INDEX idx ON test (foo) WHERE", nil, true},
+		{"Expression", "CREATE INDEX idx ON test (foo, bar + 1)", &statement.CreateIndexStmt{
+			Info: database.IndexInfo{
+				IndexName: "idx",
+				Owner:     database.Owner{TableName: "test"},
+				Paths:     []document.Path{document.Path(testutil.ParseDocumentPath(t, "foo")), nil},
+				Exprs:     []database.TableExpression{nil, expr.Constraint(parser.MustParseExpr("bar + 1"))},
+				ExprPaths: []document.Path{document.Path(testutil.ParseDocumentPath(t, "bar"))},
+			}}, false},
+		{"Non deterministic expression", "CREATE INDEX idx ON test (time.now())", nil, true},
+		{"Multikey", "CREATE INDEX idx ON test (foo, bar[*])", &statement.CreateIndexStmt{
+			Info: database.IndexInfo{
+				IndexName: "idx",
+				Owner:     database.Owner{TableName: "test"},
+				Paths:     []document.Path{document.Path(testutil.ParseDocumentPath(t, "foo")), document.Path{document.PathFragment{FieldName: "bar"}, document.PathFragment{ArrayIndex: document.AnyIndex}}},
+			}}, false},
+		{"Multikey with two arrays", "CREATE INDEX idx ON test (foo[*], bar[*])", nil, true},
+		{"Multikey in expression", "CREATE INDEX idx ON test (foo[*] + 1)", nil, true},
+		{"Subquery in predicate", "CREATE INDEX idx ON test (foo) WHERE foo IN (SELECT a FROM b)", nil, true},
 	}
 
 	for _, test := range tests {
@@ -204,3 +233,108 @@ func TestParserCreateSequence(t *testing.T) {
 		})
 	}
 }
+
+func TestParserCreateView(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		viewName    string
+		ifNotExists bool
+		sql         string
+		relations   []string
+		errored     bool
+	}{
+		{"Basic", "CREATE VIEW v AS SELECT a FROM test", "v", false, "SELECT a FROM test", []string{"test"}, false},
+		{"If not exists", "CREATE VIEW IF NOT EXISTS v AS SELECT * FROM test WHERE a > 1", "v", true, "SELECT * FROM test WHERE a > 1", []string{"test"}, false},
+		{"With semicolon", "CREATE VIEW v AS SELECT a FROM test ; SELECT 1", "v", false, "SELECT a FROM test", []string{"test"}, false},
+		{"Join and subquery", "CREATE VIEW v AS SELECT a FROM foo JOIN bar ON foo.a = bar.a WHERE foo.b IN (SELECT b FROM baz)", "v", false, "SELECT a FROM foo JOIN bar ON foo.a = bar.a WHERE foo.b IN (SELECT b FROM baz)", []string{"bar", "baz", "foo"}, false},
+		{"Compound", "CREATE VIEW v AS SELECT a FROM foo UNION SELECT a FROM bar", "v", false, "SELECT a FROM foo UNION SELECT a FROM bar", []string{"bar", "foo"}, false},
+		{"CTE", "CREATE VIEW v AS WITH foo AS (SELECT a FROM bar) SELECT a FROM foo", "v", false, "WITH foo AS (SELECT a FROM bar) SELECT a FROM foo", []string{"bar"}, false},
+		{"Unicode", "CREATE VIEW v AS SELECT 'héhé' AS a FROM test", "v", false, "SELECT 'héhé' AS a FROM test", []string{"test"}, false},
+		{"No query", "CREATE VIEW v AS", "", false, "", nil, true},
+		{"No AS", "CREATE VIEW v SELECT a FROM test", "", false, "", nil, true},
+		{"Not a select", "CREATE VIEW v AS DELETE FROM test", "", false, "", nil, true},
+		{"Params", "CREATE VIEW v AS SELECT a FROM test WHERE a = ?", "", false, "", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			stmt := q.Statements[0].(*statement.CreateViewStmt)
+			require.Equal(t, test.viewName, stmt.Info.ViewName)
+			require.Equal(t, test.ifNotExists, stmt.IfNotExists)
+			require.Equal(t, test.sql, stmt.Info.Query.String())
+			require.Equal(t, test.relations, stmt.Info.Query.Relations())
+
+			// the query can be parsed again
+			sel, err := stmt.Info.Query.(*statement.ViewQuery).Parse()
+			assert.NoError(t, err)
+			require.NotEmpty(t, sel.CompoundSelect)
+		})
+	}
+}
+
+func TestParserCreateTrigger(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		expected    database.TriggerInfo
+		ifNotExists bool
+		sql         string
+		statements  int
+		errored     bool
+	}{
+		{"Single statement", "CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (a) VALUES (NEW.a)",
+			database.TriggerInfo{TriggerName: "t", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerInsert},
+			false, "INSERT INTO audit (a) VALUES (NEW.a)", 1, false},
+		{"For each row", "CREATE TRIGGER t BEFORE UPDATE ON test FOR EACH ROW UPDATE counter SET n = n + 1",
+			database.TriggerInfo{TriggerName: "t", TableName: "test", Timing: database.TriggerBefore, Event: database.TriggerUpdate},
+			false, "UPDATE counter SET n = n + 1", 1, false},
+		{"If not exists", "CREATE TRIGGER IF NOT EXISTS t AFTER DELETE ON test DELETE FROM audit WHERE a = OLD.a",
+			database.TriggerInfo{TriggerName: "t", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerDelete},
+			true, "DELETE FROM audit WHERE a = OLD.a", 1, false},
+		{"Block", "CREATE TRIGGER t AFTER INSERT ON test FOR EACH ROW BEGIN INSERT INTO audit (a) VALUES (NEW.a);\n  UPDATE counter SET n = n + 1 ; END",
+			database.TriggerInfo{TriggerName: "t", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerInsert},
+			false, "INSERT INTO audit (a) VALUES (NEW.a); UPDATE counter SET n = n + 1", 2, false},
+		{"No timing", "CREATE TRIGGER t INSERT ON test DELETE FROM audit", database.TriggerInfo{}, false, "", 0, true},
+		{"No event", "CREATE TRIGGER t AFTER ON test DELETE FROM audit", database.TriggerInfo{}, false, "", 0, true},
+		{"No table", "CREATE TRIGGER t AFTER INSERT DELETE FROM audit", database.TriggerInfo{}, false, "", 0, true},
+		{"No body", "CREATE TRIGGER t AFTER INSERT ON test", database.TriggerInfo{}, false, "", 0, true},
+		{"Empty block", "CREATE TRIGGER t AFTER INSERT ON test BEGIN END", database.TriggerInfo{}, false, "", 0, true},
+		{"Missing semicolon", "CREATE TRIGGER t AFTER INSERT ON test BEGIN DELETE FROM audit END", database.TriggerInfo{}, false, "", 0, true},
+		{"Missing END", "CREATE TRIGGER t AFTER INSERT ON test BEGIN DELETE FROM audit;", database.TriggerInfo{}, false, "", 0, true},
+		{"Not a DML statement", "CREATE TRIGGER t AFTER INSERT ON test DROP TABLE audit", database.TriggerInfo{}, false, "", 0, true},
+		{"Params", "CREATE TRIGGER t AFTER INSERT ON test DELETE FROM audit WHERE a = ?", database.TriggerInfo{}, false, "", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+
+			stmt := q.Statements[0].(*statement.CreateTriggerStmt)
+			require.Equal(t, test.ifNotExists, stmt.IfNotExists)
+			require.Equal(t, test.sql, stmt.Info.Body.String())
+
+			info := stmt.Info
+			info.Body = nil
+			require.Equal(t, test.expected, info)
+
+			// the statements can be parsed again
+			stmts, err := stmt.Info.Body.(*statement.TriggerBody).Parse()
+			assert.NoError(t, err)
+			require.Len(t, stmts, test.statements)
+		})
+	}
+}
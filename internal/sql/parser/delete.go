@@ -49,5 +49,10 @@ func (p *Parser) parseDeleteStatement() (statement.Statement, error) {
 		return nil, err
 	}
 
+	stmt.Returning, err = p.parseReturning()
+	if err != nil {
+		return nil, err
+	}
+
 	return stmt, nil
 }
@@ -59,6 +59,12 @@ func TestParserDelete(t *testing.T) {
 				Pipe(table.Delete("test")).
 				Pipe(stream.Discard()),
 		},
+		{"WithReturning", "DELETE FROM test WHERE age = 10 RETURNING pk(), age",
+			stream.New(table.Scan("test")).
+				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
+				Pipe(table.DeleteReturning("test")).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "pk()"), testutil.ParseNamedExpr(t, "age"))),
+		},
 	}
 
 	for _, test := range tests {
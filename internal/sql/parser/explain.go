@@ -15,8 +15,8 @@ func (p *Parser) parseExplainStatement() (statement.Statement, error) {
 
 	// ensure we don't have multiple EXPLAIN keywords
 	tok, pos, lit := p.ScanIgnoreWhitespace()
-	if tok != scanner.SELECT && tok != scanner.UPDATE && tok != scanner.DELETE && tok != scanner.INSERT {
-		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "SELECT", "UPDATE", "DELETE"}, pos)
+	if tok != scanner.SELECT && tok != scanner.WITH && tok != scanner.UPDATE && tok != scanner.DELETE && tok != scanner.INSERT {
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "SELECT", "WITH", "UPDATE", "DELETE"}, pos)
 	}
 	p.Unscan()
 
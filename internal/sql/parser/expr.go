@@ -11,6 +11,7 @@ import (
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/types"
 )
@@ -33,7 +34,66 @@ func (d *dummyOperator) SetRightHandExpr(e expr.Expr) { d.rightHand = e }
 
 // ParseExpr parses an expression.
 func (p *Parser) ParseExpr() (e expr.Expr, err error) {
-	return p.parseExprWithMinPrecedence(0)
+	_, pos, _ := p.ScanIgnoreWhitespace()
+	p.Unscan()
+
+	e, err = p.parseExprWithMinPrecedence(0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkAnyElement(e, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// checkAnyElement returns an error if a path ending with [*] is used elsewhere
+// than as an operand of a comparison, IN or BETWEEN operator.
+// Only the operators of e are visited: expressions nested in functions, parentheses,
+// lists, etc. are checked when they are parsed.
+func checkAnyElement(e expr.Expr, pos scanner.Pos) error {
+	switch t := e.(type) {
+	case expr.Path:
+		if isAnyElement(t) {
+			return errors.WithStack(&ParseError{Message: fmt.Sprintf("%s can only be used in comparisons, IN or BETWEEN", t), Pos: pos})
+		}
+	case *expr.BetweenOperator:
+		if !isAnyElement(t.X) {
+			if err := checkAnyElement(t.X, pos); err != nil {
+				return err
+			}
+		}
+		if err := checkAnyElement(t.LeftHand(), pos); err != nil {
+			return err
+		}
+		return checkAnyElement(t.RightHand(), pos)
+	case expr.Operator:
+		accepted := expr.AcceptsAnyElement(t)
+		for _, operand := range []expr.Expr{t.LeftHand(), t.RightHand()} {
+			if accepted && isAnyElement(operand) {
+				continue
+			}
+			if err := checkAnyElement(operand, pos); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isAnyElement returns true if e is a path ending with [*].
+func isAnyElement(e expr.Expr) bool {
+	p, ok := e.(expr.Path)
+	if !ok {
+		return false
+	}
+
+	_, ok = document.Path(p).ElementsOf()
+	return ok
 }
 
 func (p *Parser) parseExprWithMinPrecedence(precedence int, allowed ...scanner.Token) (e expr.Expr, err error) {
@@ -93,18 +153,12 @@ func (p *Parser) parseOperator(minPrecedence int, allowed ...scanner.Token) (fun
 		return nil, 0, nil
 	}
 
-	// Ignore currently unused operators.
-	if op == scanner.EQREGEX || op == scanner.NEQREGEX {
-		p.Unscan()
-		return nil, 0, nil
-	}
-
 	if op == scanner.NOT {
 		tok, pos, lit := p.ScanIgnoreWhitespace()
 		if tok.Precedence() >= minPrecedence {
 			switch {
 			case tok == scanner.IN && tok.Precedence() >= minPrecedence:
-				return expr.NotIn, scanner.NIN, nil
+				return inOperator(expr.NotIn), scanner.NIN, nil
 			case tok == scanner.LIKE && tok.Precedence() >= minPrecedence:
 				return expr.NotLike, scanner.NLIKE, nil
 			}
@@ -152,7 +206,7 @@ func (p *Parser) parseOperator(minPrecedence int, allowed ...scanner.Token) (fun
 	case scanner.BITWISEXOR:
 		return expr.BitwiseXor, op, nil
 	case scanner.IN:
-		return expr.In, op, nil
+		return inOperator(expr.In), op, nil
 	case scanner.IS:
 		if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.NOT {
 			return expr.IsNot, scanner.ISN, nil
@@ -161,6 +215,10 @@ func (p *Parser) parseOperator(minPrecedence int, allowed ...scanner.Token) (fun
 		return expr.Is, op, nil
 	case scanner.LIKE:
 		return expr.Like, op, nil
+	case scanner.EQREGEX:
+		return expr.Regex, op, nil
+	case scanner.NEQREGEX:
+		return expr.NotRegex, op, nil
 	case scanner.CONCAT:
 		return expr.Concat, op, nil
 	case scanner.BETWEEN:
@@ -194,6 +252,9 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 	case scanner.CAST:
 		p.Unscan()
 		return p.parseCastExpression()
+	case scanner.CASE:
+		p.Unscan()
+		return p.parseCaseExpression()
 	case scanner.IDENT:
 		tok1, _, _ := p.ScanIgnoreWhitespace()
 		// if the next token is a left parenthesis, this is a global function
@@ -206,7 +267,7 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 			return p.parseFunction()
 		} else if tok1 == scanner.DOT {
 			// it may be a package function instead.
-			if tok2, _, _ := p.Scan(); tok2 == scanner.IDENT {
+			if tok2, _, _ := p.Scan(); tok2 == scanner.IDENT || tok2.IsKeyword() {
 				if tok3, _, _ := p.Scan(); tok3 == scanner.LPAREN {
 					p.Unscan()
 					p.Unscan()
@@ -228,7 +289,7 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 
 		p.Unscan()
 
-		field, err := p.parsePath()
+		field, err := p.parseExprPath()
 		if err != nil {
 			return nil, err
 		}
@@ -288,6 +349,32 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 			return nil, errors.WithStack(&ParseError{Message: "unable to parse integer", Pos: pos})
 		}
 		return expr.LiteralValue{Value: types.NewIntegerValue(v)}, nil
+	case scanner.TYPETIMESTAMP, scanner.TYPEDATE:
+		// typed literal: TIMESTAMP '2006-01-02 15:04:05' or DATE '2006-01-02'
+		tp := tok
+		tok, pos, lit = p.ScanIgnoreWhitespace()
+		if tok != scanner.STRING {
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"string"}, pos)
+		}
+		t, err := document.ParseTimestamp(lit)
+		if err != nil {
+			return nil, errors.WithStack(&ParseError{Message: err.Error(), Pos: pos})
+		}
+		if tp == scanner.TYPEDATE {
+			t = document.TruncateToDate(t)
+		}
+		return expr.LiteralValue{Value: types.NewTimestampValue(t)}, nil
+	case scanner.TYPEDECIMAL, scanner.TYPENUMERIC:
+		// typed literal: DECIMAL '10.50'
+		tok, pos, lit = p.ScanIgnoreWhitespace()
+		if tok != scanner.STRING {
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"string"}, pos)
+		}
+		d, err := types.ParseDecimal(lit)
+		if err != nil {
+			return nil, errors.WithStack(&ParseError{Message: err.Error(), Pos: pos})
+		}
+		return expr.LiteralValue{Value: types.NewDecimalValue(d)}, nil
 	case scanner.TRUE, scanner.FALSE:
 		return expr.LiteralValue{Value: types.NewBoolValue(tok == scanner.TRUE)}, nil
 	case scanner.NULL:
@@ -300,6 +387,13 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 		p.Unscan()
 		return p.parseExprList(scanner.LSBRACKET, scanner.RSBRACKET)
 	case scanner.LPAREN:
+		// check if this is a subquery
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.SELECT {
+			p.Unscan()
+			return p.parseSubquery(statement.ScalarSubquery)
+		}
+		p.Unscan()
+
 		e, err := p.ParseExpr()
 		if err != nil {
 			return nil, err
@@ -321,6 +415,11 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 		}
 
 		return nil, newParseError(scanner.Tokstr(tok, lit), []string{")", ","}, pos)
+	case scanner.EXISTS:
+		if err := p.parseTokens(scanner.LPAREN); err != nil {
+			return nil, err
+		}
+		return p.parseSubquery(statement.ExistsSubquery)
 	case scanner.NOT:
 		e, err := p.ParseExpr()
 		if err != nil {
@@ -343,6 +442,34 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 	}
 }
 
+// parseSubquery parses a SELECT statement followed by a closing parenthesis.
+// This function assumes the opening parenthesis has already been consumed.
+func (p *Parser) parseSubquery(kind statement.SubqueryKind) (*statement.Subquery, error) {
+	stmt, err := p.parseSelectStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return nil, err
+	}
+
+	return &statement.Subquery{Stmt: stmt, Kind: kind}, nil
+}
+
+// inOperator returns a function that creates an IN or NOT IN operator.
+// If the right-hand side is a subquery, the operator compares the left-hand side
+// with every value returned by it.
+func inOperator(fn func(a, b expr.Expr) expr.Expr) func(a, b expr.Expr) expr.Expr {
+	return func(a, b expr.Expr) expr.Expr {
+		if sq, ok := b.(*statement.Subquery); ok && sq.Kind == statement.ScalarSubquery {
+			sq.Kind = statement.ListSubquery
+		}
+
+		return fn(a, b)
+	}
+}
+
 // parseInteger parses an integer.
 func (p *Parser) parseInteger() (int64, error) {
 	tok, pos, lit := p.ScanIgnoreWhitespace()
@@ -425,6 +552,14 @@ func (p *Parser) parseParam() (expr.Expr, error) {
 	}
 }
 
+// peekDate reports whether the next token is the DATE type.
+// DATE is parsed as TIMESTAMP by parseType.
+func (p *Parser) peekDate() bool {
+	tok, _, _ := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	return tok == scanner.TYPEDATE
+}
+
 func (p *Parser) parseType() (types.ValueType, error) {
 	tok, pos, lit := p.ScanIgnoreWhitespace()
 	switch tok {
@@ -447,11 +582,15 @@ func (p *Parser) parseType() (types.ValueType, error) {
 		}
 		p.Unscan()
 		return types.DoubleValue, nil
+	case scanner.TYPEDECIMAL, scanner.TYPENUMERIC:
+		return types.DecimalValue, nil
 	case scanner.TYPEINTEGER, scanner.TYPEINT, scanner.TYPEINT2, scanner.TYPEINT8, scanner.TYPETINYINT,
 		scanner.TYPEBIGINT, scanner.TYPEMEDIUMINT, scanner.TYPESMALLINT:
 		return types.IntegerValue, nil
 	case scanner.TYPETEXT:
 		return types.TextValue, nil
+	case scanner.TYPETIMESTAMP, scanner.TYPEDATE:
+		return types.TimestampValue, nil
 	case scanner.TYPEVARCHAR, scanner.TYPECHARACTER:
 		if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != scanner.LPAREN {
 			return 0, newParseError(scanner.Tokstr(tok, lit), []string{"("}, pos)
@@ -472,6 +611,44 @@ func (p *Parser) parseType() (types.ValueType, error) {
 	return 0, newParseError(scanner.Tokstr(tok, lit), []string{"type"}, pos)
 }
 
+// parseDecimalParams parses the optional precision and scale
+// of a DECIMAL type: [(precision [, scale])].
+// If they are omitted, the precision is zero, meaning the decimal is not constrained.
+func (p *Parser) parseDecimalParams() (precision int, scale int, err error) {
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.LPAREN {
+		p.Unscan()
+		return 0, 0, nil
+	}
+
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok != scanner.INTEGER {
+		return 0, 0, newParseError(scanner.Tokstr(tok, lit), []string{"integer"}, pos)
+	}
+	precision, err = strconv.Atoi(lit)
+	if err != nil || precision < 1 || precision > types.MaxDecimalDigits {
+		return 0, 0, errors.WithStack(&ParseError{Message: fmt.Sprintf("DECIMAL precision must be between 1 and %d", types.MaxDecimalDigits), Pos: pos})
+	}
+
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.COMMA {
+		tok, pos, lit = p.ScanIgnoreWhitespace()
+		if tok != scanner.INTEGER {
+			return 0, 0, newParseError(scanner.Tokstr(tok, lit), []string{"integer"}, pos)
+		}
+		scale, err = strconv.Atoi(lit)
+		if err != nil || scale > precision {
+			return 0, 0, errors.WithStack(&ParseError{Message: "DECIMAL scale must be between 0 and the precision", Pos: pos})
+		}
+	} else {
+		p.Unscan()
+	}
+
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return 0, 0, err
+	}
+
+	return precision, scale, nil
+}
+
 // ParseDocument parses a document
 func (p *Parser) ParseDocument() (*expr.KVPairs, error) {
 	// Parse { token.
@@ -536,6 +713,16 @@ func (p *Parser) parseKV() (expr.KVPair, error) {
 
 // parsePath parses a path to a specific value.
 func (p *Parser) parsePath() (document.Path, error) {
+	return p.parsePathFragments(false)
+}
+
+// parseExprPath parses a path used within an expression,
+// which may end with [*] to refer to every element of an array.
+func (p *Parser) parseExprPath() (document.Path, error) {
+	return p.parsePathFragments(true)
+}
+
+func (p *Parser) parsePathFragments(allowAnyIndex bool) (document.Path, error) {
 	var path document.Path
 	// parse first mandatory ident
 	chunk, err := p.parseIdent()
@@ -589,6 +776,19 @@ LOOP:
 				path = append(path, document.PathFragment{
 					FieldName: lit,
 				})
+			case scanner.MUL:
+				if !allowAnyIndex {
+					return nil, newParseError(scanner.Tokstr(tok, lit), []string{"integer", "string"}, pos)
+				}
+				// [*] refers to every element of the array
+				// and must be the last fragment of the path
+				path = append(path, document.PathFragment{
+					ArrayIndex: document.AnyIndex,
+				})
+				if err := p.parseTokens(scanner.RSBRACKET); err != nil {
+					return nil, err
+				}
+				break LOOP
 			}
 			// scan the next token for a closing left bracket
 			if err := p.parseTokens(scanner.RSBRACKET); err != nil {
@@ -654,7 +854,7 @@ func (p *Parser) parseFunction() (expr.Expr, error) {
 	var pkgName string
 	if tok, _, _ := p.Scan(); tok == scanner.DOT {
 		pkgName = funcName
-		funcName, err = p.parseIdent()
+		funcName, err = p.parsePackageFunctionName()
 		if err != nil {
 			return nil, err
 		}
@@ -673,7 +873,7 @@ func (p *Parser) parseFunction() (expr.Expr, error) {
 			return nil, newParseError(scanner.Tokstr(tok, lit), []string{")"}, pos)
 		}
 
-		return &functions.Count{Wildcard: true}, nil
+		return p.parseOver(&functions.Count{Wildcard: true})
 	}
 	p.Unscan()
 
@@ -683,7 +883,11 @@ func (p *Parser) parseFunction() (expr.Expr, error) {
 		if err != nil {
 			return nil, err
 		}
-		return def.Function()
+		fn, err := def.Function()
+		if err != nil {
+			return nil, err
+		}
+		return p.parseOver(fn)
 	}
 	p.Unscan()
 
@@ -713,7 +917,97 @@ func (p *Parser) parseFunction() (expr.Expr, error) {
 	if err != nil {
 		return nil, err
 	}
-	return def.Function(exprs...)
+	fn, err := def.Function(exprs...)
+	if err != nil {
+		return nil, err
+	}
+	return p.parseOver(fn)
+}
+
+// parsePackageFunctionName parses the name of a function following its package name.
+// Since the name is qualified, keywords are allowed, e.g. strings.replace.
+func (p *Parser) parsePackageFunctionName() (string, error) {
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok.IsKeyword() {
+		return strings.ToLower(tok.String()), nil
+	}
+	if tok != scanner.IDENT {
+		return "", newParseError(scanner.Tokstr(tok, lit), []string{"identifier"}, pos)
+	}
+
+	return lit, nil
+}
+
+// parseOver parses the optional OVER clause following a function call.
+//   fn OVER ([PARTITION BY expr [, expr...]] [ORDER BY expr [ASC|DESC]])
+// Window functions must be followed by an OVER clause, other functions
+// can only be used with it if they are aggregate functions.
+func (p *Parser) parseOver(fn expr.Function) (expr.Expr, error) {
+	_, isWindowFn := fn.(functions.WindowFunction)
+
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.OVER {
+		p.Unscan()
+		if isWindowFn {
+			return nil, fmt.Errorf("%s requires an OVER clause", fn)
+		}
+		return fn, nil
+	}
+
+	if _, ok := fn.(expr.AggregatorBuilder); !ok && !isWindowFn {
+		return nil, fmt.Errorf("%s is not a window function", fn)
+	}
+
+	// Parse required ( token.
+	if err := p.parseTokens(scanner.LPAREN); err != nil {
+		return nil, err
+	}
+
+	w := functions.Window{Fn: fn}
+
+	// Parse optional PARTITION BY expr [, expr...]
+	ok, err := p.parseOptional(scanner.PARTITION, scanner.BY)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		for {
+			e, err := p.ParseExpr()
+			if err != nil {
+				return nil, err
+			}
+			w.PartitionBy = append(w.PartitionBy, e)
+
+			if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+				p.Unscan()
+				break
+			}
+		}
+	}
+
+	// Parse optional ORDER BY expr [ASC|DESC]
+	ok, err = p.parseOptional(scanner.ORDER, scanner.BY)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		w.OrderBy, err = p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.DESC {
+			w.Desc = true
+		} else if tok != scanner.ASC {
+			p.Unscan()
+		}
+	}
+
+	// Parse required ) token.
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return nil, err
+	}
+
+	return &w, nil
 }
 
 // parseCastExpression parses a string of the form CAST(expr AS type).
@@ -735,17 +1029,95 @@ func (p *Parser) parseCastExpression() (expr.Expr, error) {
 	}
 
 	// Parse required typename.
+	isDate := p.peekDate()
 	tp, err := p.parseType()
 	if err != nil {
 		return nil, err
 	}
 
+	c := expr.Cast{Expr: e, CastAs: tp, Date: isDate}
+	if tp == types.DecimalValue {
+		c.Precision, c.Scale, err = p.parseDecimalParams()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Parse required ) token.
 	if err := p.parseTokens(scanner.RPAREN); err != nil {
 		return nil, err
 	}
 
-	return expr.Cast{Expr: e, CastAs: tp}, nil
+	return c, nil
+}
+
+// parseCaseExpression parses a string of the form
+// CASE [expr] WHEN expr THEN expr [WHEN expr THEN expr]* [ELSE expr] END.
+func (p *Parser) parseCaseExpression() (expr.Expr, error) {
+	// Parse required CASE token.
+	if err := p.parseTokens(scanner.CASE); err != nil {
+		return nil, err
+	}
+
+	var c expr.Case
+	var err error
+
+	// Parse optional operand.
+	tok, _, _ := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	if tok != scanner.WHEN && tok != scanner.END {
+		c.Operand, err = p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse one or more WHEN clauses.
+	for {
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.WHEN {
+			p.Unscan()
+			break
+		}
+
+		var w expr.When
+		w.Cond, err = p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.parseTokens(scanner.THEN); err != nil {
+			return nil, err
+		}
+
+		w.Then, err = p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		c.Whens = append(c.Whens, &w)
+	}
+
+	if len(c.Whens) == 0 {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"WHEN"}, pos)
+	}
+
+	// Parse optional ELSE clause.
+	if ok, err := p.parseOptional(scanner.ELSE); err != nil {
+		return nil, err
+	} else if ok {
+		c.Else, err = p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse required END token.
+	if err := p.parseTokens(scanner.END); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
 }
 
 // tokenIsAllowed is a helper function that determines if a token is allowed.
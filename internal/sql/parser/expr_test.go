@@ -1,12 +1,15 @@
 package parser_test
 
 import (
+	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
@@ -41,6 +44,20 @@ func TestParserExpr(t *testing.T) {
 		{"blob as hex string", `'\xff'`, testutil.BlobValue([]byte{255}), false},
 		{"invalid blob hex string", `'\xzz'`, nil, true},
 
+		// timestamps
+		{"timestamp", `TIMESTAMP '2023-03-15 10:20:30.5'`, testutil.TimestampValue(time.Date(2023, 3, 15, 10, 20, 30, 5e8, time.UTC)), false},
+		{"timestamp rfc3339", `TIMESTAMP '2023-03-15T10:20:30+02:00'`, testutil.TimestampValue(time.Date(2023, 3, 15, 8, 20, 30, 0, time.UTC)), false},
+		{"date", `DATE '2023-03-15'`, testutil.TimestampValue(time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)), false},
+		{"date with time", `DATE '2023-03-15 10:20:30'`, testutil.TimestampValue(time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)), false},
+		{"invalid timestamp", `TIMESTAMP 'foo'`, nil, true},
+		{"timestamp without string", `TIMESTAMP 10`, nil, true},
+
+		// decimals
+		{"decimal", `DECIMAL '10.50'`, expr.LiteralValue{Value: types.NewDecimalValue(types.NewDecimal(big.NewInt(1050), 2))}, false},
+		{"numeric", `NUMERIC '-1.5e2'`, expr.LiteralValue{Value: types.NewDecimalValue(types.NewDecimalFromInt(-150))}, false},
+		{"invalid decimal", `DECIMAL 'foo'`, nil, true},
+		{"decimal without string", `DECIMAL 10`, nil, true},
+
 		// documents
 		{"empty document", `{}`, &expr.KVPairs{SelfReferenced: true}, false},
 		{"document values", `{a: 1, b: 1.0, c: true, d: 'string', e: "string", f: {foo: 'bar'}, g: h.i.j, k: [1, 2, 3]}`,
@@ -130,6 +147,8 @@ func TestParserExpr(t *testing.T) {
 		{"IS NOT", "age IS NOT NULL", expr.IsNot(testutil.ParsePath(t, "age"), testutil.NullValue()), false},
 		{"LIKE", "name LIKE 'foo'", expr.Like(testutil.ParsePath(t, "name"), testutil.TextValue("foo")), false},
 		{"NOT LIKE", "name NOT LIKE 'foo'", expr.NotLike(testutil.ParsePath(t, "name"), testutil.TextValue("foo")), false},
+		{"=~", "name =~ '^fo+'", expr.Regex(testutil.ParsePath(t, "name"), testutil.TextValue("^fo+")), false},
+		{"!~", "name !~ '^fo+'", expr.NotRegex(testutil.ParsePath(t, "name"), testutil.TextValue("^fo+")), false},
 		{"NOT =", "name NOT = 'foo'", nil, true},
 		{"precedence", "4 > 1 + 2", expr.Gt(
 			testutil.IntegerValue(4),
@@ -160,19 +179,88 @@ func TestParserExpr(t *testing.T) {
 
 		// unary operators
 		{"CAST", "CAST(a.b[1][0] AS TEXT)", expr.Cast{Expr: testutil.ParsePath(t, "a.b[1][0]"), CastAs: types.TextValue}, false},
+		{"CAST timestamp", "CAST(a AS TIMESTAMP)", expr.Cast{Expr: testutil.ParsePath(t, "a"), CastAs: types.TimestampValue}, false},
+		{"CAST date", "CAST(a AS DATE)", expr.Cast{Expr: testutil.ParsePath(t, "a"), CastAs: types.TimestampValue, Date: true}, false},
+		{"CAST decimal", "CAST(a AS DECIMAL)", expr.Cast{Expr: testutil.ParsePath(t, "a"), CastAs: types.DecimalValue}, false},
+		{"CAST decimal with precision", "CAST(a AS NUMERIC(10))", expr.Cast{Expr: testutil.ParsePath(t, "a"), CastAs: types.DecimalValue, Precision: 10}, false},
+		{"CAST decimal with precision and scale", "CAST(a AS DECIMAL(10, 2))", expr.Cast{Expr: testutil.ParsePath(t, "a"), CastAs: types.DecimalValue, Precision: 10, Scale: 2}, false},
+		{"CAST decimal with invalid scale", "CAST(a AS DECIMAL(2, 3))", nil, true},
+		{"CAST decimal with zero precision", "CAST(a AS DECIMAL(0))", nil, true},
+		{"CAST decimal with too large precision", "CAST(a AS DECIMAL(1001))", nil, true},
 		{"NOT", "NOT 10", expr.Not(testutil.IntegerValue(10)), false},
 		{"NOT", "NOT NOT", nil, true},
 		{"NOT", "NOT NOT 10", expr.Not(expr.Not(testutil.IntegerValue(10))), false},
 		{"NEXT VALUE FOR", "NEXT VALUE FOR hello", expr.NextValueFor{SeqName: "hello"}, false},
 		{"NEXT VALUE FOR", "NEXT VALUE FOR `good morning`", expr.NextValueFor{SeqName: "good morning"}, false},
 		{"NEXT VALUE FOR", "NEXT VALUE FOR 10", nil, true},
+		{"CASE", "CASE WHEN a > 1 THEN 'big' ELSE 'small' END",
+			&expr.Case{
+				Whens: []*expr.When{{Cond: expr.Gt(testutil.ParsePath(t, "a"), testutil.IntegerValue(1)), Then: testutil.TextValue("big")}},
+				Else:  testutil.TextValue("small"),
+			}, false},
+		{"CASE with operand", "CASE a + 1 WHEN 1 THEN 'one' WHEN 2 THEN 'two' END",
+			&expr.Case{
+				Operand: expr.Add(testutil.ParsePath(t, "a"), testutil.IntegerValue(1)),
+				Whens: []*expr.When{
+					{Cond: testutil.IntegerValue(1), Then: testutil.TextValue("one")},
+					{Cond: testutil.IntegerValue(2), Then: testutil.TextValue("two")},
+				},
+			}, false},
+		{"CASE without WHEN", "CASE a ELSE 1 END", nil, true},
+		{"CASE without END", "CASE WHEN a THEN 1", nil, true},
 
 		// functions
 		{"pk() function", "pk()", &functions.PK{}, false},
 		{"count(expr) function", "count(a)", &functions.Count{Expr: testutil.ParsePath(t, "a")}, false},
 		{"count(*) function", "count(*)", &functions.Count{Wildcard: true}, false},
 		{"count (*) function with spaces", "count      (*)", &functions.Count{Wildcard: true}, false},
+		{"coalesce(a, b, c) function", "coalesce(a, b, 1)", &functions.Coalesce{Exprs: []expr.Expr{testutil.ParsePath(t, "a"), testutil.ParsePath(t, "b"), testutil.IntegerValue(1)}}, false},
+		{"coalesce() function without arguments", "coalesce()", nil, true},
+		{"nullif(a, b) function", "nullif(a, 1)", &functions.NullIf{Expr: testutil.ParsePath(t, "a"), Other: testutil.IntegerValue(1)}, false},
+		{"ifnull(a, b) function", "ifnull(a, 1)", &functions.IfNull{Expr: testutil.ParsePath(t, "a"), Default: testutil.IntegerValue(1)}, false},
 		{"packaged function", "math.floor(1.2)", testutil.FunctionExpr(t, "math.floor", testutil.DoubleValue(1.2)), false},
+		{"packaged function named after a keyword", "strings.replace(a, 'b', 'c')", testutil.FunctionExpr(t, "strings.replace", testutil.ParsePath(t, "a"), testutil.TextValue("b"), testutil.TextValue("c")), false},
+
+		// window functions
+		{"window function", "row_number() OVER ()", &functions.Window{Fn: &functions.RowNumber{}}, false},
+		{"window function with partition and order", "rank() OVER (PARTITION BY a, b ORDER BY c DESC)",
+			&functions.Window{
+				Fn:          &functions.Rank{},
+				PartitionBy: []expr.Expr{testutil.ParsePath(t, "a"), testutil.ParsePath(t, "b")},
+				OrderBy:     testutil.ParsePath(t, "c"),
+				Desc:        true,
+			}, false},
+		{"window aggregate", "SUM(a) OVER (ORDER BY b ASC)", &functions.Window{Fn: &functions.Sum{Expr: testutil.ParsePath(t, "a")}, OrderBy: testutil.ParsePath(t, "b")}, false},
+		{"window count(*)", "COUNT(*) OVER (PARTITION BY a)", &functions.Window{Fn: &functions.Count{Wildcard: true}, PartitionBy: []expr.Expr{testutil.ParsePath(t, "a")}}, false},
+		{"lag with offset and default", "lag(a, 2, 0) OVER (ORDER BY b)",
+			&functions.Window{Fn: &functions.Lag{Expr: testutil.ParsePath(t, "a"), Offset: testutil.IntegerValue(2), Default: testutil.IntegerValue(0)}, OrderBy: testutil.ParsePath(t, "b")}, false},
+		{"lag with too many arguments", "lag(a, 1, 0, 1) OVER ()", nil, true},
+		{"window function without OVER", "row_number()", nil, true},
+		{"OVER on a scalar function", "typeof(a) OVER ()", nil, true},
+		{"unclosed OVER", "rank() OVER (ORDER BY a", nil, true},
+		// any element of an array
+		{"any element comparison", "a[*] = 1", expr.Eq(anyElement("a"), testutil.IntegerValue(1)), false},
+		{"any element IN", "a[*] IN [1, 2]", expr.In(anyElement("a"), expr.LiteralExprList{testutil.IntegerValue(1), testutil.IntegerValue(2)}), false},
+		{"any element BETWEEN", "a[*] BETWEEN 1 AND 2", expr.Between(testutil.IntegerValue(1))(anyElement("a"), testutil.IntegerValue(2)), false},
+		{"any element in boolean expression", "a[*] > 1 AND b[*] < 2",
+			expr.And(expr.Gt(anyElement("a"), testutil.IntegerValue(1)), expr.Lt(anyElement("b"), testutil.IntegerValue(2))), false},
+		{"any element alone", "a[*]", nil, true},
+		{"any element with IS", "a[*] IS NULL", nil, true},
+		{"any element with LIKE", "a[*] LIKE 'foo%'", nil, true},
+		{"any element in arithmetic", "a[*] + 1 = 2", nil, true},
+		{"any element as BETWEEN bound", "a BETWEEN b[*] AND 2", nil, true},
+		{"any element in function", "typeof(a[*]) = 'integer'", nil, true},
+		{"any element in parentheses", "(a[*]) = 1", nil, true},
+		// subqueries
+		{"scalar subquery", "(SELECT a FROM foo)", &statement.Subquery{Stmt: parseSelect(t, "SELECT a FROM foo"), Kind: statement.ScalarSubquery}, false},
+		{"IN subquery", "a IN (SELECT a FROM foo)",
+			expr.In(testutil.ParsePath(t, "a"), &statement.Subquery{Stmt: parseSelect(t, "SELECT a FROM foo"), Kind: statement.ListSubquery}), false},
+		{"NOT IN subquery", "a NOT IN (SELECT a FROM foo UNION ALL SELECT b FROM bar)",
+			expr.NotIn(testutil.ParsePath(t, "a"), &statement.Subquery{Stmt: parseSelect(t, "SELECT a FROM foo UNION ALL SELECT b FROM bar"), Kind: statement.ListSubquery}), false},
+		{"EXISTS", "EXISTS (SELECT * FROM foo WHERE a > 1)", &statement.Subquery{Stmt: parseSelect(t, "SELECT * FROM foo WHERE a > 1"), Kind: statement.ExistsSubquery}, false},
+		{"NOT EXISTS", "NOT EXISTS (SELECT * FROM foo)", expr.Not(&statement.Subquery{Stmt: parseSelect(t, "SELECT * FROM foo"), Kind: statement.ExistsSubquery}), false},
+		{"EXISTS without parentheses", "EXISTS SELECT * FROM foo", nil, true},
+		{"unclosed subquery", "(SELECT * FROM foo", nil, true},
 	}
 
 	for _, test := range tests {
@@ -190,6 +278,20 @@ func TestParserExpr(t *testing.T) {
 	}
 }
 
+func anyElement(name string) expr.Path {
+	return expr.Path{document.PathFragment{FieldName: name}, document.PathFragment{ArrayIndex: document.AnyIndex}}
+}
+
+func parseSelect(t testing.TB, s string) *statement.SelectStmt {
+	t.Helper()
+
+	q, err := parser.ParseQuery(s)
+	assert.NoError(t, err)
+	require.Len(t, q.Statements, 1)
+
+	return q.Statements[0].(*statement.SelectStmt)
+}
+
 func TestParsePath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -226,6 +328,7 @@ func TestParsePath(t *testing.T) {
 			document.PathFragment{FieldName: "  \"quotes"},
 		}, false},
 
+		{"any element", `a.b[*]`, nil, true},
 		{"negative index", `a.b[-100].c`, nil, true},
 		{"with spaces", `a.  b[100].  c`, nil, true},
 		{"starting with array", `[10].a`, nil, true},
@@ -54,7 +54,7 @@ func (p *Parser) parseInsertStatement() (*statement.InsertStmt, error) {
 	}
 
 	// Parse ON CONFLICT clause
-	stmt.OnConflict, err = p.parseOnConflictClause()
+	err = p.parseOnConflictClause(stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -215,34 +215,64 @@ func (p *Parser) parseParamOrDocument() (expr.Expr, error) {
 	return p.ParseDocument()
 }
 
-func (p *Parser) parseOnConflictClause() (database.OnConflictAction, error) {
-	// Parse ON CONFLICT DO clause: ON CONFLICT DO action
+func (p *Parser) parseOnConflictClause(stmt *statement.InsertStmt) error {
+	// Parse ON CONFLICT DO clause: ON CONFLICT [(path, ...)] DO action
 	if ok, err := p.parseOptional(scanner.ON, scanner.CONFLICT); !ok || err != nil {
-		return 0, err
+		return err
 	}
 
 	tok, pos, lit := p.ScanIgnoreWhitespace()
 	// SQLite compatibility: ON CONFLICT [IGNORE | REPLACE]
 	switch tok {
 	case scanner.IGNORE:
-		return database.OnConflictDoNothing, nil
+		stmt.OnConflict = database.OnConflictDoNothing
+		return nil
 	case scanner.REPLACE:
-		return database.OnConflictDoReplace, nil
+		stmt.OnConflict = database.OnConflictDoReplace
+		return nil
 	}
+	p.Unscan()
 
-	// DO [NOTHING | REPLACE]
+	// Parse optional conflict target
+	var err error
+	stmt.OnConflictPaths, err = p.parsePathList()
+	if err != nil {
+		return err
+	}
+
+	// DO [NOTHING | REPLACE | UPDATE]
+	tok, pos, lit = p.ScanIgnoreWhitespace()
 	if tok != scanner.DO {
-		return 0, newParseError(scanner.Tokstr(tok, lit), []string{scanner.DO.String()}, pos)
+		return newParseError(scanner.Tokstr(tok, lit), []string{scanner.DO.String()}, pos)
 	}
 
 	tok, pos, lit = p.ScanIgnoreWhitespace()
 	switch tok {
 	case scanner.NOTHING:
-		return database.OnConflictDoNothing, nil
+		stmt.OnConflict = database.OnConflictDoNothing
+		return nil
 	case scanner.REPLACE:
-		return database.OnConflictDoReplace, nil
+		stmt.OnConflict = database.OnConflictDoReplace
+		return nil
+	case scanner.UPDATE:
+		stmt.OnConflict = database.OnConflictDoUpdate
+	default:
+		return newParseError(scanner.Tokstr(tok, lit), []string{scanner.NOTHING.String(), scanner.REPLACE.String(), scanner.UPDATE.String()}, pos)
 	}
-	return 0, newParseError(scanner.Tokstr(tok, lit), []string{scanner.NOTHING.String(), scanner.REPLACE.String()}, pos)
+
+	// Parse DO UPDATE SET clause
+	if err := p.parseTokens(scanner.SET); err != nil {
+		return err
+	}
+
+	stmt.OnConflictSetPairs, err = p.parseSetClause()
+	if err != nil {
+		return err
+	}
+
+	// Parse condition: "WHERE EXPR".
+	stmt.OnConflictWhere, err = p.parseCondition()
+	return err
 }
 
 func (p *Parser) parseReturning() ([]expr.Expr, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query"
 	"github.com/genjidb/genji/internal/query/statement"
@@ -160,6 +161,28 @@ func TestParserInsert(t *testing.T) {
 			nil, true},
 		{"Values / ON CONFLICT DO BLA", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT DO BLA RETURNING *",
 			nil, true},
+		{"Values / ON CONFLICT DO UPDATE", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT DO UPDATE SET b = EXCLUDED.b WHERE a > 1",
+			stream.New(docs.Emit(
+				&expr.KVPairs{Pairs: []expr.KVPair{
+					{K: "a", V: testutil.TextValue("c")},
+					{K: "b", V: testutil.TextValue("d")},
+				}},
+			)).
+				Pipe(table.Validate("test")).
+				Pipe(stream.OnConflict(stream.New(table.Lookup("test")).
+					Pipe(docs.Filter(parser.MustParseExpr("a > 1"))).
+					Pipe(path.Set(document.NewPath("b"), parser.MustParseExpr("EXCLUDED.b"))).
+					Pipe(table.Validate("test")).
+					Pipe(table.Replace("test")))).
+				Pipe(table.Insert("test")).
+				Pipe(stream.Discard()),
+			false},
+		{"Values / ON CONFLICT DO UPDATE / missing SET", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT DO UPDATE b = 1",
+			nil, true},
+		{"Values / ON CONFLICT with paths / IGNORE", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT (a) IGNORE",
+			nil, true},
+		{"Values / ON CONFLICT with paths / missing DO", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT (a) NOTHING",
+			nil, true},
 		{"Select / Without fields", "INSERT INTO test SELECT * FROM foo",
 			stream.New(table.Scan("foo")).
 				Pipe(table.Validate("test")).
@@ -1,9 +1,11 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
@@ -20,6 +22,9 @@ type Parser struct {
 	orderedParams int
 	namedParams   int
 	packagesTable functions.Packages
+
+	// text read by the scanner so far
+	src bytes.Buffer
 }
 
 // NewParser returns a new instance of Parser.
@@ -33,7 +38,9 @@ func NewParserWithOptions(r io.Reader, opts *Options) *Parser {
 		opts = defaultOptions()
 	}
 
-	return &Parser{s: scanner.NewScanner(r), packagesTable: opts.Packages}
+	p := Parser{packagesTable: opts.Packages}
+	p.s = scanner.NewScanner(io.TeeReader(r, &p.src))
+	return &p
 }
 
 // ParseQuery parses a query string and returns its AST representation.
@@ -118,6 +125,8 @@ func (p *Parser) ParseStatement() (statement.Statement, error) {
 		return p.parseCommitStatement()
 	case scanner.SELECT:
 		return p.parseSelectStatement()
+	case scanner.WITH:
+		return p.parseWithStatement()
 	case scanner.DELETE:
 		return p.parseDeleteStatement()
 	case scanner.UPDATE:
@@ -137,7 +146,7 @@ func (p *Parser) ParseStatement() (statement.Statement, error) {
 	}
 
 	return nil, newParseError(scanner.Tokstr(tok, lit), []string{
-		"ALTER", "BEGIN", "COMMIT", "SELECT", "DELETE", "UPDATE", "INSERT", "CREATE", "DROP", "EXPLAIN", "REINDEX", "ROLLBACK",
+		"ALTER", "BEGIN", "COMMIT", "SELECT", "WITH", "DELETE", "UPDATE", "INSERT", "CREATE", "DROP", "EXPLAIN", "REINDEX", "ROLLBACK",
 	}, pos)
 }
 
@@ -216,6 +225,39 @@ func (p *Parser) Unscan() {
 	p.s.Unscan()
 }
 
+// sourceText returns the text of the query between the start and end positions.
+func (p *Parser) sourceText(start, end scanner.Pos) string {
+	src := p.src.Bytes()
+
+	// convert positions into offsets, counting lines and characters
+	// the same way the scanner does
+	var pos scanner.Pos
+	offset := func(to scanner.Pos) int {
+		i := 0
+		for i < len(src) && pos != to {
+			ch, size := utf8.DecodeRune(src[i:])
+			i += size
+			switch ch {
+			case '\r':
+				if i < len(src) && src[i] == '\n' {
+					i++
+				}
+				fallthrough
+			case '\n':
+				pos.Line++
+				pos.Char = 0
+			default:
+				pos.Char++
+			}
+		}
+
+		return i
+	}
+
+	src = src[offset(start):]
+	return strings.TrimSpace(string(src[:offset(end)]))
+}
+
 // parseTokens parses all the given tokens one after the other.
 // It returns an error if one of the token is missing.
 func (p *Parser) parseTokens(tokens ...scanner.Token) error {
@@ -3,6 +3,7 @@ package parser
 import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/scanner"
 )
@@ -12,7 +13,7 @@ import (
 func (p *Parser) parseSelectStatement() (*statement.SelectStmt, error) {
 	stmt := statement.NewSelectStatement()
 
-	// Parse SELECT ... [UNION | UNION ALL | INTERSECT] SELECT ...
+	// Parse SELECT ... [UNION | INTERSECT | EXCEPT] [ALL] SELECT ...
 	err := p.parseCompoundSelectStatement(stmt)
 	if err != nil {
 		return nil, err
@@ -39,6 +40,66 @@ func (p *Parser) parseSelectStatement() (*statement.SelectStmt, error) {
 	return stmt, nil
 }
 
+// parseWithStatement parses a SELECT statement preceded by a list of common table expressions:
+// "WITH [RECURSIVE] name AS (select_stmt) [, name AS (select_stmt)]* select_stmt".
+func (p *Parser) parseWithStatement() (*statement.SelectStmt, error) {
+	if err := p.parseTokens(scanner.WITH); err != nil {
+		return nil, err
+	}
+
+	recursive, err := p.parseOptional(scanner.RECURSIVE)
+	if err != nil {
+		return nil, err
+	}
+
+	var ctes []*statement.CommonTableExpr
+	for {
+		var cte statement.CommonTableExpr
+		cte.Recursive = recursive
+
+		cte.Name, err = p.parseIdent()
+		if err != nil {
+			pErr := errors.Unwrap(err).(*ParseError)
+			pErr.Expected = []string{"table_name"}
+			return nil, pErr
+		}
+
+		if err := p.parseTokens(scanner.AS, scanner.LPAREN); err != nil {
+			return nil, err
+		}
+
+		cte.Stmt, err = p.parseSelectStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.parseTokens(scanner.RPAREN); err != nil {
+			return nil, err
+		}
+
+		ctes = append(ctes, &cte)
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
+	}
+
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	if tok != scanner.SELECT {
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"SELECT"}, pos)
+	}
+
+	stmt, err := p.parseSelectStatement()
+	if err != nil {
+		return nil, err
+	}
+	stmt.CTEs = ctes
+
+	return stmt, nil
+}
+
 func (p *Parser) parseCompoundSelectStatement(stmt *statement.SelectStmt) error {
 	for {
 		core, err := p.parseSelectCore()
@@ -46,26 +107,21 @@ func (p *Parser) parseCompoundSelectStatement(stmt *statement.SelectStmt) error
 			return err
 		}
 
-		// Parse optional compound operator
-		tok, _, _ := p.ScanIgnoreWhitespace()
-		if tok == scanner.UNION {
-			all, err := p.parseOptional(scanner.ALL)
-			if err != nil {
-				return err
-			}
-			if all {
-				tok = scanner.ALL
-			}
-		}
-
 		stmt.CompoundSelect = append(stmt.CompoundSelect, core)
 
-		if tok != scanner.UNION && tok != scanner.ALL {
+		// Parse optional compound operator
+		tok, _, _ := p.ScanIgnoreWhitespace()
+		if tok != scanner.UNION && tok != scanner.INTERSECT && tok != scanner.EXCEPT {
 			p.Unscan()
 			break
 		}
 
-		stmt.CompoundOperators = append(stmt.CompoundOperators, tok)
+		all, err := p.parseOptional(scanner.ALL)
+		if err != nil {
+			return err
+		}
+
+		stmt.CompoundOperators = append(stmt.CompoundOperators, statement.CompoundOperator{Tok: tok, All: all})
 	}
 
 	return nil
@@ -92,19 +148,41 @@ func (p *Parser) parseSelectCore() (*statement.SelectCoreStmt, error) {
 	}
 
 	// Parse "FROM".
-	stmt.TableName, err = p.parseFrom()
+	stmt.TableName, stmt.TableAlias, err = p.parseFrom()
 	if err != nil {
 		return nil, err
 	}
 
+	// Parse joins: "[INNER | LEFT [OUTER]] JOIN table [[AS] alias] ON expr"
+	// or "', UNNEST(expr) [AS] alias [WITH OFFSET [AS] alias]"
+	if stmt.TableName != "" {
+		stmt.Joins, err = p.parseJoins()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Parse condition: "WHERE expr".
 	stmt.WhereExpr, err = p.parseCondition()
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse group by: "GROUP BY expr"
-	stmt.GroupByExpr, err = p.parseGroupBy()
+	// Parse group by: "GROUP BY expr [, expr ...]"
+	_, pos, _ := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	stmt.GroupByExprs, err = p.parseGroupBy()
+	if err != nil {
+		return nil, err
+	}
+
+	// window functions are computed over documents, not over groups
+	if len(stmt.GroupByExprs) > 0 && hasWindow(stmt.ProjectionExprs) {
+		return nil, errors.WithStack(&ParseError{Message: "window functions cannot be used with GROUP BY", Pos: pos})
+	}
+
+	// Parse having: "HAVING expr"
+	stmt.HavingExpr, err = p.parseHaving()
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +190,22 @@ func (p *Parser) parseSelectCore() (*statement.SelectCoreStmt, error) {
 	return &stmt, nil
 }
 
+// hasWindow returns whether any of the expressions uses a window function.
+func hasWindow(exprs []expr.Expr) bool {
+	var found bool
+	for _, e := range exprs {
+		expr.Walk(e, func(e expr.Expr) bool {
+			_, found = e.(*functions.Window)
+			return !found
+		})
+		if found {
+			return true
+		}
+	}
+
+	return false
+}
+
 // parseProjectedExprs parses the list of projected fields.
 func (p *Parser) parseProjectedExprs() ([]expr.Expr, error) {
 	// Parse first (required) result path.
@@ -168,29 +262,185 @@ func (p *Parser) parseProjectedExpr() (expr.Expr, error) {
 	return ne, nil
 }
 
-func (p *Parser) parseFrom() (string, error) {
+func (p *Parser) parseFrom() (string, string, error) {
 	if ok, err := p.parseOptional(scanner.FROM); !ok || err != nil {
-		return "", err
+		return "", "", err
 	}
 
+	return p.parseTableNameWithAlias()
+}
+
+// parseTableNameWithAlias parses a table name followed by an optional alias:
+// "table [[AS] alias]".
+func (p *Parser) parseTableNameWithAlias() (string, string, error) {
 	// Parse table name
 	ident, err := p.parseIdent()
 	if err != nil {
 		pErr := errors.Unwrap(err).(*ParseError)
 		pErr.Expected = []string{"table_name"}
-		return ident, pErr
+		return ident, "", pErr
 	}
 
-	return ident, nil
+	// Parse optional alias
+	tok, _, lit := p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.AS:
+		alias, err := p.parseIdent()
+		if err != nil {
+			return "", "", err
+		}
+		return ident, alias, nil
+	case scanner.IDENT:
+		return ident, lit, nil
+	}
+	p.Unscan()
+
+	return ident, "", nil
 }
 
-func (p *Parser) parseGroupBy() (expr.Expr, error) {
+// parseJoins parses the list of join clauses following the FROM clause.
+func (p *Parser) parseJoins() ([]*statement.JoinClause, error) {
+	var joins []*statement.JoinClause
+
+	for {
+		tok, _, _ := p.ScanIgnoreWhitespace()
+
+		var j statement.JoinClause
+
+		switch tok {
+		case scanner.COMMA:
+			j, err := p.parseUnnest()
+			if err != nil {
+				return nil, err
+			}
+			joins = append(joins, j)
+			continue
+		case scanner.JOIN:
+			j.Type = scanner.INNER
+		case scanner.INNER:
+			if err := p.parseTokens(scanner.JOIN); err != nil {
+				return nil, err
+			}
+			j.Type = scanner.INNER
+		case scanner.LEFT:
+			if _, err := p.parseOptional(scanner.OUTER); err != nil {
+				return nil, err
+			}
+			if err := p.parseTokens(scanner.JOIN); err != nil {
+				return nil, err
+			}
+			j.Type = scanner.LEFT
+		default:
+			p.Unscan()
+			return joins, nil
+		}
+
+		var err error
+		j.TableName, j.Alias, err = p.parseTableNameWithAlias()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.parseTokens(scanner.ON); err != nil {
+			return nil, err
+		}
+
+		j.On, err = p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		joins = append(joins, &j)
+	}
+}
+
+// parseUnnest parses an UNNEST clause:
+// "UNNEST(expr) [AS] alias [WITH OFFSET [AS] alias]".
+// This function assumes the preceding comma has already been consumed.
+func (p *Parser) parseUnnest() (*statement.JoinClause, error) {
+	if err := p.parseTokens(scanner.UNNEST, scanner.LPAREN); err != nil {
+		return nil, err
+	}
+
+	var j statement.JoinClause
+	var err error
+
+	j.Type = scanner.INNER
+	j.Unnest, err = p.ParseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return nil, err
+	}
+
+	j.Alias, err = p.parseAlias()
+	if err != nil {
+		return nil, err
+	}
+
+	withOffset, err := p.parseOptional(scanner.WITH, scanner.OFFSET)
+	if err != nil || !withOffset {
+		return &j, err
+	}
+
+	j.OffsetAlias, err = p.parseAlias()
+	if err != nil {
+		return nil, err
+	}
+
+	return &j, nil
+}
+
+// parseAlias parses a mandatory alias: "[AS] alias".
+func (p *Parser) parseAlias() (string, error) {
+	if _, err := p.parseOptional(scanner.AS); err != nil {
+		return "", err
+	}
+
+	alias, err := p.parseIdent()
+	if err != nil {
+		pErr := errors.Unwrap(err).(*ParseError)
+		pErr.Expected = []string{"alias"}
+		return "", pErr
+	}
+
+	return alias, nil
+}
+
+func (p *Parser) parseGroupBy() ([]expr.Expr, error) {
 	ok, err := p.parseOptional(scanner.GROUP, scanner.BY)
 	if err != nil || !ok {
 		return nil, err
 	}
 
-	// parse expr
+	// parse the first expr
 	e, err := p.ParseExpr()
-	return e, err
+	if err != nil {
+		return nil, err
+	}
+	exprs := []expr.Expr{e}
+
+	// parse the remaining exprs, if any
+	for {
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			return exprs, nil
+		}
+
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+}
+
+func (p *Parser) parseHaving() (expr.Expr, error) {
+	if ok, err := p.parseOptional(scanner.HAVING); !ok || err != nil {
+		return nil, err
+	}
+
+	return p.ParseExpr()
 }
@@ -86,6 +86,35 @@ func TestParserSelect(t *testing.T) {
 				Pipe(docs.Project(&expr.NamedExpr{ExprName: "a.b.c", Expr: expr.Path(document.NewPath("a.b.c"))})),
 			true, false,
 		},
+		{"WithMultipleGroupBy", "SELECT a, b FROM test GROUP BY a, b",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSort(parser.MustParseExpr("[a, b]"))).
+				Pipe(docs.GroupAggregateTuple(expr.LiteralExprList{parser.MustParseExpr("a"), parser.MustParseExpr("b")})).
+				Pipe(docs.Project(
+					&expr.NamedExpr{ExprName: "a", Expr: expr.Path(document.NewPath("a"))},
+					&expr.NamedExpr{ExprName: "b", Expr: expr.Path(document.NewPath("b"))},
+				)),
+			true, false,
+		},
+		{"WithHaving", "SELECT a, COUNT(*) FROM test GROUP BY a HAVING COUNT(*) > 1",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSort(parser.MustParseExpr("a"))).
+				Pipe(docs.GroupAggregate(parser.MustParseExpr("a"), &functions.Count{Wildcard: true})).
+				Pipe(docs.Filter(parser.MustParseExpr("COUNT(*) > 1"))).
+				Pipe(docs.Project(
+					&expr.NamedExpr{ExprName: "a", Expr: expr.Path(document.NewPath("a"))},
+					testutil.ParseNamedExpr(t, "COUNT(*)"),
+				)),
+			true, false,
+		},
+		{"WithHavingAndNewAggregator", "SELECT a FROM test GROUP BY a HAVING MAX(b) > 1",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSort(parser.MustParseExpr("a"))).
+				Pipe(docs.GroupAggregate(parser.MustParseExpr("a"), parser.MustParseExpr("MAX(b)").(expr.AggregatorBuilder))).
+				Pipe(docs.Filter(parser.MustParseExpr("MAX(b) > 1"))).
+				Pipe(docs.Project(&expr.NamedExpr{ExprName: "a", Expr: expr.Path(document.NewPath("a"))})),
+			true, false,
+		},
 		{"WithOrderBy", "SELECT * FROM test WHERE age = 10 ORDER BY a.b.c",
 			stream.New(table.Scan("test")).
 				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
@@ -244,6 +273,51 @@ func TestParserSelect(t *testing.T) {
 			)).Pipe(docs.TempTreeSort(testutil.ParsePath(t, "a"))).Pipe(docs.Skip(parser.MustParseExpr("20"))).Pipe(docs.Take(parser.MustParseExpr("10"))),
 			true, false,
 		},
+		{"WithIntersect", "SELECT * FROM test1 INTERSECT SELECT * FROM test2",
+			stream.New(stream.Intersect(
+				stream.New(table.Scan("test1")),
+				stream.New(table.Scan("test2")),
+			)),
+			true, false,
+		},
+		{"WithIntersectAll", "SELECT * FROM test1 INTERSECT ALL SELECT * FROM test2",
+			stream.New(stream.IntersectAll(
+				stream.New(table.Scan("test1")),
+				stream.New(table.Scan("test2")),
+			)),
+			true, false,
+		},
+		{"WithExcept", "SELECT * FROM test1 EXCEPT SELECT * FROM test2",
+			stream.New(stream.Except(
+				stream.New(table.Scan("test1")),
+				stream.New(table.Scan("test2")),
+			)),
+			true, false,
+		},
+		{"WithExceptAllAndOrderBy", "SELECT * FROM test1 EXCEPT ALL SELECT * FROM test2 ORDER BY a",
+			stream.New(stream.ExceptAll(
+				stream.New(table.Scan("test1")),
+				stream.New(table.Scan("test2")),
+			)).Pipe(docs.TempTreeSort(testutil.ParsePath(t, "a"))),
+			true, false,
+		},
+		{"WithExceptAfterLimit", "SELECT * FROM test1 LIMIT 10 EXCEPT SELECT * FROM test2",
+			nil,
+			true, true,
+		},
+		{"WithMixedCompoundOps", "SELECT * FROM a UNION SELECT * FROM b EXCEPT SELECT * FROM c INTERSECT ALL SELECT * FROM d",
+			stream.New(stream.IntersectAll(
+				stream.New(stream.Except(
+					stream.New(stream.Union(
+						stream.New(table.Scan("a")),
+						stream.New(table.Scan("b")),
+					)),
+					stream.New(table.Scan("c")),
+				)),
+				stream.New(table.Scan("d")),
+			)),
+			true, false,
+		},
 		{"WithMultipleCompoundOps/1", "SELECT * FROM a UNION ALL SELECT * FROM b UNION ALL SELECT * FROM c",
 			stream.New(stream.Concat(
 				stream.New(table.Scan("a")),
@@ -310,6 +384,69 @@ func TestParserSelect(t *testing.T) {
 			)),
 			false, false,
 		},
+		{"WithTableAlias", "SELECT t.a FROM test AS t",
+			stream.New(table.Scan("test")).
+				Pipe(docs.Alias("t")).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "t.a"))),
+			true, false,
+		},
+		{"WithJoin", "SELECT * FROM a JOIN b ON a.id = b.a_id",
+			stream.New(table.Scan("a")).
+				Pipe(docs.Alias("a")).
+				Pipe(stream.NestedLoopJoin("b", stream.New(table.Scan("b")), parser.MustParseExpr("a.id = b.a_id"))),
+			true, false,
+		},
+		{"WithInnerJoinAndAliases", "SELECT x.id, y.id FROM a x INNER JOIN b AS y ON x.id = y.a_id WHERE y.n > 10",
+			stream.New(table.Scan("a")).
+				Pipe(docs.Alias("x")).
+				Pipe(stream.NestedLoopJoin("y", stream.New(table.Scan("b")), parser.MustParseExpr("x.id = y.a_id"))).
+				Pipe(docs.Filter(parser.MustParseExpr("y.n > 10"))).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "x.id"), testutil.ParseNamedExpr(t, "y.id"))),
+			true, false,
+		},
+		{"WithLeftJoins", "SELECT * FROM a LEFT JOIN b ON a.id = b.a_id LEFT OUTER JOIN c ON b.id = c.b_id",
+			stream.New(table.Scan("a")).
+				Pipe(docs.Alias("a")).
+				Pipe(stream.NestedLoopLeftJoin("b", stream.New(table.Scan("b")), parser.MustParseExpr("a.id = b.a_id"))).
+				Pipe(stream.NestedLoopLeftJoin("c", stream.New(table.Scan("c")), parser.MustParseExpr("b.id = c.b_id"))),
+			true, false,
+		},
+		{"WithJoinWithoutOn", "SELECT * FROM a JOIN b", nil, true, true},
+		{"WithUnnest", "SELECT o.id, item.sku FROM orders o, UNNEST(o.items) AS item",
+			stream.New(table.Scan("orders")).
+				Pipe(docs.Alias("o")).
+				Pipe(docs.Unnest(parser.MustParseExpr("o.items"), "item", "")).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "o.id"), testutil.ParseNamedExpr(t, "item.sku"))),
+			true, false,
+		},
+		{"WithUnnestWithOffset", "SELECT * FROM orders, UNNEST(orders.items) item WITH OFFSET AS i WHERE i > 0",
+			stream.New(table.Scan("orders")).
+				Pipe(docs.Alias("orders")).
+				Pipe(docs.Unnest(parser.MustParseExpr("orders.items"), "item", "i")).
+				Pipe(docs.Filter(parser.MustParseExpr("i > 0"))),
+			true, false,
+		},
+		{"WithUnnestWithoutAlias", "SELECT * FROM orders, UNNEST(orders.items)", nil, true, true},
+		{"WithCommaJoin", "SELECT * FROM a, b", nil, true, true},
+		{"WithCTE", "WITH c AS (SELECT a FROM test) SELECT * FROM c",
+			stream.New(stream.CTE("c", stream.New(table.Scan("test")).Pipe(docs.Project(testutil.ParseNamedExpr(t, "a"))))),
+			true, false,
+		},
+		{"WithCTEJoin", "WITH c AS (SELECT * FROM test) SELECT * FROM a JOIN c ON a.id = c.id",
+			stream.New(table.Scan("a")).
+				Pipe(docs.Alias("a")).
+				Pipe(stream.NestedLoopJoin("c", stream.New(stream.CTE("c", stream.New(table.Scan("test")))), parser.MustParseExpr("a.id = c.id"))),
+			true, false,
+		},
+		{"WithRecursiveCTE", "WITH RECURSIVE n AS (SELECT a FROM test UNION ALL SELECT a FROM n) SELECT * FROM n",
+			stream.New(stream.RecursiveConcat("n", new(stream.WorkingTable),
+				stream.New(table.Scan("test")).Pipe(docs.Project(testutil.ParseNamedExpr(t, "a"))),
+				stream.New(stream.WorkingTableScan("n", new(stream.WorkingTable))).Pipe(docs.Project(testutil.ParseNamedExpr(t, "a"))),
+			)),
+			true, false,
+		},
+		{"WithCTEWithoutSelect", "WITH c AS (SELECT a FROM test) DELETE FROM test", nil, true, true},
+		{"WithCTEWithoutParentheses", "WITH c AS SELECT a FROM test SELECT * FROM c", nil, true, true},
 	}
 
 	for _, test := range tests {
@@ -326,6 +463,7 @@ func TestParserSelect(t *testing.T) {
 					CREATE TABLE b;
 					CREATE TABLE c;
 					CREATE TABLE d;
+					CREATE TABLE orders;
 				`,
 				)
 
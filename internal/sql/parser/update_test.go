@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
@@ -76,7 +77,19 @@ func TestParserUpdate(t *testing.T) {
 				Pipe(stream.Discard()),
 			false,
 		},
+		{"SET/With returning", "UPDATE test SET a = 1 WHERE age = 10 RETURNING *, OLD.a",
+			stream.New(table.Scan("test")).
+				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
+				Pipe(docs.StoreVar("OLD")).
+				Pipe(path.Set(document.Path(testutil.ParsePath(t, "a")), testutil.IntegerValue(1))).
+				Pipe(table.Validate("test")).
+				Pipe(table.Replace("test")).
+				Pipe(docs.StoreVar("NEW")).
+				Pipe(docs.Project(expr.Wildcard{}, testutil.ParseNamedExpr(t, "OLD.a"))),
+			false,
+		},
 		{"Trailing comma", "UPDATE test SET a = 1, WHERE age = 10", nil, true},
+		{"No returning expr", "UPDATE test SET a = 1 RETURNING", nil, true},
 		{"No SET", "UPDATE test WHERE age = 10", nil, true},
 		{"No pair", "UPDATE test SET WHERE age = 10", nil, true},
 		{"query.Field only", "UPDATE test SET a WHERE age = 10", nil, true},
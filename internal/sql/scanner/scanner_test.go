@@ -190,9 +190,13 @@ func TestScanner_Scan(t *testing.T) {
 		{s: "BYTES", tok: TYPEBYTES},
 		{s: "BOOL", tok: TYPEBOOL},
 		{s: "BOOLEAN", tok: TYPEBOOLEAN},
+		{s: "DATE", tok: TYPEDATE},
+		{s: "DECIMAL", tok: TYPEDECIMAL},
 		{s: "DOUBLE", tok: TYPEDOUBLE},
 		{s: "INTEGER", tok: TYPEINTEGER},
+		{s: "NUMERIC", tok: TYPENUMERIC},
 		{s: "TEXT", tok: TYPETEXT},
+		{s: "TIMESTAMP", tok: TYPETIMESTAMP},
 	}
 
 	for i, tt := range tests {
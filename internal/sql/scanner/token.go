@@ -79,13 +79,17 @@ const (
 	keywordBeg
 	// ALL and the following are Genji SQL Keywords
 	ADD_KEYWORD
+	AFTER
 	ALL
 	ALTER
 	AS
 	ASC
+	BEFORE
 	BEGIN
 	BY
 	CACHE
+	CASCADE
+	CASE
 	CAST
 	CHECK
 	COMMIT
@@ -99,19 +103,29 @@ const (
 	DISTINCT
 	DO
 	DROP
+	EACH
+	ELSE
+	END
+	EXCEPT
 	EXISTS
 	EXPLAIN
 	FIELD
 	FOR
+	FOREIGN
 	FROM
 	GROUP
+	HAVING
 	IF
 	IGNORE
 	INCREMENT
 	INDEX
+	INNER
 	INSERT
+	INTERSECT
 	INTO
+	JOIN
 	KEY
+	LEFT
 	LIMIT
 	MAXVALUE
 	MINVALUE
@@ -123,27 +137,39 @@ const (
 	ON
 	ONLY
 	ORDER
+	OUTER
+	OVER
+	PARTITION
 	PRECISION
 	PRIMARY
 	READ
+	RECURSIVE
+	REFERENCES
 	REINDEX
 	RENAME
 	REPLACE
+	RESTRICT
 	RETURNING
 	ROLLBACK
+	ROW
 	SELECT
 	SEQUENCE
 	SET
 	START
 	TABLE
+	THEN
 	TO
 	TRANSACTION
+	TRIGGER
 	UNION
 	UNIQUE
+	UNNEST
 	UNSET
 	UPDATE
 	VALUE
 	VALUES
+	VIEW
+	WHEN
 	WITH
 	WHERE
 	WRITE
@@ -157,6 +183,8 @@ const (
 	TYPEBOOLEAN
 	TYPEBYTES
 	TYPECHARACTER
+	TYPEDATE
+	TYPEDECIMAL
 	TYPEDOCUMENT
 	TYPEDOUBLE
 	TYPEINT
@@ -164,8 +192,10 @@ const (
 	TYPEINT8
 	TYPEINTEGER
 	TYPEMEDIUMINT
+	TYPENUMERIC
 	TYPESMALLINT
 	TYPETEXT
+	TYPETIMESTAMP
 	TYPETINYINT
 	TYPEREAL
 	TYPEVARCHAR
@@ -227,13 +257,17 @@ var tokens = [...]string{
 	DOT:         ".",
 
 	ADD_KEYWORD: "ADD",
+	AFTER:       "AFTER",
 	ALL:         "ALL",
 	ALTER:       "ALTER",
 	AS:          "AS",
 	ASC:         "ASC",
+	BEFORE:      "BEFORE",
 	BEGIN:       "BEGIN",
 	BY:          "BY",
 	CACHE:       "CACHE",
+	CASCADE:     "CASCADE",
+	CASE:        "CASE",
 	CAST:        "CAST",
 	CHECK:       "CHECK",
 	COMMIT:      "COMMIT",
@@ -247,19 +281,29 @@ var tokens = [...]string{
 	DESC:        "DESC",
 	DISTINCT:    "DISTINCT",
 	DROP:        "DROP",
+	EACH:        "EACH",
+	ELSE:        "ELSE",
+	END:         "END",
+	EXCEPT:      "EXCEPT",
 	EXISTS:      "EXISTS",
 	EXPLAIN:     "EXPLAIN",
 	GROUP:       "GROUP",
 	KEY:         "KEY",
 	FIELD:       "FIELD",
 	FOR:         "FOR",
+	FOREIGN:     "FOREIGN",
 	FROM:        "FROM",
+	HAVING:      "HAVING",
 	IF:          "IF",
 	IGNORE:      "IGNORE",
 	INCREMENT:   "INCREMENT",
 	INDEX:       "INDEX",
+	INNER:       "INNER",
 	INSERT:      "INSERT",
+	INTERSECT:   "INTERSECT",
 	INTO:        "INTO",
+	JOIN:        "JOIN",
+	LEFT:        "LEFT",
 	LIMIT:       "LIMIT",
 	MAXVALUE:    "MAXVALUE",
 	MINVALUE:    "MINVALUE",
@@ -271,27 +315,39 @@ var tokens = [...]string{
 	ON:          "ON",
 	ONLY:        "ONLY",
 	ORDER:       "ORDER",
+	OUTER:       "OUTER",
+	OVER:        "OVER",
+	PARTITION:   "PARTITION",
 	PRECISION:   "PRECISION",
 	PRIMARY:     "PRIMARY",
 	READ:        "READ",
+	RECURSIVE:   "RECURSIVE",
+	REFERENCES:  "REFERENCES",
 	REINDEX:     "REINDEX",
 	RENAME:      "RENAME",
 	RETURNING:   "RETURNING",
 	REPLACE:     "REPLACE",
+	RESTRICT:    "RESTRICT",
 	ROLLBACK:    "ROLLBACK",
+	ROW:         "ROW",
 	START:       "START",
 	SELECT:      "SELECT",
 	SET:         "SET",
 	SEQUENCE:    "SEQUENCE",
 	TABLE:       "TABLE",
+	THEN:        "THEN",
 	TO:          "TO",
 	TRANSACTION: "TRANSACTION",
+	TRIGGER:     "TRIGGER",
 	UNION:       "UNION",
 	UNIQUE:      "UNIQUE",
+	UNNEST:      "UNNEST",
 	UNSET:       "UNSET",
 	UPDATE:      "UPDATE",
 	VALUE:       "VALUE",
 	VALUES:      "VALUES",
+	VIEW:        "VIEW",
+	WHEN:        "WHEN",
 	WITH:        "WITH",
 	WHERE:       "WHERE",
 	WRITE:       "WRITE",
@@ -304,6 +360,8 @@ var tokens = [...]string{
 	TYPEBOOLEAN:   "BOOLEAN",
 	TYPEBYTES:     "BYTES",
 	TYPECHARACTER: "CHARACTER",
+	TYPEDATE:      "DATE",
+	TYPEDECIMAL:   "DECIMAL",
 	TYPEDOCUMENT:  "DOCUMENT",
 	TYPEDOUBLE:    "DOUBLE",
 	TYPEINT:       "INT",
@@ -311,8 +369,10 @@ var tokens = [...]string{
 	TYPEINT8:      "INT8",
 	TYPEINTEGER:   "INTEGER",
 	TYPEMEDIUMINT: "MEDIUMINT",
+	TYPENUMERIC:   "NUMERIC",
 	TYPESMALLINT:  "SMALLINT",
 	TYPETEXT:      "TEXT",
+	TYPETIMESTAMP: "TIMESTAMP",
 	TYPETINYINT:   "TINYINT",
 	TYPEREAL:      "REAL",
 	TYPEVARCHAR:   "VARCHAR",
@@ -356,6 +416,9 @@ func (tok Token) Precedence() int {
 // IsOperator returns true for operator tokens.
 func (tok Token) IsOperator() bool { return tok > operatorBeg && tok < operatorEnd }
 
+// IsKeyword returns true for keyword tokens.
+func (tok Token) IsKeyword() bool { return tok > keywordBeg && tok < keywordEnd }
+
 // Tokstr returns a literal if provided, otherwise returns the token string.
 func Tokstr(tok Token, lit string) string {
 	if lit != "" {
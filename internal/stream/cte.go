@@ -0,0 +1,280 @@
+package stream
+
+import (
+	"fmt"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/encoding"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A CTEOperator iterates over the documents of a common table expression.
+type CTEOperator struct {
+	BaseOperator
+	Name   string
+	Stream *Stream
+}
+
+// CTE returns an operator that iterates over the documents returned by
+// the stream of the common table expression with the given name.
+func CTE(name string, s *Stream) *CTEOperator {
+	return &CTEOperator{Name: name, Stream: s}
+}
+
+// Iterate implements the Operator interface.
+func (op *CTEOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.Set(environment.TableKey, types.NewTextValue(op.Name))
+
+	return op.Stream.Iterate(in, func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return nil
+		}
+
+		newEnv.SetDocument(d)
+		return fn(&newEnv)
+	})
+}
+
+func (op *CTEOperator) String() string {
+	return fmt.Sprintf("cte(%q, %s)", op.Name, op.Stream)
+}
+
+// A WorkingTable holds the documents produced by the last iteration
+// of a recursive common table expression.
+// Documents of every iteration are stored in the same tree,
+// prefixed by the number of the iteration.
+type WorkingTable struct {
+	tree      *tree.Tree
+	iteration int64
+}
+
+// A WorkingTableScanOperator iterates over the documents of a working table.
+type WorkingTableScanOperator struct {
+	BaseOperator
+	Name  string
+	Table *WorkingTable
+}
+
+// WorkingTableScan returns an operator that iterates over the documents
+// of the working table of the recursive common table expression with the given name.
+func WorkingTableScan(name string, wt *WorkingTable) *WorkingTableScanOperator {
+	return &WorkingTableScanOperator{Name: name, Table: wt}
+}
+
+// Iterate implements the Operator interface.
+func (op *WorkingTableScanOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	tr := op.Table.tree
+	if tr == nil {
+		return nil
+	}
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.Set(environment.TableKey, types.NewTextValue(op.Name))
+
+	prefix := tree.NewKey(types.NewIntegerValue(op.Table.iteration))
+	rng := tree.Range{Min: prefix, Max: prefix}
+
+	return tr.IterateOnRange(&rng, false, func(_ *tree.Key, data []byte) error {
+		newEnv.SetDocument(encoding.DecodeDocument(data, false /* intAsDouble */))
+		return fn(&newEnv)
+	})
+}
+
+func (op *WorkingTableScanOperator) String() string {
+	return fmt.Sprintf("workingTable(%q)", op.Name)
+}
+
+// A RecursiveUnionOperator evaluates a recursive common table expression.
+type RecursiveUnionOperator struct {
+	BaseOperator
+	Name      string
+	Table     *WorkingTable
+	Anchor    *Stream
+	Recursive *Stream
+	All       bool
+}
+
+// RecursiveUnion returns an operator that evaluates a recursive common table expression.
+// The anchor stream is evaluated first, and its documents are stored in a transient tree
+// used as the working table. The recursive stream is then evaluated repeatedly,
+// reading the working table filled by the previous iteration, until it returns no documents.
+// Documents are returned as soon as they are produced.
+// The fields of the documents returned by the recursive stream are renamed
+// after the fields of the documents of the anchor, by position.
+// Duplicate documents are ignored, which guarantees that the recursion stops
+// even if the data contains cycles.
+func RecursiveUnion(name string, wt *WorkingTable, anchor, recursive *Stream) *RecursiveUnionOperator {
+	return &RecursiveUnionOperator{Name: name, Table: wt, Anchor: anchor, Recursive: recursive}
+}
+
+// RecursiveConcat does the same as RecursiveUnion but returns duplicate documents.
+func RecursiveConcat(name string, wt *WorkingTable, anchor, recursive *Stream) *RecursiveUnionOperator {
+	return &RecursiveUnionOperator{Name: name, Table: wt, Anchor: anchor, Recursive: recursive, All: true}
+}
+
+// Iterate implements the Operator interface.
+func (op *RecursiveUnionOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
+	db := in.GetDB()
+	catalog := in.GetCatalog()
+
+	var cleanups []func() error
+	defer func() {
+		for _, cleanup := range cleanups {
+			if e := cleanup(); err == nil {
+				err = e
+			}
+		}
+	}()
+
+	newTree := func() (*tree.Tree, error) {
+		tr, cleanup, err := tree.NewTransient(db.Store.NewTransientSession(), catalog.GetFreeTransientNamespace())
+		if err != nil {
+			return nil, err
+		}
+		cleanups = append(cleanups, cleanup)
+		return tr, nil
+	}
+
+	// documents already returned, used to remove duplicates
+	var seen *tree.Tree
+	if !op.All {
+		seen, err = newTree()
+		if err != nil {
+			return err
+		}
+	}
+
+	// the working table might be used by an enclosing evaluation
+	// of the same expression
+	prev := *op.Table
+	defer func() {
+		*op.Table = prev
+	}()
+
+	wt, err := newTree()
+	if err != nil {
+		return err
+	}
+
+	var iteration, counter int64
+	var buf []byte
+	// names of the fields of the anchor
+	var columns []string
+	var fb document.FieldBuffer
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.Set(environment.TableKey, types.NewTextValue(op.Name))
+
+	emit := func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return nil
+		}
+
+		if iteration == 0 {
+			if columns == nil {
+				columns = []string{}
+				err = d.Iterate(func(field string, _ types.Value) error {
+					columns = append(columns, field)
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			d, err = op.rename(d, columns, &fb)
+			if err != nil {
+				return err
+			}
+		}
+
+		if seen != nil {
+			key := tree.NewKey(types.NewDocumentValue(d))
+			exists, err := seen.Exists(key)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return nil
+			}
+
+			err = seen.Put(key, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		// store the document in the working table of the next iteration
+		buf, err = encoding.EncodeDocument(buf[:0], d)
+		if err != nil {
+			return err
+		}
+		err = wt.Put(tree.NewKey(types.NewIntegerValue(iteration), types.NewIntegerValue(counter)), buf)
+		if err != nil {
+			return err
+		}
+		counter++
+
+		newEnv.SetDocument(d)
+		return fn(&newEnv)
+	}
+
+	s := op.Anchor
+	for {
+		counter = 0
+
+		err = s.Iterate(in, emit)
+		if err != nil {
+			return err
+		}
+
+		// stop once an iteration doesn't produce any new document
+		if counter == 0 {
+			return nil
+		}
+
+		op.Table.tree = wt
+		op.Table.iteration = iteration
+		iteration++
+		s = op.Recursive
+	}
+}
+
+// rename returns a document with the values of d stored under the given field names.
+func (op *RecursiveUnionOperator) rename(d types.Document, columns []string, fb *document.FieldBuffer) (types.Document, error) {
+	fb.Reset()
+
+	var i int
+	err := d.Iterate(func(field string, v types.Value) error {
+		if i < len(columns) {
+			fb.Add(columns[i], v)
+		}
+		i++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if i != len(columns) {
+		return nil, fmt.Errorf("recursive term of %q returns %d columns, but its anchor returns %d", op.Name, i, len(columns))
+	}
+
+	return fb, nil
+}
+
+func (op *RecursiveUnionOperator) String() string {
+	if op.All {
+		return fmt.Sprintf("recursiveConcat(%q, %s, %s)", op.Name, op.Anchor, op.Recursive)
+	}
+
+	return fmt.Sprintf("recursiveUnion(%q, %s, %s)", op.Name, op.Anchor, op.Recursive)
+}
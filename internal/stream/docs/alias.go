@@ -0,0 +1,50 @@
+package docs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
+)
+
+// An AliasOperator stores every incoming document under a field named after the alias.
+// It is used to qualify the fields of a table when it is joined with other tables.
+type AliasOperator struct {
+	stream.BaseOperator
+	Alias string
+}
+
+// Alias creates an AliasOperator.
+func Alias(alias string) *AliasOperator {
+	return &AliasOperator{Alias: alias}
+}
+
+// Iterate implements the Operator interface.
+func (op *AliasOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	var fb document.FieldBuffer
+	var newEnv environment.Environment
+	newEnv.Set(environment.AliasesKey, types.NewArrayValue(document.NewValueBuffer(types.NewTextValue(op.Alias))))
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return errors.New("missing document")
+		}
+
+		fb.Reset()
+		fb.Add(op.Alias, types.NewDocumentValue(d))
+
+		newEnv.SetOuter(out)
+		newEnv.SetDocument(&fb)
+
+		return f(&newEnv)
+	})
+}
+
+func (op *AliasOperator) String() string {
+	return fmt.Sprintf("docs.Alias(%s)", strconv.Quote(op.Alias))
+}
@@ -15,6 +15,7 @@ type GroupAggregateOperator struct {
 	stream.BaseOperator
 	Builders []expr.AggregatorBuilder
 	E        expr.Expr
+	Tuple    bool
 }
 
 // GroupAggregate consumes the incoming stream and outputs one value per group.
@@ -23,19 +24,32 @@ func GroupAggregate(groupBy expr.Expr, builders ...expr.AggregatorBuilder) *Grou
 	return &GroupAggregateOperator{E: groupBy, Builders: builders}
 }
 
+// GroupAggregateTuple works like GroupAggregate but groups documents by the tuple
+// formed by the given expressions and adds each of them to the output document.
+// It assumes the stream is sorted by groupBy.
+func GroupAggregateTuple(groupBy expr.LiteralExprList, builders ...expr.AggregatorBuilder) *GroupAggregateOperator {
+	return &GroupAggregateOperator{E: groupBy, Builders: builders, Tuple: true}
+}
+
 func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
 	var lastGroup types.Value
 	var ga *groupAggregator
 
-	var groupExpr string
-	if op.E != nil {
-		groupExpr = op.E.String()
+	var groupExprs []string
+	switch {
+	case op.E == nil:
+	case op.Tuple:
+		for _, e := range op.E.(expr.LiteralExprList) {
+			groupExprs = append(groupExprs, e.String())
+		}
+	default:
+		groupExprs = []string{op.E.String()}
 	}
 
 	err := op.Prev.Iterate(in, func(out *environment.Environment) error {
 		if op.E == nil {
 			if ga == nil {
-				ga = newGroupAggregator(nil, groupExpr, op.Builders)
+				ga = newGroupAggregator(nil, groupExprs, op.Builders)
 			}
 
 			return ga.Aggregate(out)
@@ -52,7 +66,7 @@ func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(ou
 			if err != nil {
 				return err
 			}
-			ga = newGroupAggregator(lastGroup, groupExpr, op.Builders)
+			ga = newGroupAggregator(lastGroup, groupExprs, op.Builders)
 			return ga.Aggregate(out)
 		}
 
@@ -79,7 +93,7 @@ func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(ou
 			return err
 		}
 
-		ga = newGroupAggregator(lastGroup, groupExpr, op.Builders)
+		ga = newGroupAggregator(lastGroup, groupExprs, op.Builders)
 		return ga.Aggregate(out)
 	})
 	if err != nil {
@@ -92,7 +106,7 @@ func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(ou
 	// we want the following result:
 	// {"COUNT(*)": 0}
 	if ga == nil {
-		ga = newGroupAggregator(nil, "", op.Builders)
+		ga = newGroupAggregator(nil, nil, op.Builders)
 	}
 
 	e, err := ga.Flush(in)
@@ -105,7 +119,11 @@ func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(ou
 func (op *GroupAggregateOperator) String() string {
 	var sb strings.Builder
 
-	sb.WriteString("docs.GroupAggregate(")
+	if op.Tuple {
+		sb.WriteString("docs.GroupAggregateTuple(")
+	} else {
+		sb.WriteString("docs.GroupAggregate(")
+	}
 	if op.E != nil {
 		sb.WriteString(op.E.String())
 	} else {
@@ -126,11 +144,11 @@ func (op *GroupAggregateOperator) String() string {
 // result of the aggregation.
 type groupAggregator struct {
 	group       types.Value
-	groupExpr   string
+	groupExprs  []string
 	aggregators []expr.Aggregator
 }
 
-func newGroupAggregator(group types.Value, groupExpr string, builders []expr.AggregatorBuilder) *groupAggregator {
+func newGroupAggregator(group types.Value, groupExprs []string, builders []expr.AggregatorBuilder) *groupAggregator {
 	newAggregators := make([]expr.Aggregator, len(builders))
 	for i, b := range builders {
 		newAggregators[i] = b.Aggregator()
@@ -139,7 +157,7 @@ func newGroupAggregator(group types.Value, groupExpr string, builders []expr.Agg
 	return &groupAggregator{
 		aggregators: newAggregators,
 		group:       group,
-		groupExpr:   groupExpr,
+		groupExprs:  groupExprs,
 	}
 }
 
@@ -158,8 +176,20 @@ func (g *groupAggregator) Flush(env *environment.Environment) (*environment.Envi
 	fb := document.NewFieldBuffer()
 
 	// add the current group to the document
-	if g.groupExpr != "" {
-		fb.Add(g.groupExpr, g.group)
+	switch len(g.groupExprs) {
+	case 0:
+	case 1:
+		fb.Add(g.groupExprs[0], g.group)
+	default:
+		// the group is a tuple, add each of its values
+		// under the name of their expression
+		err := g.group.V().(types.Array).Iterate(func(i int, v types.Value) error {
+			fb.Add(g.groupExprs[i], v)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	for _, agg := range g.aggregators {
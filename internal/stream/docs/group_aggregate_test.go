@@ -51,6 +51,19 @@ func TestAggregate(t *testing.T) {
 			[]types.Document{testutil.MakeDocument(t, `{"a % 2": 0, "COUNT(a)": 5, "AVG(a)": 4.0}`), testutil.MakeDocument(t, `{"a % 2": 1, "COUNT(a)": 5, "AVG(a)": 5.0}`)},
 			false,
 		},
+		{
+			"count/groupByArray",
+			parser.MustParseExpr("[a % 2, a < 5]"),
+			[]expr.AggregatorBuilder{&functions.Count{Wildcard: true}},
+			generateSeqDocs(t, 10),
+			testutil.MakeDocuments(t,
+				`{"[a % 2, a < 5]": [0, false], "COUNT(*)": 2}`,
+				`{"[a % 2, a < 5]": [0, true], "COUNT(*)": 3}`,
+				`{"[a % 2, a < 5]": [1, false], "COUNT(*)": 3}`,
+				`{"[a % 2, a < 5]": [1, true], "COUNT(*)": 2}`,
+			),
+			false,
+		},
 		{
 			"count/noInput",
 			nil,
@@ -118,6 +131,8 @@ func TestAggregate(t *testing.T) {
 		require.Equal(t, `docs.GroupAggregate(a % 2, a(), b())`, docs.GroupAggregate(parser.MustParseExpr("a % 2"), makeAggregatorBuilders("a()", "b()")...).String())
 		require.Equal(t, `docs.GroupAggregate(NULL, a(), b())`, docs.GroupAggregate(nil, makeAggregatorBuilders("a()", "b()")...).String())
 		require.Equal(t, `docs.GroupAggregate(a % 2)`, docs.GroupAggregate(parser.MustParseExpr("a % 2")).String())
+		require.Equal(t, `docs.GroupAggregate([a, b], a())`, docs.GroupAggregate(parser.MustParseExpr("[a, b]"), makeAggregatorBuilders("a()")...).String())
+		require.Equal(t, `docs.GroupAggregateTuple([a, b], a())`, docs.GroupAggregateTuple(expr.LiteralExprList{parser.MustParseExpr("a"), parser.MustParseExpr("b")}, makeAggregatorBuilders("a()")...).String())
 	})
 }
 
@@ -0,0 +1,48 @@
+package docs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
+)
+
+// A StoreVarOperator stores every incoming document in a variable.
+// The variable remains accessible to the next operators, even if they modify the document.
+// It is used to return the old and new versions of the documents modified by an UPDATE statement.
+type StoreVarOperator struct {
+	stream.BaseOperator
+	Name string
+}
+
+// StoreVar creates a StoreVarOperator.
+func StoreVar(name string) *StoreVarOperator {
+	return &StoreVarOperator{Name: name}
+}
+
+// Iterate implements the Operator interface.
+func (op *StoreVarOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+	path := document.NewPath(op.Name)
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return errors.New("missing document")
+		}
+
+		newEnv.SetOuter(out)
+		newEnv.SetDocument(d)
+		newEnv.Set(path, types.NewDocumentValue(d))
+
+		return f(&newEnv)
+	})
+}
+
+func (op *StoreVarOperator) String() string {
+	return fmt.Sprintf("docs.StoreVar(%s)", strconv.Quote(op.Name))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/encoding"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
@@ -80,10 +81,25 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 
 		counter++
 
+		// the variables of the environment, such as the results of window functions,
+		// are stored along with the document
+		vars := sortedVars(out)
+		if vars != nil {
+			buf = encoding.EncodeArrayLength(buf, 2)
+		}
+
 		buf, err = encoding.EncodeDocument(buf, doc)
 		if err != nil {
 			return err
 		}
+
+		if vars != nil {
+			buf, err = encoding.EncodeDocument(buf, vars)
+			if err != nil {
+				return err
+			}
+		}
+
 		return tr.Put(tk, buf)
 	})
 	if err != nil {
@@ -99,6 +115,26 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 			return err
 		}
 
+		doc := encoding.DecodeDocument(data, false /* intAsDouble */)
+		if data[0] == encoding.ArrayValue {
+			a := encoding.DecodeArray(data, false /* intAsDouble */)
+			d, err := a.GetByIndex(0)
+			if err != nil {
+				return err
+			}
+			doc = types.As[types.Document](d)
+
+			vars, err := a.GetByIndex(1)
+			if err != nil {
+				return err
+			}
+			newEnv.Vars = document.NewFieldBuffer()
+			err = newEnv.Vars.Copy(types.As[types.Document](vars))
+			if err != nil {
+				return err
+			}
+		}
+
 		tableName := kv[1]
 		if tableName.Type() != types.NullValue {
 			newEnv.Set(environment.TableKey, tableName)
@@ -109,7 +145,7 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 			newEnv.SetKey(tree.NewEncodedKey(types.As[[]byte](docKey)))
 		}
 
-		newEnv.SetDocument(encoding.DecodeDocument(data, false /* intAsDouble */))
+		newEnv.SetDocument(doc)
 
 		return fn(&newEnv)
 	})
@@ -122,3 +158,25 @@ func (op *TempTreeSortOperator) String() string {
 
 	return fmt.Sprintf("docs.TempTreeSort(%s)", op.Expr)
 }
+
+// sortedVars returns the variables of the environment that must be kept
+// when sorting its document, or nil if there are none.
+// The table name is already part of the key of the temporary tree.
+func sortedVars(env *environment.Environment) types.Document {
+	if env.Vars == nil {
+		return nil
+	}
+
+	var keep bool
+	_ = env.Vars.Iterate(func(field string, _ types.Value) error {
+		if field != environment.TableKey[0].FieldName {
+			keep = true
+		}
+		return nil
+	})
+	if !keep {
+		return nil
+	}
+
+	return env.Vars
+}
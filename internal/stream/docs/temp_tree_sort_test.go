@@ -105,6 +105,36 @@ func TestTempTreeSort(t *testing.T) {
 		})
 	}
 
+	t.Run("Vars", func(t *testing.T) {
+		db, tx, cleanup := testutil.NewTestTx(t)
+		defer cleanup()
+
+		testutil.MustExec(t, db, tx, "CREATE TABLE test(a int); INSERT INTO test (a) VALUES (2), (1)")
+
+		var env environment.Environment
+		env.DB = db
+		env.Tx = tx
+		env.Catalog = db.Catalog
+
+		s := stream.New(table.Scan("test")).
+			Pipe(docs.StoreVar("v")).
+			Pipe(docs.TempTreeSort(parser.MustParseExpr("a")))
+
+		var got []types.Value
+		err := s.Iterate(&env, func(env *environment.Environment) error {
+			v, ok := env.Get(document.NewPath("v", "a"))
+			require.True(t, ok)
+			got = append(got, v)
+
+			tableName, ok := env.Get(environment.TableKey)
+			require.True(t, ok)
+			require.Equal(t, "test", types.As[string](tableName))
+			return nil
+		})
+		assert.NoError(t, err)
+		require.Equal(t, []types.Value{types.NewIntegerValue(1), types.NewIntegerValue(2)}, got)
+	})
+
 	t.Run("String", func(t *testing.T) {
 		require.Equal(t, `docs.TempTreeSort(a)`, docs.TempTreeSort(parser.MustParseExpr("a")).String())
 	})
@@ -0,0 +1,92 @@
+package docs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
+)
+
+// An UnnestOperator evaluates an array expression for every incoming document
+// and emits one document per element of the array.
+// Each resulting document contains the fields of the incoming document,
+// the element stored under the Alias field and, if OffsetAlias is set,
+// the position of the element in the array stored under the OffsetAlias field.
+// Incoming documents for which the expression evaluates to NULL are skipped.
+type UnnestOperator struct {
+	stream.BaseOperator
+	Expr        expr.Expr
+	Alias       string
+	OffsetAlias string
+}
+
+// Unnest creates an UnnestOperator.
+func Unnest(e expr.Expr, alias string, offsetAlias string) *UnnestOperator {
+	return &UnnestOperator{Expr: e, Alias: alias, OffsetAlias: offsetAlias}
+}
+
+// Iterate implements the Operator interface.
+func (op *UnnestOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	var fb document.FieldBuffer
+	var newEnv environment.Environment
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return errors.New("missing document")
+		}
+
+		v, err := op.Expr.Eval(out)
+		if err != nil {
+			return err
+		}
+
+		switch v.Type() {
+		case types.NullValue:
+			return nil
+		case types.ArrayValue:
+		default:
+			return fmt.Errorf("cannot unnest value of type %s, expected an array", v.Type())
+		}
+
+		newEnv.SetOuter(out)
+
+		return types.As[types.Array](v).Iterate(func(i int, value types.Value) error {
+			fb.Reset()
+			err := fb.ScanDocument(d)
+			if err != nil {
+				return err
+			}
+
+			fb.Add(op.Alias, value)
+			if op.OffsetAlias != "" {
+				fb.Add(op.OffsetAlias, types.NewIntegerValue(int64(i)))
+			}
+			newEnv.SetDocument(&fb)
+
+			return f(&newEnv)
+		})
+	})
+}
+
+func (op *UnnestOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("docs.Unnest(")
+	s.WriteString(op.Expr.String())
+	s.WriteString(", ")
+	s.WriteString(strconv.Quote(op.Alias))
+	if op.OffsetAlias != "" {
+		s.WriteString(", ")
+		s.WriteString(strconv.Quote(op.OffsetAlias))
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
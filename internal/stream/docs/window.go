@@ -0,0 +1,190 @@
+package docs
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A WindowOperator computes window functions over partitions of the stream.
+type WindowOperator struct {
+	stream.BaseOperator
+	Windows []*functions.Window
+}
+
+// Window consumes the incoming stream and computes the given window functions
+// for each document. All the windows must partition and order documents the same way:
+// windows defined differently are computed by subsequent Window operators.
+// It assumes the stream is sorted by the partition and order expressions of the windows.
+// Each partition is loaded in memory, then its documents are returned with the
+// result of the window functions, which can be read by evaluating the windows.
+func Window(windows ...*functions.Window) *WindowOperator {
+	return &WindowOperator{Windows: windows}
+}
+
+// Iterate implements the Operator interface.
+func (op *WindowOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	w := op.Windows[0]
+	partitionBy := expr.LiteralExprList(w.PartitionBy)
+
+	var p functions.Partition
+	var lastPartition, lastOrder types.Value
+
+	err := op.Prev.Iterate(in, func(out *environment.Environment) error {
+		partition, err := partitionBy.Eval(out)
+		if err != nil {
+			return err
+		}
+
+		order := types.NewNullValue()
+		if w.OrderBy != nil {
+			order, err = w.OrderBy.Eval(out)
+			if err != nil {
+				return err
+			}
+		}
+
+		peer := 0
+		if len(p.Envs) > 0 {
+			ok, err := types.IsEqual(lastPartition, partition)
+			if err != nil {
+				return err
+			}
+
+			// if the document belongs to a different partition,
+			// we flush the previous one and start a new partition
+			if !ok {
+				err = op.flush(&p, f)
+				if err != nil {
+					return err
+				}
+			} else {
+				peer = p.Peers[len(p.Peers)-1]
+				ok, err = types.IsEqual(lastOrder, order)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					peer++
+				}
+			}
+		}
+
+		lastPartition, err = document.CloneValue(partition)
+		if err != nil {
+			return err
+		}
+		lastOrder, err = document.CloneValue(order)
+		if err != nil {
+			return err
+		}
+
+		env, err := copyEnvironment(in, out)
+		if err != nil {
+			return err
+		}
+
+		p.Envs = append(p.Envs, env)
+		p.Peers = append(p.Peers, peer)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return op.flush(&p, f)
+}
+
+// flush computes the windows for every document of the partition, emits them and resets the partition.
+func (op *WindowOperator) flush(p *functions.Partition, f func(out *environment.Environment) error) error {
+	if len(p.Envs) == 0 {
+		return nil
+	}
+
+	p.Ordered = op.Windows[0].OrderBy != nil
+
+	for _, w := range op.Windows {
+		values, err := w.EvalPartition(p)
+		if err != nil {
+			return err
+		}
+
+		path := document.Path{document.PathFragment{FieldName: w.String()}}
+		for i, env := range p.Envs {
+			env.Set(path, values[i])
+		}
+	}
+
+	for _, env := range p.Envs {
+		err := f(env)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.Envs = p.Envs[:0]
+	p.Peers = p.Peers[:0]
+	return nil
+}
+
+// copyEnvironment copies the document, key, table and variables of out,
+// such as the results of previous windows, into a new environment that outlives the iteration.
+func copyEnvironment(in, out *environment.Environment) (*environment.Environment, error) {
+	var env environment.Environment
+	env.SetOuter(in)
+
+	d, ok := out.GetDocument()
+	if !ok {
+		return nil, errors.New("missing document")
+	}
+
+	fb := document.NewFieldBuffer()
+	err := fb.Copy(d)
+	if err != nil {
+		return nil, err
+	}
+	env.SetDocument(fb)
+
+	if out.Vars != nil {
+		env.Vars = document.NewFieldBuffer()
+		err = env.Vars.Copy(out.Vars)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if k, ok := out.GetKey(); ok {
+		if k.Encoded != nil {
+			k = tree.NewEncodedKey(append([]byte{}, k.Encoded...))
+		}
+		env.SetKey(k)
+	}
+
+	if tableName, ok := out.Get(environment.TableKey); ok {
+		env.Set(environment.TableKey, tableName)
+	}
+
+	return &env, nil
+}
+
+func (op *WindowOperator) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("docs.Window(")
+	for i, w := range op.Windows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(w.String())
+	}
+	sb.WriteString(")")
+
+	return sb.String()
+}
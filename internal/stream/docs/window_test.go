@@ -0,0 +1,73 @@
+package docs_test
+
+import (
+	"testing"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/testutil"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		sortBy string
+		window string
+		want   []int64
+	}{
+		{"row_number", "[a % 2, a]", "ROW_NUMBER() OVER (PARTITION BY a % 2 ORDER BY a)", []int64{1, 2, 3, 1, 2}},
+		{"rank", "a / 2", "RANK() OVER (ORDER BY a / 2)", []int64{1, 1, 3, 3, 5}},
+		{"dense_rank", "a / 2", "DENSE_RANK() OVER (ORDER BY a / 2)", []int64{1, 1, 2, 2, 3}},
+		{"running sum", "[a % 2, a]", "SUM(a) OVER (PARTITION BY a % 2 ORDER BY a)", []int64{0, 2, 6, 1, 4}},
+		{"count", "a % 2", "COUNT(*) OVER (PARTITION BY a % 2)", []int64{3, 3, 3, 2, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, tx, cleanup := testutil.NewTestTx(t)
+			defer cleanup()
+
+			testutil.MustExec(t, db, tx, "CREATE TABLE test(a int)")
+
+			for _, doc := range generateSeqDocs(t, 5) {
+				testutil.MustExec(t, db, tx, "INSERT INTO test VALUES ?", environment.Param{Value: doc})
+			}
+
+			var env environment.Environment
+			env.DB = db
+			env.Tx = tx
+			env.Catalog = db.Catalog
+
+			w := parser.MustParseExpr(test.window).(*functions.Window)
+
+			s := stream.New(table.Scan("test"))
+			s = s.Pipe(docs.TempTreeSort(parser.MustParseExpr(test.sortBy)))
+			s = s.Pipe(docs.Window(w))
+
+			var got []int64
+			err := s.Iterate(&env, func(env *environment.Environment) error {
+				v, err := w.Eval(env)
+				if err != nil {
+					return err
+				}
+
+				got = append(got, types.As[int64](v))
+				return nil
+			})
+			assert.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		w := parser.MustParseExpr("RANK() OVER (PARTITION BY a ORDER BY b DESC)").(*functions.Window)
+		require.Equal(t, `docs.Window(RANK() OVER (PARTITION BY a ORDER BY b DESC))`, docs.Window(w).String())
+	})
+}
@@ -0,0 +1,70 @@
+package stream
+
+import (
+	"github.com/genjidb/genji/internal/environment"
+)
+
+// ExceptOperator is an operator that returns the documents
+// of its first stream that are not returned by the other streams.
+type ExceptOperator struct {
+	BaseOperator
+	Streams []*Stream
+	All     bool
+}
+
+// Except returns a new ExceptOperator. Duplicate documents are removed.
+func Except(s ...*Stream) *ExceptOperator {
+	return &ExceptOperator{Streams: s}
+}
+
+// ExceptAll returns a new ExceptOperator that keeps duplicate documents:
+// a document returned n times by the first stream and m times by the other streams
+// is returned n - m times.
+func ExceptAll(s ...*Stream) *ExceptOperator {
+	return &ExceptOperator{Streams: s, All: true}
+}
+
+// Iterate counts the documents of the first stream in a temporary tree.
+// Then, every document returned by the other streams decrements its count.
+func (it *ExceptOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
+	c := counter{all: it.All}
+	defer func() {
+		if e := c.cleanup(); err == nil {
+			err = e
+		}
+	}()
+
+	if len(it.Streams) == 0 {
+		return nil
+	}
+
+	tr, err := c.count(in, it.Streams[0])
+	if err != nil {
+		return err
+	}
+
+	for _, s := range it.Streams[1:] {
+		err = s.Iterate(in, func(out *environment.Environment) error {
+			key, err := documentKey(out)
+			if err != nil {
+				return err
+			}
+
+			n, err := getCount(tr, key)
+			if err != nil || n == 0 {
+				return err
+			}
+
+			return putCount(tr, key, n-1)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return emitCounted(in, tr, fn)
+}
+
+func (it *ExceptOperator) String() string {
+	return setOperatorString("except", it.All, it.Streams)
+}
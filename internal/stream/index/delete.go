@@ -5,8 +5,8 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/environment"
+	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/stream"
-	"github.com/genjidb/genji/types"
 )
 
 // DeleteOperator reads the input stream and deletes the document from the specified index.
@@ -48,6 +48,10 @@ func (op *DeleteOperator) Iterate(in *environment.Environment, fn func(out *envi
 		}
 
 		old, err := table.GetDocument(key)
+		if errs.IsNotFoundError(err) {
+			// the document was deleted by a foreign key action
+			return nil
+		}
 		if err != nil {
 			return err
 		}
@@ -57,20 +61,27 @@ func (op *DeleteOperator) Iterate(in *environment.Environment, fn func(out *envi
 			return err
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(old)
-			if err != nil {
-				v = types.NewNullValue()
-			}
-			vs = append(vs, v)
+		// the document was not indexed if it didn't match the predicate of a partial index
+		ok, err = info.Matches(tx, old)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fn(out)
 		}
 
-		err = idx.Delete(vs, key.Encoded)
+		entries, err := info.Entries(tx, old)
 		if err != nil {
 			return err
 		}
 
+		for _, vs := range entries {
+			err = idx.Delete(vs, key.Encoded)
+			if err != nil {
+				return err
+			}
+		}
+
 		return fn(out)
 	})
 }
@@ -6,7 +6,6 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
-	"github.com/genjidb/genji/types"
 )
 
 // InsertOperator reads the input stream and indexes each document.
@@ -47,18 +46,26 @@ func (op *InsertOperator) Iterate(in *environment.Environment, fn func(out *envi
 			return errors.New("missing document key")
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(d)
-			if err != nil {
-				v = types.NewNullValue()
-			}
-			vs = append(vs, v)
+		// documents not matching the predicate of a partial index are not indexed
+		ok, err = info.Matches(tx, d)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fn(out)
 		}
 
-		err = idx.Set(vs, key.Encoded)
+		// multikey indexes store one entry per element of the array
+		entries, err := info.Entries(tx, d)
 		if err != nil {
-			return fmt.Errorf("error while inserting index value: %w", err)
+			return err
+		}
+
+		for _, vs := range entries {
+			err = idx.Set(vs, key.Encoded)
+			if err != nil {
+				return fmt.Errorf("error while inserting index value: %w", err)
+			}
 		}
 
 		return fn(out)
@@ -0,0 +1,135 @@
+package index
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A JoinOperator joins every document of the stream with the documents
+// of the table owning the index, by looking up the index for each of them.
+// Documents of the table are stored in the resulting document under the Alias field.
+type JoinOperator struct {
+	stream.BaseOperator
+
+	// IndexName references the index used to lookup the documents of the table.
+	IndexName string
+	// Alias is the name of the field under which the documents of the table are stored.
+	Alias string
+	// Key is evaluated for each document of the stream and compared against
+	// the first path of the index.
+	Key expr.Expr
+	// On is the join condition.
+	On expr.Expr
+	// If Left is true, documents of the stream that don't match with
+	// any document of the table are still returned, with a NULL
+	// value stored under the Alias field.
+	Left bool
+}
+
+// Join creates an operator that performs an inner join by looking up the given index.
+func Join(indexName, alias string, key, on expr.Expr) *JoinOperator {
+	return &JoinOperator{IndexName: indexName, Alias: alias, Key: key, On: on}
+}
+
+// LeftJoin creates an operator that performs a left outer join by looking up the given index.
+func LeftJoin(indexName, alias string, key, on expr.Expr) *JoinOperator {
+	return &JoinOperator{IndexName: indexName, Alias: alias, Key: key, On: on, Left: true}
+}
+
+// Iterate implements the Operator interface.
+func (op *JoinOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	catalog := in.GetCatalog()
+	tx := in.GetTx()
+
+	index, err := catalog.GetIndex(tx, op.IndexName)
+	if err != nil {
+		return err
+	}
+
+	info, err := catalog.GetIndexInfo(op.IndexName)
+	if err != nil {
+		return err
+	}
+
+	table, err := catalog.GetTable(tx, info.Owner.TableName)
+	if err != nil {
+		return err
+	}
+
+	j := stream.NewJoiner(op.Alias, op.On, op.Left)
+	ptr := DocumentPointer{
+		Table: table,
+	}
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		j.Reset(out)
+
+		v, err := op.Key.Eval(out)
+		if err != nil {
+			return err
+		}
+
+		// NULL never matches
+		if v.Type() != types.NullValue {
+			rng := database.Range{
+				Min:   []types.Value{v},
+				Exact: true,
+			}
+
+			r, err := rng.ToTreeRange(&table.Info.FieldConstraints, info.Paths)
+			if err != nil {
+				return err
+			}
+
+			var closed bool
+			err = index.IterateOnRange(r, false, func(key *tree.Key) error {
+				ptr.key = key
+				ptr.Doc = nil
+
+				err := j.Join(types.NewDocumentValue(&ptr), fn)
+				if errors.Is(err, stream.ErrStreamClosed) {
+					closed = true
+				}
+				return err
+			})
+			if closed {
+				return errors.WithStack(stream.ErrStreamClosed)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return j.Done(fn)
+	})
+}
+
+func (op *JoinOperator) String() string {
+	var s strings.Builder
+
+	if op.Left {
+		s.WriteString("index.LeftJoin(")
+	} else {
+		s.WriteString("index.Join(")
+	}
+	s.WriteString(strconv.Quote(op.IndexName))
+	s.WriteString(", ")
+	s.WriteString(strconv.Quote(op.Alias))
+	s.WriteString(", ")
+	s.WriteString(op.Key.String())
+	if op.On != nil {
+		s.WriteString(", ")
+		s.WriteString(op.On.String())
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
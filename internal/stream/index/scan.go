@@ -6,6 +6,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
@@ -24,6 +25,10 @@ type ScanOperator struct {
 	Ranges stream.Ranges
 	// Reverse indicates the direction used to traverse the index.
 	Reverse bool
+	// IndexOnly indicates that the documents are built from the values stored
+	// in the index and in the primary key, instead of being read from the table.
+	// It is set by the planner when the index contains every path used by the query.
+	IndexOnly bool
 }
 
 // Scan creates an iterator that iterates over each document of the given table.
@@ -38,7 +43,7 @@ func ScanReverse(name string, ranges ...stream.Range) *ScanOperator {
 
 // Iterate over the documents of the table. Each document is stored in the environment
 // that is passed to the fn function, using SetCurrentValue.
-func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
 	catalog := in.GetCatalog()
 	tx := in.GetTx()
 
@@ -64,16 +69,65 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 	ptr := DocumentPointer{
 		Table: table,
 	}
-	newEnv.SetDocument(&ptr)
 
-	if len(it.Ranges) == 0 {
-		return index.IterateOnRange(nil, it.Reverse, func(key *tree.Key) error {
-			ptr.key = key
-			ptr.Doc = nil
-			newEnv.SetKey(key)
+	// multikey indexes store one entry per element of an array,
+	// keys of the documents already returned are stored to remove duplicates
+	var seen *tree.Tree
+	if info.MultikeyPos() >= 0 {
+		var cleanup func() error
+		seen, cleanup, err = tree.NewTransient(in.GetDB().Store.NewTransientSession(), catalog.GetFreeTransientNamespace())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if e := cleanup(); err == nil {
+				err = e
+			}
+		}()
+	}
+
+	var pk *database.PrimaryKey
+	var fb document.FieldBuffer
+	if it.IndexOnly {
+		pk = table.Info.GetPrimaryKey()
+	}
+
+	visit := func(vs []types.Value, key *tree.Key) error {
+		if seen != nil {
+			k := tree.NewKey(types.NewBlobValue(key.Encoded))
+			exists, err := seen.Exists(k)
+			if err != nil || exists {
+				return err
+			}
+
+			err = seen.Put(k, nil)
+			if err != nil {
+				return err
+			}
+		}
 
-			return fn(&newEnv)
-		})
+		newEnv.SetKey(key)
+
+		if it.IndexOnly {
+			ok, err := documentFromKey(&fb, info, pk, vs, key)
+			if err != nil {
+				return err
+			}
+			if ok {
+				newEnv.SetDocument(&fb)
+				return fn(&newEnv)
+			}
+		}
+
+		ptr.key = key
+		ptr.Doc = nil
+		newEnv.SetDocument(&ptr)
+
+		return fn(&newEnv)
+	}
+
+	if len(it.Ranges) == 0 {
+		return index.IterateValuesOnRange(nil, it.Reverse, visit)
 	}
 
 	ranges, err := it.Ranges.Eval(in)
@@ -87,13 +141,7 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 			return err
 		}
 
-		err = index.IterateOnRange(r, it.Reverse, func(key *tree.Key) error {
-			ptr.key = key
-			ptr.Doc = nil
-			newEnv.SetKey(key)
-
-			return fn(&newEnv)
-		})
+		err = index.IterateValuesOnRange(r, it.Reverse, visit)
 		if errors.Is(err, stream.ErrStreamClosed) {
 			err = nil
 		}
@@ -105,10 +153,62 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 	return nil
 }
 
+// documentFromKey fills fb with the indexed values and the values of the primary key.
+// Only top-level fields are set, nested paths and indexed expressions are ignored.
+// It returns false if the document must be read from the table because
+// the encoding of the values in the key lost some information:
+// decimals are stored without their scale.
+func documentFromKey(fb *document.FieldBuffer, info *database.IndexInfo, pk *database.PrimaryKey, vs []types.Value, key *tree.Key) (bool, error) {
+	fb.Reset()
+
+	if pk != nil {
+		pvs, err := key.Decode()
+		if err != nil {
+			return false, err
+		}
+
+		for i, v := range pvs {
+			if len(pk.Paths[i]) != 1 {
+				continue
+			}
+
+			if v.Type() == types.DecimalValue {
+				return false, nil
+			}
+
+			err = fb.Set(pk.Paths[i], v)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	for i, v := range vs {
+		if len(info.Paths[i]) != 1 {
+			continue
+		}
+
+		if v.Type() == types.DecimalValue {
+			return false, nil
+		}
+
+		err := fb.Set(info.Paths[i], v)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
 func (it *ScanOperator) String() string {
 	var s strings.Builder
 
-	s.WriteString("index.Scan")
+	s.WriteString("index.")
+	if it.IndexOnly {
+		s.WriteString("IndexOnly")
+	}
+	s.WriteString("Scan")
 	if it.Reverse {
 		s.WriteString("Reverse")
 	}
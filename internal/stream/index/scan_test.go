@@ -37,6 +37,9 @@ func TestIndexScan(t *testing.T) {
 			op.Reverse = true
 
 			require.Equal(t, `index.ScanReverse("idx_test_a", [{"min": [1], "max": [2]}])`, op.String())
+
+			op.IndexOnly = true
+			require.Equal(t, `index.IndexOnlyScanReverse("idx_test_a", [{"min": [1], "max": [2]}])`, op.String())
 		})
 
 		t.Run("idx_test_a_b", func(t *testing.T) {
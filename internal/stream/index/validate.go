@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/errors"
-	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/types"
@@ -47,35 +46,41 @@ func (op *ValidateOperator) Iterate(in *environment.Environment, fn func(out *en
 			return errors.New("missing document")
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
-
-		// if the indexes values contain NULL somewhere,
-		// we don't check for unicity.
-		// cf: https://sqlite.org/lang_createindex.html#unique_indexes
-		var hasNull bool
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(doc)
-			if err != nil {
-				hasNull = true
-				v = types.NewNullValue()
-			} else if v.Type() == types.NullValue {
-				hasNull = true
-			}
+		// unicity is only enforced among the documents matching the predicate of a partial index
+		ok, err := info.Matches(tx, doc)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fn(out)
+		}
 
-			vs = append(vs, v)
+		entries, err := info.Entries(tx, doc)
+		if err != nil {
+			return err
 		}
 
-		if !hasNull {
+		for _, vs := range entries {
+			// if the indexes values contain NULL somewhere,
+			// we don't check for unicity.
+			// cf: https://sqlite.org/lang_createindex.html#unique_indexes
+			var hasNull bool
+			for _, v := range vs {
+				if v.Type() == types.NullValue {
+					hasNull = true
+					break
+				}
+			}
+			if hasNull {
+				continue
+			}
+
 			duplicate, key, err := idx.Exists(vs)
 			if err != nil {
 				return err
 			}
 			if duplicate {
-				return &database.ConstraintViolationError{
-					Constraint: "UNIQUE",
-					Paths:      info.Paths,
-					Key:        key,
-				}
+				return info.UniqueViolation(key)
 			}
 		}
 
@@ -0,0 +1,228 @@
+package stream
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/kv"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// IntersectOperator is an operator that returns the documents
+// returned by all of its streams.
+type IntersectOperator struct {
+	BaseOperator
+	Streams []*Stream
+	All     bool
+}
+
+// Intersect returns a new IntersectOperator. Duplicate documents are removed.
+func Intersect(s ...*Stream) *IntersectOperator {
+	return &IntersectOperator{Streams: s}
+}
+
+// IntersectAll returns a new IntersectOperator that keeps duplicate documents:
+// a document returned n times by every stream is returned n times.
+func IntersectAll(s ...*Stream) *IntersectOperator {
+	return &IntersectOperator{Streams: s, All: true}
+}
+
+// Iterate counts the documents of the first stream in a temporary tree.
+// Then, for every other stream, only the documents already present in the tree
+// are counted in a new tree, which replaces the previous one.
+func (it *IntersectOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
+	c := counter{all: it.All}
+	defer func() {
+		if e := c.cleanup(); err == nil {
+			err = e
+		}
+	}()
+
+	if len(it.Streams) == 0 {
+		return nil
+	}
+
+	prev, err := c.count(in, it.Streams[0])
+	if err != nil {
+		return err
+	}
+
+	for _, s := range it.Streams[1:] {
+		cur, err := c.newTree(in)
+		if err != nil {
+			return err
+		}
+
+		err = s.Iterate(in, func(out *environment.Environment) error {
+			key, err := documentKey(out)
+			if err != nil {
+				return err
+			}
+
+			max, err := getCount(prev, key)
+			if err != nil || max == 0 {
+				return err
+			}
+
+			// the encoded key contains the namespace of the previous tree
+			key = tree.NewKey(key.Values...)
+
+			n, err := getCount(cur, key)
+			if err != nil || n >= max {
+				return err
+			}
+
+			return putCount(cur, key, n+1)
+		})
+		if err != nil {
+			return err
+		}
+
+		prev = cur
+	}
+
+	return emitCounted(in, prev, fn)
+}
+
+func (it *IntersectOperator) String() string {
+	return setOperatorString("intersect", it.All, it.Streams)
+}
+
+// counter stores the number of occurrences of documents in temporary trees.
+type counter struct {
+	all      bool
+	cleanups []func() error
+}
+
+func (c *counter) newTree(in *environment.Environment) (*tree.Tree, error) {
+	db := in.GetDB()
+	catalog := in.GetCatalog()
+
+	tr, cleanup, err := tree.NewTransient(db.Store.NewTransientSession(), catalog.GetFreeTransientNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	c.cleanups = append(c.cleanups, cleanup)
+	return tr, nil
+}
+
+// count stores the documents of the stream in a new temporary tree,
+// along with the number of times they were returned.
+// If duplicates are not kept, the count of every document is 1.
+func (c *counter) count(in *environment.Environment, s *Stream) (*tree.Tree, error) {
+	tr, err := c.newTree(in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Iterate(in, func(out *environment.Environment) error {
+		key, err := documentKey(out)
+		if err != nil {
+			return err
+		}
+
+		n, err := getCount(tr, key)
+		if err != nil {
+			return err
+		}
+		if n > 0 && !c.all {
+			return nil
+		}
+
+		return putCount(tr, key, n+1)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tr, nil
+}
+
+func (c *counter) cleanup() error {
+	var err error
+	for _, cleanup := range c.cleanups {
+		if e := cleanup(); err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+func documentKey(out *environment.Environment) (*tree.Key, error) {
+	doc, ok := out.GetDocument()
+	if !ok {
+		return nil, errors.New("missing document")
+	}
+
+	return tree.NewKey(types.NewDocumentValue(doc)), nil
+}
+
+func getCount(tr *tree.Tree, key *tree.Key) (uint64, error) {
+	v, err := tr.Get(key)
+	if errors.Is(err, kv.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	n, _ := binary.Uvarint(v)
+	return n, nil
+}
+
+func putCount(tr *tree.Tree, key *tree.Key, n uint64) error {
+	var buf [binary.MaxVarintLen64]byte
+	return tr.Put(key, buf[:binary.PutUvarint(buf[:], n)])
+}
+
+// emitCounted returns every document of the tree as many times as it was counted.
+func emitCounted(in *environment.Environment, tr *tree.Tree, fn func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+
+	return tr.IterateOnRange(nil, false, func(key *tree.Key, v []byte) error {
+		n, _ := binary.Uvarint(v)
+		if n == 0 {
+			return nil
+		}
+
+		values, err := key.Decode()
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetDocument(types.As[types.Document](values[0]))
+		for i := uint64(0); i < n; i++ {
+			err = fn(&newEnv)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func setOperatorString(name string, all bool, streams []*Stream) string {
+	var s strings.Builder
+
+	s.WriteString(name)
+	if all {
+		s.WriteString("All")
+	}
+	s.WriteRune('(')
+	for i, st := range streams {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		s.WriteString(st.String())
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
@@ -0,0 +1,201 @@
+package stream
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/types"
+)
+
+// A NestedLoopJoinOperator joins every document of the stream with
+// every document returned by the Right stream, using a nested loop.
+// Documents produced by the Right stream are stored in the resulting document
+// under the Alias field.
+type NestedLoopJoinOperator struct {
+	BaseOperator
+	Alias string
+	Right *Stream
+	On    expr.Expr
+	// If Left is true, documents of the stream that don't match with
+	// any document of the Right stream are still returned, with a NULL
+	// value stored under the Alias field.
+	Left bool
+}
+
+// NestedLoopJoin returns a NestedLoopJoinOperator that performs an inner join.
+func NestedLoopJoin(alias string, right *Stream, on expr.Expr) *NestedLoopJoinOperator {
+	return &NestedLoopJoinOperator{Alias: alias, Right: right, On: on}
+}
+
+// NestedLoopLeftJoin returns a NestedLoopJoinOperator that performs a left outer join.
+func NestedLoopLeftJoin(alias string, right *Stream, on expr.Expr) *NestedLoopJoinOperator {
+	return &NestedLoopJoinOperator{Alias: alias, Right: right, On: on, Left: true}
+}
+
+// Iterate iterates over the Right stream for each document of the stream
+// and returns the documents that satisfy the On condition.
+func (op *NestedLoopJoinOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	j := NewJoiner(op.Alias, op.On, op.Left)
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		j.Reset(out)
+
+		// the right stream might swallow ErrStreamClosed
+		// so we need to keep track of it
+		var closed bool
+		err := op.Right.Iterate(out, func(rout *environment.Environment) error {
+			d, ok := rout.GetDocument()
+			if !ok {
+				return errors.New("missing document")
+			}
+
+			err := j.Join(types.NewDocumentValue(d), fn)
+			if errors.Is(err, ErrStreamClosed) {
+				closed = true
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if closed {
+			return errors.WithStack(ErrStreamClosed)
+		}
+
+		return j.Done(fn)
+	})
+}
+
+func (op *NestedLoopJoinOperator) String() string {
+	var s strings.Builder
+
+	if op.Left {
+		s.WriteString("nestedLoopLeftJoin(")
+	} else {
+		s.WriteString("nestedLoopJoin(")
+	}
+	s.WriteString(strconv.Quote(op.Alias))
+	s.WriteString(", ")
+	s.WriteString(op.Right.String())
+	if op.On != nil {
+		s.WriteString(", ")
+		s.WriteString(op.On.String())
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
+
+// A Joiner builds the documents resulting from the join of a document
+// with other documents.
+// It is used by join operators to share the same semantics.
+type Joiner struct {
+	alias   string
+	on      expr.Expr
+	left    bool
+	matched bool
+	outer   *environment.Environment
+	fb      document.FieldBuffer
+	env     environment.Environment
+	// vars holds the aliases of the tables joined so far,
+	// including the alias of the joiner.
+	vars *document.FieldBuffer
+}
+
+// NewJoiner creates a Joiner that stores joined documents under
+// the given alias and filters them using the on expression.
+// If left is true, the Joiner performs a left outer join.
+func NewJoiner(alias string, on expr.Expr, left bool) *Joiner {
+	return &Joiner{
+		alias: alias,
+		on:    on,
+		left:  left,
+	}
+}
+
+// Reset prepares the joiner for the document of the given environment.
+func (j *Joiner) Reset(outer *environment.Environment) {
+	j.matched = false
+	j.outer = outer
+	j.env = environment.Environment{}
+	j.env.SetOuter(outer)
+}
+
+// Join creates a document with the fields of the current document
+// and the given value stored under the alias field.
+// If the result satisfies the join condition, it calls fn.
+func (j *Joiner) Join(v types.Value, fn func(out *environment.Environment) error) error {
+	err := j.build(v)
+	if err != nil {
+		return err
+	}
+
+	if j.on != nil {
+		v, err := j.on.Eval(&j.env)
+		if err != nil {
+			return err
+		}
+
+		ok, err := types.IsTruthy(v)
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	j.matched = true
+	return fn(&j.env)
+}
+
+// Done must be called once all the documents have been joined
+// with the current document. In the case of a left join, it calls fn
+// with a NULL value stored under the alias field if no document matched.
+func (j *Joiner) Done(fn func(out *environment.Environment) error) error {
+	if !j.left || j.matched {
+		return nil
+	}
+
+	err := j.build(types.NewNullValue())
+	if err != nil {
+		return err
+	}
+
+	return fn(&j.env)
+}
+
+func (j *Joiner) build(v types.Value) error {
+	j.fb.Reset()
+
+	d, ok := j.outer.GetDocument()
+	if !ok {
+		return errors.New("missing document")
+	}
+
+	err := j.fb.ScanDocument(d)
+	if err != nil {
+		return err
+	}
+
+	j.fb.Add(j.alias, v)
+	j.env.SetDocument(&j.fb)
+
+	// the aliases are the same for every document
+	if j.vars == nil {
+		var aliases document.ValueBuffer
+		if a, ok := j.outer.GetAliases(); ok {
+			err = aliases.Copy(a)
+			if err != nil {
+				return err
+			}
+		}
+		aliases.Append(types.NewTextValue(j.alias))
+
+		j.vars = document.NewFieldBuffer().Add(environment.AliasesKey[0].FieldName, types.NewArrayValue(&aliases))
+	}
+	j.env.Vars = j.vars
+
+	return nil
+}
@@ -3,36 +3,58 @@ package stream
 import (
 	"fmt"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
 )
 
+// ExcludedVar is the name of the variable holding the document
+// that could not be inserted because of a conflict.
+const ExcludedVar = "EXCLUDED"
+
 // OnConflictOperator handles any conflicts that occur during the iteration.
 type OnConflictOperator struct {
 	BaseOperator
 
 	OnConflict *Stream
+	// If Paths is not empty, only conflicts on the primary key
+	// or the unique constraint of these paths are handled.
+	Paths document.Paths
 }
 
-func OnConflict(onConflict *Stream) *OnConflictOperator {
+// OnConflict creates an operator that runs the onConflict stream with the key of the conflicting document.
+// The document that could not be inserted is accessible from the stream using the EXCLUDED variable.
+// The documents returned by the onConflict stream are discarded: they are not passed to the next operators.
+// If onConflict is nil, conflicts are ignored.
+func OnConflict(onConflict *Stream, paths ...document.Path) *OnConflictOperator {
 	return &OnConflictOperator{
 		OnConflict: onConflict,
+		Paths:      paths,
 	}
 }
 
 func (op *OnConflictOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
 	var newEnv environment.Environment
+	excluded := document.NewPath(ExcludedVar)
 
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
 		err := fn(out)
 		if err != nil {
 			if cerr, ok := err.(*database.ConstraintViolationError); ok {
+				if len(op.Paths) > 0 && !op.Paths.IsEqual(cerr.Paths) {
+					return err
+				}
+
 				if op.OnConflict == nil {
 					return nil
 				}
 
 				newEnv.SetOuter(out)
 				newEnv.SetKey(cerr.Key)
+				if d, ok := out.GetDocument(); ok {
+					newEnv.Set(excluded, types.NewDocumentValue(d))
+				}
 
 				err = op.OnConflict.Iterate(&newEnv, func(out *environment.Environment) error { return nil })
 			}
@@ -42,9 +64,14 @@ func (op *OnConflictOperator) Iterate(in *environment.Environment, fn func(out *
 }
 
 func (op *OnConflictOperator) String() string {
-	if op.OnConflict == nil {
-		return "stream.OnConflict(NULL)"
+	s := "NULL"
+	if op.OnConflict != nil {
+		s = op.OnConflict.String()
+	}
+
+	if len(op.Paths) > 0 {
+		return fmt.Sprintf("stream.OnConflict((%s), %s)", op.Paths, s)
 	}
 
-	return fmt.Sprintf("stream.OnConflict(%s)", op.OnConflict)
+	return fmt.Sprintf("stream.OnConflict(%s)", s)
 }
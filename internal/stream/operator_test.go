@@ -352,6 +352,7 @@ func TestTableDelete(t *testing.T) {
 
 	t.Run("String", func(t *testing.T) {
 		require.Equal(t, table.Delete("test").String(), "table.Delete('test')")
+		require.Equal(t, table.DeleteReturning("test").String(), "table.DeleteReturning('test')")
 	})
 }
 
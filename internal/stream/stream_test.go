@@ -2,6 +2,7 @@ package stream_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -138,6 +139,175 @@ func TestUnion(t *testing.T) {
 	})
 }
 
+func TestRecursiveUnion(t *testing.T) {
+	tests := []struct {
+		name     string
+		next     string
+		all      bool
+		expected testutil.Docs
+	}{
+		{"union all", "a + 1", true, testutil.MakeDocuments(t, `{"a": 0}`, `{"a": 1}`, `{"a": 2}`, `{"a": 3}`)},
+		{"union all with duplicates", "a % 2", true, testutil.MakeDocuments(t, `{"a": 0}`, `{"a": 0}`, `{"a": 0}`, `{"a": 0}`)},
+		{"union", "(a + 1) % 3", false, testutil.MakeDocuments(t, `{"a": 0}`, `{"a": 1}`, `{"a": 2}`)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, tx, cleanup := testutil.NewTestTx(t)
+			defer cleanup()
+
+			var wt stream.WorkingTable
+			anchor := stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 0}`)...))
+			rec := stream.New(stream.WorkingTableScan("n", &wt)).
+				Pipe(docs.Filter(parser.MustParseExpr("a < 3"))).
+				Pipe(docs.Project(&expr.NamedExpr{ExprName: "a", Expr: parser.MustParseExpr(test.next)}))
+
+			var st *stream.Stream
+			if test.all {
+				st = stream.New(stream.RecursiveConcat("n", &wt, anchor, rec)).Pipe(docs.Take(parser.MustParseExpr("4")))
+			} else {
+				st = stream.New(stream.RecursiveUnion("n", &wt, anchor, rec))
+			}
+
+			var env environment.Environment
+			env.Tx = tx
+			env.DB = db
+			env.Catalog = db.Catalog
+
+			var got testutil.Docs
+			err := st.Iterate(&env, func(env *environment.Environment) error {
+				d, ok := env.GetDocument()
+				require.True(t, ok)
+
+				clone, err := document.CloneValue(types.NewDocumentValue(d))
+				if err != nil {
+					return err
+				}
+
+				got = append(got, types.As[types.Document](clone))
+				return nil
+			})
+			if errors.Is(err, stream.ErrStreamClosed) {
+				err = nil
+			}
+			assert.NoError(t, err)
+
+			test.expected.RequireEqual(t, got)
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		var wt stream.WorkingTable
+		st := stream.New(stream.RecursiveUnion("n",
+			&wt,
+			stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 1}`)...)),
+			stream.New(stream.WorkingTableScan("n", &wt)),
+		))
+
+		require.Equal(t, `recursiveUnion("n", docs.Emit({a: 1}), workingTable("n"))`, st.String())
+	})
+}
+
+func TestIntersectAndExcept(t *testing.T) {
+	tests := []struct {
+		name          string
+		op            func(s ...*stream.Stream) stream.Operator
+		first, second []expr.Expr
+		expected      testutil.Docs
+	}{
+		{
+			"intersect",
+			func(s ...*stream.Stream) stream.Operator { return stream.Intersect(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 1}`, `{"a": 2}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 1}`, `{"a": 3}`, `{"a": 4}`),
+			testutil.MakeDocuments(t, `{"a": 1}`, `{"a": 3}`),
+		},
+		{
+			"intersect all",
+			func(s ...*stream.Stream) stream.Operator { return stream.IntersectAll(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 1}`, `{"a": 1}`, `{"a": 2}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 1}`, `{"a": 3}`, `{"a": 3}`, `{"a": 4}`),
+			testutil.MakeDocuments(t, `{"a": 1}`, `{"a": 1}`, `{"a": 3}`),
+		},
+		{
+			"intersect empty",
+			func(s ...*stream.Stream) stream.Operator { return stream.Intersect(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 2}`),
+			testutil.ParseExprs(t, `{"a": 3}`),
+			nil,
+		},
+		{
+			"except",
+			func(s ...*stream.Stream) stream.Operator { return stream.Except(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 1}`, `{"a": 2}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 4}`),
+			testutil.MakeDocuments(t, `{"a": 2}`, `{"a": 3}`),
+		},
+		{
+			"except all",
+			func(s ...*stream.Stream) stream.Operator { return stream.ExceptAll(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 1}`, `{"a": 1}`, `{"a": 2}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 3}`, `{"a": 3}`, `{"a": 4}`),
+			testutil.MakeDocuments(t, `{"a": 1}`, `{"a": 1}`, `{"a": 2}`),
+		},
+		{
+			"except different fields",
+			func(s ...*stream.Stream) stream.Operator { return stream.Except(s...) },
+			testutil.ParseExprs(t, `{"a": 1, "b": 1}`, `{"a": 1, "b": 2}`),
+			testutil.ParseExprs(t, `{"a": 1, "b": 1}`, `{"a": 1}`),
+			testutil.MakeDocuments(t, `{"a": 1, "b": 2}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, tx, cleanup := testutil.NewTestTx(t)
+			defer cleanup()
+
+			st := stream.New(test.op(
+				stream.New(docs.Emit(test.first...)),
+				stream.New(docs.Emit(test.second...)),
+			))
+
+			var env environment.Environment
+			env.Tx = tx
+			env.DB = db
+			env.Catalog = db.Catalog
+
+			var got testutil.Docs
+			err := st.Iterate(&env, func(env *environment.Environment) error {
+				d, ok := env.GetDocument()
+				require.True(t, ok)
+
+				clone, err := document.CloneValue(types.NewDocumentValue(d))
+				if err != nil {
+					return err
+				}
+
+				got = append(got, types.As[types.Document](clone))
+				return nil
+			})
+			assert.NoError(t, err)
+			require.Equal(t, len(test.expected), len(got))
+			test.expected.RequireEqual(t, got)
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		st := stream.New(stream.IntersectAll(
+			stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 1}`)...)),
+			stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 2}`)...)),
+		))
+		require.Equal(t, `intersectAll(docs.Emit({a: 1}), docs.Emit({a: 2}))`, st.String())
+
+		st = stream.New(stream.Except(
+			stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 1}`)...)),
+			stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 2}`)...)),
+		))
+		require.Equal(t, `except(docs.Emit({a: 1}), docs.Emit({a: 2}))`, st.String())
+	})
+}
+
 func TestConcatOperator(t *testing.T) {
 	in1 := testutil.ParseExprs(t, `{"a": 10}`, `{"a": 11}`)
 	in2 := testutil.ParseExprs(t, `{"a": 12}`, `{"a": 13}`)
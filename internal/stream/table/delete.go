@@ -6,13 +6,18 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
+	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
 )
 
-// A DeleteOperator replaces documents in the table
+// A DeleteOperator deletes documents from the table.
+// It runs the DELETE triggers of the table and performs the ON DELETE
+// action of the foreign keys referencing it.
 type DeleteOperator struct {
 	stream.BaseOperator
-	Name string
+	Name      string
+	Returning bool
 }
 
 // Delete deletes documents from the table. Incoming documents must implement the document.Keyer interface.
@@ -20,17 +25,30 @@ func Delete(tableName string) *DeleteOperator {
 	return &DeleteOperator{Name: tableName}
 }
 
+// DeleteReturning deletes documents from the table and streams the deleted documents,
+// for the RETURNING clause.
+func DeleteReturning(tableName string) *DeleteOperator {
+	return &DeleteOperator{Name: tableName, Returning: true}
+}
+
 // Iterate implements the Operator interface.
 func (op *DeleteOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+
 	var table *database.Table
+	var triggers *tableTriggers
+	var referenced bool
 
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		catalog := out.GetCatalog()
 		if table == nil {
 			var err error
-			table, err = out.GetCatalog().GetTable(out.GetTx(), op.Name)
+			table, err = catalog.GetTable(out.GetTx(), op.Name)
 			if err != nil {
 				return err
 			}
+			triggers = loadTriggers(out, op.Name, database.TriggerDelete)
+			referenced = len(catalog.Cache.GetReferencingConstraints(op.Name)) > 0
 		}
 
 		key, ok := out.GetKey()
@@ -38,15 +56,59 @@ func (op *DeleteOperator) Iterate(in *environment.Environment, f func(out *envir
 			return errors.New("missing key")
 		}
 
-		err := table.Delete(key)
+		// the document is only read if it is used by triggers, foreign keys or
+		// the RETURNING clause. It must be read before being deleted, since the document
+		// of the environment might be loaded lazily and must remain readable by the next operators
+		var old types.Document
+		if op.Returning || !triggers.isEmpty() || referenced {
+			var err error
+			old, err = table.GetDocument(key)
+			if errs.IsNotFoundError(err) {
+				// the document was deleted by a foreign key action
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		err := triggers.runBefore(out, old, nil)
+		if err != nil {
+			return err
+		}
+
+		err = table.Delete(key)
 		if err != nil {
 			return err
 		}
 
-		return f(out)
+		if referenced {
+			err = catalog.OnDelete(out.GetDB(), out.GetTx(), out.GetTriggerDepth(), op.Name, old)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = triggers.runAfter(out, old, nil)
+		if err != nil {
+			return err
+		}
+
+		if !op.Returning {
+			return f(out)
+		}
+
+		newEnv.SetOuter(out)
+		newEnv.SetDocument(old)
+
+		return f(&newEnv)
 	})
 }
 
 func (op *DeleteOperator) String() string {
+	if op.Returning {
+		return fmt.Sprintf("table.DeleteReturning('%s')", op.Name)
+	}
+
 	return fmt.Sprintf("table.Delete('%s')", op.Name)
 }
@@ -11,6 +11,7 @@ import (
 )
 
 // A InsertOperator inserts incoming documents to the table.
+// It runs the INSERT triggers of the table.
 type InsertOperator struct {
 	stream.BaseOperator
 	Name string
@@ -27,6 +28,7 @@ func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *envir
 	newEnv.Set(environment.TableKey, types.NewTextValue(op.Name))
 
 	var table *database.Table
+	var triggers *tableTriggers
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
 		newEnv.SetOuter(out)
 
@@ -41,6 +43,12 @@ func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *envir
 			if err != nil {
 				return err
 			}
+			triggers = loadTriggers(out, op.Name, database.TriggerInsert)
+		}
+
+		err = triggers.runBefore(out, nil, d)
+		if err != nil {
+			return err
 		}
 
 		key, d, err := table.Insert(d)
@@ -48,6 +56,11 @@ func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *envir
 			return err
 		}
 
+		err = triggers.runAfter(out, nil, d)
+		if err != nil {
+			return err
+		}
+
 		newEnv.SetKey(key)
 		newEnv.SetDocument(d)
 
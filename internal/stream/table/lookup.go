@@ -0,0 +1,63 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/environment"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/stream"
+)
+
+// A LookupOperator reads the document stored at the key of the environment.
+type LookupOperator struct {
+	stream.BaseOperator
+	Name string
+}
+
+// Lookup reads the documents of the table stored at the key of each environment.
+// It can be used as the first operator of a stream.
+func Lookup(tableName string) *LookupOperator {
+	return &LookupOperator{Name: tableName}
+}
+
+// Iterate implements the Operator interface.
+func (op *LookupOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+
+	it := func(out *environment.Environment) error {
+		key, ok := out.GetKey()
+		if !ok {
+			return errors.New("missing key")
+		}
+
+		table, err := out.GetCatalog().GetTable(out.GetTx(), op.Name)
+		if err != nil {
+			return err
+		}
+
+		d, err := table.GetDocument(key)
+		if errs.IsNotFoundError(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetOuter(out)
+		newEnv.SetKey(key)
+		newEnv.SetDocument(d)
+
+		return f(&newEnv)
+	}
+
+	if op.Prev == nil {
+		return it(in)
+	}
+
+	return op.Prev.Iterate(in, it)
+}
+
+func (op *LookupOperator) String() string {
+	return fmt.Sprintf("table.Lookup(%q)", op.Name)
+}
@@ -6,36 +6,50 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
+	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
 )
 
-// A ReplaceOperator replaces documents in the table
+// A ReplaceOperator replaces documents in the table.
+// It runs the UPDATE triggers of the table and performs the ON UPDATE
+// action of the foreign keys referencing it.
 type ReplaceOperator struct {
 	stream.BaseOperator
 	Name string
 }
 
 // Replace replaces documents in the table. Incoming documents must implement the document.Keyer interface.
+// If the primary key of a document is modified, it is stored under its new key.
 func Replace(tableName string) *ReplaceOperator {
 	return &ReplaceOperator{Name: tableName}
 }
 
 // Iterate implements the Operator interface.
 func (op *ReplaceOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+
 	var table *database.Table
+	var triggers *tableTriggers
+	var referenced bool
 
 	it := func(out *environment.Environment) error {
+		newEnv.SetOuter(out)
+
 		d, ok := out.GetDocument()
 		if !ok {
 			return errors.New("missing document")
 		}
 
+		catalog := out.GetCatalog()
 		if table == nil {
 			var err error
-			table, err = out.GetCatalog().GetTable(out.GetTx(), op.Name)
+			table, err = catalog.GetTable(out.GetTx(), op.Name)
 			if err != nil {
 				return err
 			}
+			triggers = loadTriggers(out, op.Name, database.TriggerUpdate)
+			referenced = len(catalog.Cache.GetReferencingConstraints(op.Name)) > 0
 		}
 
 		key, ok := out.GetKey()
@@ -43,12 +57,45 @@ func (op *ReplaceOperator) Iterate(in *environment.Environment, f func(out *envi
 			return errors.New("missing key")
 		}
 
-		_, err := table.Replace(key, d)
+		var old types.Document
+		if !triggers.isEmpty() || referenced {
+			var err error
+			old, err = table.GetDocument(key)
+			if errs.IsNotFoundError(err) {
+				// the document was deleted by a foreign key action
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		err := triggers.runBefore(out, old, d)
 		if err != nil {
 			return err
 		}
 
-		return f(out)
+		key, d, err = table.Update(key, d)
+		if err != nil {
+			return err
+		}
+
+		if referenced {
+			err = catalog.OnUpdate(out.GetDB(), out.GetTx(), out.GetTriggerDepth(), op.Name, old, d)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = triggers.runAfter(out, old, d)
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetKey(key)
+		newEnv.SetDocument(d)
+
+		return f(&newEnv)
 	}
 
 	if op.Prev == nil {
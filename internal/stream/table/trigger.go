@@ -0,0 +1,46 @@
+package table
+
+import (
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// tableTriggers holds the triggers of a table fired by a given event.
+type tableTriggers struct {
+	before []*database.TriggerInfo
+	after  []*database.TriggerInfo
+}
+
+func loadTriggers(env *environment.Environment, tableName, event string) *tableTriggers {
+	c := env.GetCatalog()
+
+	return &tableTriggers{
+		before: c.GetTableTriggers(tableName, database.TriggerBefore, event),
+		after:  c.GetTableTriggers(tableName, database.TriggerAfter, event),
+	}
+}
+
+// isEmpty returns true if there are no triggers to run.
+func (t *tableTriggers) isEmpty() bool {
+	return len(t.before) == 0 && len(t.after) == 0
+}
+
+func (t *tableTriggers) runBefore(env *environment.Environment, oldDoc, newDoc types.Document) error {
+	return runTriggers(env, t.before, oldDoc, newDoc)
+}
+
+func (t *tableTriggers) runAfter(env *environment.Environment, oldDoc, newDoc types.Document) error {
+	return runTriggers(env, t.after, oldDoc, newDoc)
+}
+
+func runTriggers(env *environment.Environment, triggers []*database.TriggerInfo, oldDoc, newDoc types.Document) error {
+	for _, t := range triggers {
+		err := t.Body.Run(env.GetDB(), env.GetTx(), env.GetCatalog(), env.GetTriggerDepth(), oldDoc, newDoc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
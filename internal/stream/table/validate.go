@@ -64,6 +64,12 @@ func (op *ValidateOperator) Iterate(in *environment.Environment, fn func(out *en
 			return err
 		}
 
+		// ensure the documents referenced by foreign keys exist
+		err = catalog.ValidateForeignKeys(tx, info, doc)
+		if err != nil {
+			return err
+		}
+
 		return fn(&newEnv)
 	})
 }
@@ -71,3 +77,45 @@ func (op *ValidateOperator) Iterate(in *environment.Environment, fn func(out *en
 func (op *ValidateOperator) String() string {
 	return fmt.Sprintf("table.Validate(%q)", op.tableName)
 }
+
+// ValidatePrimaryKeyOperator ensures incoming documents don't have the same primary key
+// as a document of the table.
+type ValidatePrimaryKeyOperator struct {
+	stream.BaseOperator
+
+	tableName string
+}
+
+// ValidatePrimaryKey returns an operator that ensures incoming documents don't have
+// the same primary key as a document of the table.
+// It is used to detect conflicts on the primary key before those on other constraints.
+func ValidatePrimaryKey(tableName string) *ValidatePrimaryKeyOperator {
+	return &ValidatePrimaryKeyOperator{
+		tableName: tableName,
+	}
+}
+
+func (op *ValidatePrimaryKeyOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	t, err := in.GetCatalog().GetTable(in.GetTx(), op.tableName)
+	if err != nil {
+		return err
+	}
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		doc, ok := out.GetDocument()
+		if !ok {
+			return errors.New("missing document")
+		}
+
+		err := t.ValidatePrimaryKey(doc)
+		if err != nil {
+			return err
+		}
+
+		return fn(out)
+	})
+}
+
+func (op *ValidatePrimaryKeyOperator) String() string {
+	return fmt.Sprintf("table.ValidatePrimaryKey(%q)", op.tableName)
+}
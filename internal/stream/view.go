@@ -0,0 +1,20 @@
+package stream
+
+import (
+	"fmt"
+)
+
+// A ViewOperator iterates over the documents of a view.
+type ViewOperator struct {
+	CTEOperator
+}
+
+// View returns an operator that iterates over the documents returned by
+// the stream of the view with the given name.
+func View(name string, s *Stream) *ViewOperator {
+	return &ViewOperator{CTEOperator{Name: name, Stream: s}}
+}
+
+func (op *ViewOperator) String() string {
+	return fmt.Sprintf("view(%q, %s)", op.Name, op.Stream)
+}
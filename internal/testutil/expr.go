@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/environment"
@@ -43,6 +44,11 @@ func DoubleValue(v float64) expr.LiteralValue {
 	return expr.LiteralValue{Value: types.NewDoubleValue(v)}
 }
 
+// TimestampValue creates a literal value of type Timestamp.
+func TimestampValue(v time.Time) expr.LiteralValue {
+	return expr.LiteralValue{Value: types.NewTimestampValue(v)}
+}
+
 // NullValue creates a literal value of type Null.
 func NullValue() expr.LiteralValue {
 	return expr.LiteralValue{Value: types.NewNullValue()}
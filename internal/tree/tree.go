@@ -3,6 +3,7 @@ package tree
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
@@ -279,6 +280,12 @@ func (t *Tree) NewMinValueForType(tp types.ValueType) types.Value {
 		return types.NewIntegerValue(math.MinInt64)
 	case types.DoubleValue:
 		return types.NewDoubleValue(-math.MaxFloat64)
+	case types.DecimalValue:
+		// decimals have no minimum, an empty value is encoded
+		// as the type only, which sorts before any decimal
+		return types.NewValueWith[any](types.DecimalValue, nil)
+	case types.TimestampValue:
+		return types.NewTimestampValue(time.UnixMicro(math.MinInt64))
 	case types.TextValue:
 		return types.NewTextValue("")
 	case types.BlobValue:
@@ -302,6 +309,10 @@ func (t *Tree) NewMaxTypeForType(tp types.ValueType) types.ValueType {
 		return 0xC8 // Integers go from 0x20 to 0xC7
 	case types.DoubleValue:
 		return 0xD2 // Doubles go from 0xD0 to 0xD1
+	case types.DecimalValue:
+		return 0xD5 // DecimalValue = 0xD4
+	case types.TimestampValue:
+		return 0xD9 // TimestampValue = 0xD8
 	case types.TextValue:
 		return 0xDB // TextValue = 0xDA
 	case types.BlobValue:
@@ -0,0 +1,71 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT, c TEXT);
+INSERT INTO test (a, b, c) VALUES (1, 10, "foo"), (2, 20, "bar"), (3, 20, "baz");
+
+-- test: unique
+ALTER TABLE test ADD CONSTRAINT test_c_unique UNIQUE (c);
+SELECT name, sql FROM __genji_catalog WHERE name = "test" OR name = "test_c_idx";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c TEXT, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_c_unique UNIQUE (c))"
+}
+{
+  "name": "test_c_idx",
+  "sql": "CREATE UNIQUE INDEX test_c_idx ON test (c)"
+}
+*/
+
+-- test: unique, existing documents are indexed
+ALTER TABLE test ADD UNIQUE (c);
+INSERT INTO test (a, b, c) VALUES (4, 40, "foo");
+-- error: UNIQUE constraint error: [c]
+
+-- test: unique, duplicate values
+ALTER TABLE test ADD UNIQUE (b);
+-- error: UNIQUE constraint error: [b]
+
+-- test: check
+ALTER TABLE test ADD CONSTRAINT positive CHECK (b > 0);
+INSERT INTO test (a, b) VALUES (4, -1);
+-- error: document violates check constraint "positive"
+
+-- test: check, invalid documents
+ALTER TABLE test ADD CHECK (b > 10);
+-- error: document violates check constraint "test_check"
+
+-- test: foreign key
+CREATE TABLE parent(id INT PRIMARY KEY);
+INSERT INTO parent (id) VALUES (10), (20);
+ALTER TABLE test ADD FOREIGN KEY (b) REFERENCES parent ON DELETE CASCADE;
+DELETE FROM parent WHERE id = 20;
+SELECT a, b FROM test;
+/* result:
+{
+  a: 1,
+  b: 10
+}
+*/
+
+-- test: foreign key, missing referenced documents
+CREATE TABLE parent(id INT PRIMARY KEY);
+INSERT INTO parent (id) VALUES (10);
+ALTER TABLE test ADD FOREIGN KEY (b) REFERENCES parent;
+-- error: FOREIGN KEY constraint "test_b_fkey" error: [b]
+
+-- test: primary key
+ALTER TABLE test ADD PRIMARY KEY (b);
+-- error:
+
+-- test: unknown field
+ALTER TABLE test ADD UNIQUE (d);
+-- error:
+
+-- test: duplicate name
+ALTER TABLE test ADD CONSTRAINT test_pk UNIQUE (b);
+-- error:
+
+-- test: add field with unique constraint
+ALTER TABLE test ADD FIELD d INT UNIQUE;
+INSERT INTO test (a, d) VALUES (4, 1), (5, 1);
+-- error: UNIQUE constraint error: [d]
@@ -0,0 +1,99 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b TEXT, c ANY, d DOCUMENT (e INT, ...), ...);
+INSERT INTO test (a, b, c, d) VALUES (1, "10", 1.5, {e: 100}), (2, "20", 2, {e: 200});
+
+-- test: type
+ALTER TABLE test ALTER FIELD b TYPE INT;
+SELECT name, sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c ANY, d (e INTEGER, ...), CONSTRAINT test_pk PRIMARY KEY (a), ...)"
+}
+*/
+
+-- test: documents are converted
+ALTER TABLE test ALTER FIELD b TYPE INT;
+ALTER TABLE test ALTER FIELD c TYPE TEXT;
+SELECT a, b, c FROM test;
+/* result:
+{
+  a: 1,
+  b: 10,
+  c: "1.5"
+}
+{
+  a: 2,
+  b: 20,
+  c: "2"
+}
+*/
+
+-- test: nested field
+ALTER TABLE test ALTER FIELD d.e TYPE DOUBLE;
+SELECT d FROM test;
+/* result:
+{
+  d: {e: 100.0}
+}
+{
+  d: {e: 200.0}
+}
+*/
+
+-- test: extra field
+INSERT INTO test (a, f) VALUES (3, "30");
+ALTER TABLE test ALTER FIELD f TYPE INT;
+SELECT a, f FROM test WHERE a = 3;
+/* result:
+{
+  a: 3,
+  f: 30
+}
+*/
+
+-- test: new values are converted
+ALTER TABLE test ALTER FIELD b TYPE INT;
+INSERT INTO test (a, b) VALUES (3, 30.5);
+SELECT b FROM test WHERE a = 3;
+/* result:
+{
+  b: 30
+}
+*/
+
+-- test: invalid conversion
+INSERT INTO test (a, b) VALUES (3, "foo");
+ALTER TABLE test ALTER FIELD b TYPE INT;
+-- error:
+
+-- test: index is rebuilt
+CREATE INDEX test_c_idx ON test (c);
+ALTER TABLE test ALTER FIELD c TYPE INT;
+SELECT a FROM test WHERE c = 1;
+/* result:
+{
+  a: 1
+}
+*/
+
+-- test: unique index with duplicate values
+CREATE UNIQUE INDEX test_c_idx ON test (c);
+INSERT INTO test (a, c) VALUES (3, 1.2);
+ALTER TABLE test ALTER FIELD c TYPE INT;
+-- error: UNIQUE constraint error: [c]
+
+-- test: check constraint
+CREATE TABLE other(a TEXT CHECK (a > 1));
+INSERT INTO other (a) VALUES ("1.5");
+ALTER TABLE other ALTER FIELD a TYPE INT;
+-- error: document violates check constraint "other_check"
+
+-- test: primary key
+ALTER TABLE test ALTER FIELD a TYPE DOUBLE;
+-- error:
+
+-- test: incompatible default value
+CREATE TABLE other(a TEXT DEFAULT "foo");
+ALTER TABLE other ALTER FIELD a TYPE INT;
+-- error:
@@ -19,3 +19,16 @@ ALTER TABLE unknown RENAME TO test2;
 CREATE TABLE test2;
 ALTER TABLE test2 RENAME TO test;
 -- error:
+
+-- test: add field with extra fields
+CREATE TABLE test2(a INT, ...);
+INSERT INTO test2 (a, b) VALUES (1, 2);
+ALTER TABLE test2 ADD FIELD c INT DEFAULT 3;
+SELECT * FROM test2;
+/* result:
+{
+  a: 1,
+  c: 3,
+  b: 2.0
+}
+*/
@@ -0,0 +1,54 @@
+-- setup:
+CREATE TABLE parent(id INT PRIMARY KEY, code TEXT UNIQUE);
+CREATE TABLE test(
+    a INT PRIMARY KEY,
+    b INT CHECK (b > 0),
+    c TEXT UNIQUE,
+    d INT REFERENCES parent
+);
+INSERT INTO parent (id, code) VALUES (1, "foo");
+INSERT INTO test (a, b, c, d) VALUES (1, 10, "foo", 1);
+
+-- test: check
+ALTER TABLE test DROP CONSTRAINT test_check;
+INSERT INTO test (a, b) VALUES (2, -1);
+SELECT name, sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c TEXT, d INTEGER, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_c_unique UNIQUE (c), CONSTRAINT test_d_fkey FOREIGN KEY (d) REFERENCES parent (id))"
+}
+*/
+
+-- test: unique
+ALTER TABLE test DROP CONSTRAINT test_c_unique;
+INSERT INTO test (a, c) VALUES (2, "foo");
+SELECT name FROM __genji_catalog WHERE type = "index" AND owner.table_name = "test";
+/* result:
+{
+  name: "test_d_idx"
+}
+*/
+
+-- test: foreign key
+ALTER TABLE test DROP CONSTRAINT test_d_fkey;
+INSERT INTO test (a, d) VALUES (2, 2);
+SELECT name FROM __genji_catalog WHERE type = "index" AND owner.table_name = "test";
+/* result:
+{
+  name: "test_c_idx"
+}
+*/
+
+-- test: unique referenced by a foreign key
+CREATE TABLE child(code TEXT REFERENCES parent(code));
+ALTER TABLE parent DROP CONSTRAINT parent_code_unique;
+-- error: cannot drop constraint parent_code_unique because foreign key child_code_fkey of table child references it
+
+-- test: primary key
+ALTER TABLE test DROP CONSTRAINT test_pk;
+-- error:
+
+-- test: unknown
+ALTER TABLE test DROP CONSTRAINT unknown;
+-- error:
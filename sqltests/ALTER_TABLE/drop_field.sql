@@ -0,0 +1,124 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT, c TEXT NOT NULL, d DOCUMENT (e INT, ...), ...);
+INSERT INTO test (a, b, c, d, f) VALUES (1, 10, "foo", {e: 100, g: 1000.0}, true), (2, 20, "bar", {e: 200}, false);
+
+-- test: constrained field
+ALTER TABLE test DROP FIELD b;
+SELECT name, sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, c TEXT NOT NULL, d (e INTEGER, ...), CONSTRAINT test_pk PRIMARY KEY (a), ...)"
+}
+*/
+
+-- test: documents are encoded again
+ALTER TABLE test DROP FIELD b;
+SELECT * FROM test;
+/* result:
+{
+  a: 1,
+  c: "foo",
+  d: {e: 100, g: 1000.0},
+  f: true
+}
+{
+  a: 2,
+  c: "bar",
+  d: {e: 200},
+  f: false
+}
+*/
+
+-- test: extra field
+ALTER TABLE test DROP FIELD f;
+SELECT * FROM test WHERE a = 1;
+/* result:
+{
+  a: 1,
+  b: 10,
+  c: "foo",
+  d: {e: 100, g: 1000.0}
+}
+*/
+
+-- test: nested field
+ALTER TABLE test DROP FIELD d.e;
+SELECT name, sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c TEXT NOT NULL, d (...), CONSTRAINT test_pk PRIMARY KEY (a), ...)"
+}
+*/
+
+-- test: nested field, documents
+ALTER TABLE test DROP FIELD d.e;
+SELECT d FROM test;
+/* result:
+{
+  d: {g: 1000.0}
+}
+{
+  d: {}
+}
+*/
+
+-- test: primary key
+ALTER TABLE test DROP FIELD a;
+-- error:
+
+-- test: unknown field
+CREATE TABLE other(a INT);
+ALTER TABLE other DROP FIELD b;
+-- error:
+
+-- test: index
+CREATE INDEX test_b_idx ON test(b);
+CREATE INDEX test_c_idx ON test(c);
+ALTER TABLE test DROP FIELD b;
+SELECT name FROM __genji_catalog WHERE type = "index";
+/* result:
+{
+  name: "test_c_idx"
+}
+*/
+
+-- test: index of the parent document
+CREATE INDEX test_d_idx ON test(d);
+ALTER TABLE test DROP FIELD d.g;
+SELECT a FROM test WHERE d = {e: 100};
+/* result:
+{
+  a: 1
+}
+*/
+
+-- test: constraints
+CREATE TABLE other(a INT UNIQUE, b INT CHECK (b > 0), c INT, CHECK (a > c));
+ALTER TABLE other DROP FIELD a;
+SELECT name, sql FROM __genji_catalog WHERE name = "other" OR type = "index" AND owner.table_name = "other";
+/* result:
+{
+  "name": "other",
+  "sql": "CREATE TABLE other (b INTEGER, c INTEGER, CONSTRAINT other_check CHECK (b > 0))"
+}
+*/
+
+-- test: referenced by a foreign key
+CREATE TABLE parent(a INT PRIMARY KEY, b INT UNIQUE);
+CREATE TABLE child(x INT REFERENCES parent(b));
+ALTER TABLE parent DROP FIELD b;
+-- error:
+
+-- test: foreign key
+CREATE TABLE parent(a INT PRIMARY KEY);
+CREATE TABLE child(x INT REFERENCES parent, y INT);
+ALTER TABLE child DROP FIELD x;
+SELECT name, sql FROM __genji_catalog WHERE name = "child" OR type = "index" AND owner.table_name = "child";
+/* result:
+{
+  "name": "child",
+  "sql": "CREATE TABLE child (y INTEGER)"
+}
+*/
@@ -0,0 +1,36 @@
+-- setup:
+CREATE TABLE parent(id INT PRIMARY KEY);
+CREATE TABLE child(a INT REFERENCES parent);
+INSERT INTO parent (id) VALUES (1);
+
+-- test: rename referenced table
+ALTER TABLE parent RENAME TO parent2;
+SELECT name, sql FROM __genji_catalog WHERE name = "child";
+/* result:
+{
+  "name": "child",
+  "sql": "CREATE TABLE child (a INTEGER, CONSTRAINT child_a_fkey FOREIGN KEY (a) REFERENCES parent2 (id))"
+}
+*/
+
+-- test: rename referenced table, still enforced
+ALTER TABLE parent RENAME TO parent2;
+INSERT INTO child (a) VALUES (2);
+-- error: FOREIGN KEY constraint "child_a_fkey" error: [a]
+
+-- test: rename self-referencing table
+CREATE TABLE tree(id INT PRIMARY KEY, parent_id INT REFERENCES tree);
+ALTER TABLE tree RENAME TO tree2;
+SELECT name, sql FROM __genji_catalog WHERE name = "tree2";
+/* result:
+{
+  "name": "tree2",
+  "sql": "CREATE TABLE tree2 (id INTEGER NOT NULL, parent_id INTEGER, CONSTRAINT tree_pk PRIMARY KEY (id), CONSTRAINT tree_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES tree2 (id))"
+}
+*/
+
+-- test: add field
+CREATE TABLE other(b INT);
+ALTER TABLE other ADD FIELD a INT REFERENCES parent;
+INSERT INTO other (a) VALUES (2);
+-- error: FOREIGN KEY constraint "other_a_fkey" error: [a]
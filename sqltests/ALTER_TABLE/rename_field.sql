@@ -0,0 +1,99 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT CHECK (b > 0), c TEXT UNIQUE, d DOCUMENT (e INT, ...), ...);
+INSERT INTO test (a, b, c, d, f) VALUES (1, 10, "foo", {e: 100}, true), (2, 20, "bar", {e: 200}, false);
+
+-- test: constrained field
+ALTER TABLE test RENAME FIELD b TO bb;
+SELECT name, sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, bb INTEGER, c TEXT, d (e INTEGER, ...), CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_check CHECK (bb > 0), CONSTRAINT test_c_unique UNIQUE (c), ...)"
+}
+*/
+
+-- test: documents are renamed
+ALTER TABLE test RENAME FIELD b TO bb;
+ALTER TABLE test RENAME FIELD f TO ff;
+SELECT * FROM test;
+/* result:
+{
+  a: 1,
+  bb: 10,
+  c: "foo",
+  d: {e: 100},
+  ff: true
+}
+{
+  a: 2,
+  bb: 20,
+  c: "bar",
+  d: {e: 200},
+  ff: false
+}
+*/
+
+-- test: check constraint
+ALTER TABLE test RENAME FIELD b TO bb;
+INSERT INTO test (a, bb, c) VALUES (3, -1, "baz");
+-- error: document violates check constraint "test_check"
+
+-- test: unique constraint
+ALTER TABLE test RENAME FIELD c TO cc;
+SELECT name, owner FROM __genji_catalog WHERE type = "index";
+/* result:
+{
+  name: "test_c_idx",
+  owner: {table_name: "test", paths: ["cc"]}
+}
+*/
+
+-- test: unique constraint is enforced
+ALTER TABLE test RENAME FIELD c TO cc;
+INSERT INTO test (a, cc) VALUES (3, "foo");
+-- error: UNIQUE constraint error: [cc]
+
+-- test: index
+CREATE INDEX test_b_idx ON test(b);
+ALTER TABLE test RENAME FIELD b TO bb;
+SELECT a FROM test WHERE bb = 20;
+/* result:
+{
+  a: 2
+}
+*/
+
+-- test: nested field
+ALTER TABLE test RENAME FIELD d.e TO ee;
+SELECT d FROM test;
+/* result:
+{
+  d: {ee: 100}
+}
+{
+  d: {ee: 200}
+}
+*/
+
+-- test: existing field
+ALTER TABLE test RENAME FIELD b TO c;
+-- error:
+
+-- test: existing extra field
+ALTER TABLE test RENAME FIELD b TO f;
+-- error:
+
+-- test: primary key
+ALTER TABLE test RENAME FIELD a TO aa;
+-- error:
+
+-- test: referenced by a foreign key
+CREATE TABLE child(x TEXT REFERENCES test(c));
+ALTER TABLE test RENAME FIELD c TO cc;
+SELECT name, sql FROM __genji_catalog WHERE name = "child";
+/* result:
+{
+  "name": "child",
+  "sql": "CREATE TABLE child (x TEXT, CONSTRAINT child_x_fkey FOREIGN KEY (x) REFERENCES test (cc))"
+}
+*/
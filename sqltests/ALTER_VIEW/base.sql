@@ -0,0 +1,38 @@
+-- setup:
+CREATE TABLE test(a int primary key);
+INSERT INTO test (a) VALUES (1);
+CREATE VIEW v AS SELECT a FROM test;
+
+-- test: rename
+ALTER VIEW v RENAME TO v2;
+SELECT name, sql FROM __genji_catalog WHERE type = "view";
+/* result:
+{
+  "name": "v2",
+  "sql": "CREATE VIEW v2 AS SELECT a FROM test"
+}
+*/
+
+-- test: select after rename
+ALTER VIEW v RENAME TO v2;
+SELECT * FROM v2;
+/* result:
+{"a": 1}
+*/
+
+-- test: non-existing
+ALTER VIEW unknown RENAME TO v2;
+-- error:
+
+-- test: table
+ALTER VIEW test RENAME TO test2;
+-- error:
+
+-- test: duplicate
+ALTER VIEW v RENAME TO test;
+-- error:
+
+-- test: dependent view
+CREATE VIEW v2 AS SELECT a FROM v;
+ALTER VIEW v RENAME TO v3;
+-- error:
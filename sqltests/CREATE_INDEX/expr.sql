@@ -0,0 +1,77 @@
+-- setup:
+CREATE TABLE users (id int PRIMARY KEY, email text, age int);
+INSERT INTO users (id, email, age) VALUES (1, 'Foo@Example.com', 20), (2, 'bar@example.com', 30), (3, NULL, 40);
+
+-- test: catalog
+CREATE INDEX ON users (strings.lower(email));
+CREATE INDEX users_age_idx ON users (id, age / 10);
+SELECT name, sql FROM __genji_catalog WHERE type = "index" ORDER BY name;
+/* result:
+{
+  "name": "users_age_idx",
+  "sql": "CREATE INDEX users_age_idx ON users (id, age / 10)"
+}
+{
+  "name": "users_expr_idx",
+  "sql": "CREATE INDEX users_expr_idx ON users (strings.lower(email))"
+}
+*/
+
+-- test: lookup
+CREATE INDEX users_email_idx ON users (strings.lower(email));
+INSERT INTO users (id, email, age) VALUES (4, 'BAR@example.org', 50);
+UPDATE users SET email = 'baz@example.com' WHERE id = 1;
+DELETE FROM users WHERE id = 2;
+SELECT id FROM users WHERE strings.lower(email) = 'bar@example.org' OR strings.lower(email) = 'baz@example.com' ORDER BY id;
+/* result:
+{"id": 1}
+{"id": 4}
+*/
+
+-- test: range
+CREATE INDEX users_age_idx ON users (age + 1);
+SELECT id FROM users WHERE age + 1 > 21 AND age + 1 <= 41;
+/* result:
+{"id": 2}
+{"id": 3}
+*/
+
+-- test: unique
+CREATE UNIQUE INDEX users_email_idx ON users (strings.lower(email));
+INSERT INTO users (id, email) VALUES (4, 'FOO@example.COM');
+-- error: UNIQUE constraint error: [strings.lower(email)]
+
+-- test: unique with paths and expressions
+CREATE UNIQUE INDEX users_age_email_idx ON users (age, strings.lower(email));
+INSERT INTO users (id, email, age) VALUES (4, 'FOO@example.COM', 20);
+-- error: UNIQUE constraint error: [age strings.lower(email)]
+
+-- test: unique ignores NULL
+CREATE UNIQUE INDEX users_email_idx ON users (strings.lower(email));
+INSERT INTO users (id, email) VALUES (4, NULL);
+SELECT COUNT(*) AS c FROM users WHERE email IS NULL;
+/* result:
+{"c": 2}
+*/
+
+-- test: rename field used by the expression
+CREATE INDEX users_email_idx ON users (strings.lower(email));
+ALTER TABLE users RENAME FIELD email TO mail;
+SELECT sql FROM __genji_catalog WHERE name = "users_email_idx";
+/* result:
+{
+  "sql": "CREATE INDEX users_email_idx ON users (strings.lower(mail))"
+}
+*/
+
+-- test: non deterministic function
+CREATE INDEX ON users (uuid());
+-- error:
+
+-- test: parameter
+CREATE INDEX ON users (age + ?);
+-- error:
+
+-- test: aggregate function
+CREATE INDEX ON users (COUNT(age));
+-- error:
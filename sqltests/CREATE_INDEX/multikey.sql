@@ -0,0 +1,99 @@
+-- setup:
+CREATE TABLE products (id int PRIMARY KEY, tags ARRAY, sizes);
+INSERT INTO products (id, tags, sizes) VALUES
+    (1, ['red', 'blue'], [1, 2, 3]),
+    (2, ['green', 'red', 'red'], [3, 4]),
+    (3, [], 5),
+    (4, NULL, [2.5, 10]);
+
+-- test: catalog
+CREATE INDEX ON products (tags[*]);
+CREATE INDEX products_sizes_idx ON products (id, sizes[*]);
+SELECT name, sql FROM __genji_catalog WHERE type = "index" ORDER BY name;
+/* result:
+{
+  "name": "products_sizes_idx",
+  "sql": "CREATE INDEX products_sizes_idx ON products (id, sizes[*])"
+}
+{
+  "name": "products_tags[*]_idx",
+  "sql": "CREATE INDEX `products_tags[*]_idx` ON products (tags[*])"
+}
+*/
+
+-- test: membership
+CREATE INDEX products_tags_idx ON products (tags[*]);
+SELECT id FROM products WHERE 'red' IN tags;
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: element range
+CREATE INDEX products_sizes_idx ON products (sizes[*]);
+SELECT id FROM products WHERE sizes[*] >= 3 ORDER BY id;
+/* result:
+{"id": 1}
+{"id": 2}
+{"id": 4}
+*/
+
+-- test: element between
+CREATE INDEX products_sizes_idx ON products (sizes[*]);
+SELECT id FROM products WHERE sizes[*] BETWEEN 2 AND 3 ORDER BY id;
+/* result:
+{"id": 1}
+{"id": 2}
+{"id": 4}
+*/
+
+-- test: maintenance
+CREATE INDEX products_tags_idx ON products (tags[*]);
+INSERT INTO products (id, tags) VALUES (5, ['blue', 'yellow']);
+UPDATE products SET tags = ['red'] WHERE id = 1;
+DELETE FROM products WHERE id = 2;
+SELECT id FROM products WHERE 'blue' IN tags OR 'red' IN tags ORDER BY id;
+/* result:
+{"id": 1}
+{"id": 5}
+*/
+
+-- test: maintenance with duplicate elements
+CREATE INDEX products_tags_idx ON products (tags[*]);
+UPDATE products SET tags = ['red', 'red', 'blue'] WHERE id = 2;
+DELETE FROM products WHERE id = 2;
+SELECT id FROM products WHERE 'red' IN tags;
+/* result:
+{"id": 1}
+*/
+
+-- test: unique across documents
+DELETE FROM products WHERE id = 2;
+CREATE UNIQUE INDEX products_tags_idx ON products (tags[*]);
+INSERT INTO products (id, tags) VALUES (5, ['yellow', 'blue']);
+-- error:
+
+-- test: unique within a document
+DELETE FROM products WHERE id = 2;
+CREATE UNIQUE INDEX products_tags_idx ON products (tags[*]);
+INSERT INTO products (id, tags) VALUES (5, ['yellow', 'yellow']);
+SELECT id FROM products WHERE 'yellow' IN tags;
+/* result:
+{"id": 5}
+*/
+
+-- test: typed field
+CREATE INDEX ON products (id[*]);
+-- error:
+
+-- test: more than one array
+CREATE INDEX ON products (tags[*], sizes[*]);
+-- error:
+
+-- test: any element with IS
+SELECT id FROM products WHERE tags[*] IS NULL;
+-- error: tags[*] can only be used in comparisons, IN or BETWEEN at line 1, char 31
+
+-- test: any element in projection
+SELECT tags[*] FROM products;
+-- error: tags[*] can only be used in comparisons, IN or BETWEEN at line 1, char 8
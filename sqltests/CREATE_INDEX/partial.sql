@@ -0,0 +1,69 @@
+-- setup:
+CREATE TABLE test (a int, b bool);
+INSERT INTO test (a, b) VALUES (1, true), (2, false), (3, true), (4, NULL);
+
+-- test: catalog
+CREATE INDEX test_a_idx ON test(a) WHERE b;
+SELECT name, sql FROM __genji_catalog WHERE type = "index";
+/* result:
+{
+  "name": "test_a_idx",
+  "sql": "CREATE INDEX test_a_idx ON test (a) WHERE b"
+}
+*/
+
+-- test: existing documents
+CREATE INDEX test_a_idx ON test(a) WHERE b;
+SELECT a FROM test WHERE a > 0 AND b ORDER BY a;
+/* result:
+{"a": 1}
+{"a": 3}
+*/
+
+-- test: insert, update and delete
+CREATE INDEX test_a_idx ON test(a) WHERE b;
+INSERT INTO test (a, b) VALUES (5, true), (6, false);
+UPDATE test SET b = NOT b WHERE a IN [1, 2];
+DELETE FROM test WHERE a = 3;
+SELECT a FROM test WHERE a > 0 AND b ORDER BY a;
+/* result:
+{"a": 2}
+{"a": 5}
+*/
+
+-- test: unique
+CREATE UNIQUE INDEX test_a_idx ON test(a) WHERE b;
+INSERT INTO test (a, b) VALUES (1, true);
+-- error:
+
+-- test: unique ignores documents not matching the predicate
+CREATE UNIQUE INDEX test_a_idx ON test(a) WHERE b;
+INSERT INTO test (a, b) VALUES (1, false), (3, NULL);
+SELECT COUNT(*) AS c FROM test WHERE a IN [1, 3];
+/* result:
+{"c": 4}
+*/
+
+-- test: unique on existing documents
+INSERT INTO test (a, b) VALUES (1, false);
+CREATE UNIQUE INDEX test_a_idx ON test(a) WHERE b;
+INSERT INTO test (a, b) VALUES (1, true);
+-- error:
+
+-- test: rename field used by the predicate
+CREATE INDEX test_a_idx ON test(a) WHERE b;
+ALTER TABLE test RENAME FIELD b TO c;
+SELECT sql FROM __genji_catalog WHERE name = "test_a_idx";
+/* result:
+{
+  "sql": "CREATE INDEX test_a_idx ON test (a) WHERE c"
+}
+*/
+
+-- test: drop field used by the predicate
+CREATE INDEX test_a_idx ON test(a) WHERE b;
+ALTER TABLE test DROP FIELD b;
+SELECT COUNT(*) AS c FROM __genji_catalog WHERE type = "index";
+/* result:
+{"c": 0}
+*/
@@ -77,3 +77,16 @@ SELECT name, type, sql FROM __genji_catalog WHERE name = "test";
   sql: "CREATE TABLE test (a INTEGER, CONSTRAINT test_check CHECK (a > 10), CONSTRAINT test_check1 CHECK (a > 20))"
 }
 */
+
+-- test: with function
+CREATE TABLE test (
+    a TEXT CHECK (strings.lower(a) = a)
+);
+SELECT name, type, sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  name: "test",
+  type: "table",
+  sql: "CREATE TABLE test (a TEXT, CONSTRAINT test_check CHECK (strings.lower(a) = a))"
+}
+*/
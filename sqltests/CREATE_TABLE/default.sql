@@ -67,3 +67,27 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
   "sql": "CREATE TABLE test (a (b INTEGER DEFAULT 10))"
 }
 */
+
+-- test: scalar function
+CREATE TABLE test(a TEXT DEFAULT strings.upper('foo'), b DOUBLE DEFAULT math.floor(2.5));
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a TEXT DEFAULT strings.upper(\"foo\"), b DOUBLE DEFAULT math.floor(2.5))"
+}
+*/
+
+-- test: uuid functions
+CREATE TABLE test(a BLOB PRIMARY KEY DEFAULT uuid(), b BLOB DEFAULT ulid());
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a BLOB NOT NULL DEFAULT uuid(), b BLOB DEFAULT ulid(), CONSTRAINT test_pk PRIMARY KEY (a))"
+}
+*/
+
+-- test: function with path
+CREATE TABLE test(a TEXT DEFAULT strings.upper(b));
+-- error:
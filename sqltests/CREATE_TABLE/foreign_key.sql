@@ -0,0 +1,106 @@
+-- setup:
+CREATE TABLE parent(id INT PRIMARY KEY, code TEXT UNIQUE, name TEXT);
+
+-- test: as field constraint
+CREATE TABLE child (
+    a INT REFERENCES parent
+);
+SELECT name, type, sql FROM __genji_catalog WHERE name = "child";
+/* result:
+{
+  name: "child",
+  type: "table",
+  sql: "CREATE TABLE child (a INTEGER, CONSTRAINT child_a_fkey FOREIGN KEY (a) REFERENCES parent (id))"
+}
+*/
+
+-- test: as field constraint, with paths and actions
+CREATE TABLE child (
+    a TEXT REFERENCES parent(code) ON DELETE CASCADE ON UPDATE SET NULL
+);
+SELECT name, type, sql FROM __genji_catalog WHERE name = "child";
+/* result:
+{
+  name: "child",
+  type: "table",
+  sql: "CREATE TABLE child (a TEXT, CONSTRAINT child_a_fkey FOREIGN KEY (a) REFERENCES parent (code) ON DELETE CASCADE ON UPDATE SET NULL)"
+}
+*/
+
+-- test: as table constraint
+CREATE TABLE child (
+    a INT,
+    CONSTRAINT fk FOREIGN KEY (a) REFERENCES parent (id) ON DELETE RESTRICT
+);
+SELECT name, type, sql FROM __genji_catalog WHERE name = "child";
+/* result:
+{
+  name: "child",
+  type: "table",
+  sql: "CREATE TABLE child (a INTEGER, CONSTRAINT fk FOREIGN KEY (a) REFERENCES parent (id))"
+}
+*/
+
+-- test: index
+CREATE TABLE child (
+    a INT REFERENCES parent
+);
+SELECT name, owner FROM __genji_catalog WHERE type = "index" AND owner.table_name = "child";
+/* result:
+{
+  name: "child_a_idx",
+  owner: {"table_name": "child", "paths": ["a"]}
+}
+*/
+
+-- test: no index on primary key
+CREATE TABLE child (
+    a INT PRIMARY KEY REFERENCES parent
+);
+SELECT COUNT(*) FROM __genji_catalog WHERE type = "index" AND owner.table_name = "child";
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
+
+-- test: self reference
+CREATE TABLE child (
+    id INT PRIMARY KEY,
+    parent_id INT REFERENCES child
+);
+SELECT name, type, sql FROM __genji_catalog WHERE name = "child";
+/* result:
+{
+  name: "child",
+  type: "table",
+  sql: "CREATE TABLE child (id INTEGER NOT NULL, parent_id INTEGER, CONSTRAINT child_pk PRIMARY KEY (id), CONSTRAINT child_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES child (id))"
+}
+*/
+
+-- test: unknown table
+CREATE TABLE child (
+    a INT REFERENCES unknown
+);
+-- error:
+
+-- test: path without unique constraint
+CREATE TABLE child (
+    a TEXT REFERENCES parent(name)
+);
+-- error:
+
+-- test: wrong number of paths
+CREATE TABLE child (
+    a INT,
+    b INT,
+    FOREIGN KEY (a, b) REFERENCES parent (id)
+);
+-- error:
+
+-- test: no primary key
+CREATE TABLE other(a INT);
+CREATE TABLE child (
+    a INT REFERENCES other
+);
+-- error:
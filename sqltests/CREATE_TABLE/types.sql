@@ -48,6 +48,60 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 }
 */
 
+-- test: TIMESTAMP
+CREATE TABLE test (a TIMESTAMP);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a TIMESTAMP)"
+}
+*/
+
+-- test: DATE
+CREATE TABLE test (a DATE);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a DATE)"
+}
+*/
+
+-- test: DECIMAL
+CREATE TABLE test (a DECIMAL);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a DECIMAL)"
+}
+*/
+
+-- test: DECIMAL with precision and scale
+CREATE TABLE test (a DECIMAL(10, 2), b DECIMAL(5));
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a DECIMAL(10, 2), b DECIMAL(5, 0))"
+}
+*/
+
+-- test: DECIMAL ALIAS: NUMERIC
+CREATE TABLE test (a NUMERIC(10, 2));
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a DECIMAL(10, 2))"
+}
+*/
+
+-- test: DECIMAL with scale greater than precision
+CREATE TABLE test (a DECIMAL(2, 3));
+-- error:
+
 -- test: ARRAY
 CREATE TABLE test (a ARRAY);
 SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
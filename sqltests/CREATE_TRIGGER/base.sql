@@ -0,0 +1,174 @@
+-- setup:
+CREATE TABLE test(a int primary key, b text);
+CREATE TABLE audit(op text, old_a int, new_a int, b text);
+
+-- test: catalog
+CREATE TRIGGER t AFTER INSERT ON test FOR EACH ROW BEGIN INSERT INTO audit (op, new_a) VALUES ("insert", NEW.a); END;
+SELECT name, type, sql, owner FROM __genji_catalog WHERE name = "t";
+/* result:
+{
+  "name": "t",
+  "type": "trigger",
+  "sql": "CREATE TRIGGER t AFTER INSERT ON test FOR EACH ROW BEGIN INSERT INTO audit (op, new_a) VALUES (\"insert\", NEW.a); END",
+  "owner": {"table_name": "test"}
+}
+*/
+
+-- test: after insert
+CREATE TRIGGER t AFTER INSERT ON test FOR EACH ROW BEGIN INSERT INTO audit (op, new_a, b) VALUES ("insert", NEW.a, NEW.b); END;
+INSERT INTO test (a, b) VALUES (1, "foo"), (2, "bar");
+SELECT * FROM audit;
+/* result:
+{"op": "insert", "new_a": 1, "b": "foo"}
+{"op": "insert", "new_a": 2, "b": "bar"}
+*/
+
+-- test: before insert
+CREATE TRIGGER t BEFORE INSERT ON test INSERT INTO audit (op, new_a) VALUES ("insert", NEW.a);
+INSERT INTO test (a, b) VALUES (1, "foo");
+SELECT op, new_a FROM audit;
+/* result:
+{"op": "insert", "new_a": 1}
+*/
+
+-- test: update
+INSERT INTO test (a, b) VALUES (1, "foo"), (2, "bar");
+CREATE TRIGGER t AFTER UPDATE ON test FOR EACH ROW BEGIN INSERT INTO audit (op, old_a, new_a, b) VALUES ("update", OLD.a, NEW.a, OLD.b || " -> " || NEW.b); END;
+UPDATE test SET b = "baz" WHERE a = 2;
+SELECT * FROM audit;
+/* result:
+{"op": "update", "old_a": 2, "new_a": 2, "b": "bar -> baz"}
+*/
+
+-- test: delete
+INSERT INTO test (a, b) VALUES (1, "foo"), (2, "bar");
+CREATE TRIGGER t BEFORE DELETE ON test FOR EACH ROW BEGIN INSERT INTO audit (op, old_a, b) VALUES ("delete", OLD.a, OLD.b); END;
+DELETE FROM test;
+SELECT * FROM audit ORDER BY old_a;
+/* result:
+{"op": "delete", "old_a": 1, "b": "foo"}
+{"op": "delete", "old_a": 2, "b": "bar"}
+*/
+
+-- test: multiple statements
+CREATE TABLE counter(n int);
+INSERT INTO counter (n) VALUES (0);
+CREATE TRIGGER t AFTER INSERT ON test FOR EACH ROW
+BEGIN
+  UPDATE counter SET n = n + 1;
+  INSERT INTO audit (op, new_a) VALUES ("insert", NEW.a);
+END;
+INSERT INTO test (a, b) VALUES (1, "foo"), (2, "bar"), (3, "baz");
+SELECT n, (SELECT COUNT(*) FROM audit) AS c FROM counter;
+/* result:
+{"n": 3, "c": 3}
+*/
+
+-- test: multiple triggers
+CREATE TABLE counter(n int);
+INSERT INTO counter (n) VALUES (0);
+CREATE TRIGGER t1 AFTER INSERT ON test UPDATE counter SET n = n + 1;
+CREATE TRIGGER t2 AFTER DELETE ON test UPDATE counter SET n = n - 1;
+INSERT INTO test (a, b) VALUES (1, "foo"), (2, "bar"), (3, "baz");
+DELETE FROM test WHERE a = 2;
+SELECT n FROM counter;
+/* result:
+{"n": 2}
+*/
+
+-- test: subquery
+INSERT INTO test (a, b) VALUES (1, "foo");
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (op, new_a) SELECT "insert", COUNT(*) FROM test WHERE a <= NEW.a;
+INSERT INTO test (a, b) VALUES (2, "bar");
+SELECT op, new_a FROM audit;
+/* result:
+{"op": "insert", "new_a": 2}
+*/
+
+-- test: error aborts the statement
+CREATE TABLE other(a int primary key);
+INSERT INTO other (a) VALUES (1);
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO other (a) VALUES (NEW.a);
+INSERT INTO test (a, b) VALUES (1, "foo");
+-- error:
+
+-- test: recursion
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO test (a) VALUES (NEW.a + 1);
+INSERT INTO test (a) VALUES (1);
+-- error:
+
+-- test: nested executions
+CREATE TABLE seed(n int);
+INSERT INTO seed (n) VALUES (1);
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO test (a) SELECT NEW.a + n FROM seed WHERE NEW.a < 3;
+INSERT INTO test (a) VALUES (1);
+INSERT INTO test (a) VALUES (10);
+SELECT a FROM test;
+/* result:
+{"a": 1}
+{"a": 2}
+{"a": 3}
+{"a": 10}
+*/
+
+-- test: schema changes
+CREATE TABLE counter(id int, n int);
+CREATE INDEX counter_id ON counter(id);
+INSERT INTO counter (id, n) VALUES (1, 0);
+CREATE TRIGGER t AFTER INSERT ON test UPDATE counter SET n = n + 1 WHERE id = 1;
+INSERT INTO test (a) VALUES (1);
+DROP INDEX counter_id;
+INSERT INTO test (a) VALUES (2);
+SELECT n FROM counter;
+/* result:
+{"n": 2}
+*/
+
+-- test: IF NOT EXISTS
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (op) VALUES ("a");
+CREATE TRIGGER IF NOT EXISTS t AFTER INSERT ON test INSERT INTO audit (op) VALUES ("b");
+INSERT INTO test (a) VALUES (1);
+SELECT op FROM audit;
+/* result:
+{"op": "a"}
+*/
+
+-- test: duplicate
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (op) VALUES ("a");
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (op) VALUES ("b");
+-- error:
+
+-- test: unknown table
+CREATE TRIGGER t AFTER INSERT ON unknown INSERT INTO audit (op) VALUES ("a");
+-- error:
+
+-- test: with params
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (op) VALUES (?);
+-- error:
+
+-- test: drop table drops its triggers
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (op) VALUES ("a");
+DROP TABLE test;
+SELECT name FROM __genji_catalog WHERE type = "trigger";
+/* result:
+*/
+
+-- test: rename table
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (op) VALUES ("a");
+ALTER TABLE test RENAME TO test2;
+INSERT INTO test2 (a) VALUES (1);
+SELECT op FROM audit;
+/* result:
+{"op": "a"}
+*/
+
+-- test: update of the primary key
+INSERT INTO test (a, b) VALUES (1, "foo");
+CREATE TRIGGER t1 AFTER UPDATE ON test INSERT INTO audit (op, old_a, new_a) VALUES ("update", OLD.a, NEW.a);
+CREATE TRIGGER t2 AFTER DELETE ON test INSERT INTO audit (op, old_a) VALUES ("delete", OLD.a);
+CREATE TRIGGER t3 AFTER INSERT ON test INSERT INTO audit (op, new_a) VALUES ("insert", NEW.a);
+UPDATE test SET a = 2;
+SELECT * FROM audit;
+/* result:
+{"op": "update", "old_a": 1, "new_a": 2}
+*/
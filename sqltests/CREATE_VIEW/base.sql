@@ -0,0 +1,145 @@
+-- setup:
+CREATE TABLE test(a int primary key, b text, c double);
+INSERT INTO test (a, b, c) VALUES (1, "foo", 1.5), (2, "bar", 2.5), (3, "baz", 3.5);
+
+-- test: basic
+CREATE VIEW v AS SELECT a, b FROM test WHERE a > 1;
+SELECT name, type, sql FROM __genji_catalog WHERE name = "v";
+/* result:
+{
+  "name": "v",
+  "type": "view",
+  "sql": "CREATE VIEW v AS SELECT a, b FROM test WHERE a > 1"
+}
+*/
+
+-- test: select
+CREATE VIEW v AS SELECT a, b FROM test WHERE a > 1;
+SELECT * FROM v;
+/* result:
+{"a": 2, "b": "bar"}
+{"a": 3, "b": "baz"}
+*/
+
+-- test: sees new documents
+CREATE VIEW v AS SELECT a, b FROM test WHERE a > 1;
+INSERT INTO test (a, b, c) VALUES (4, "qux", 4.5);
+SELECT COUNT(*) AS n FROM v;
+/* result:
+{"n": 3}
+*/
+
+-- test: filter and projection
+CREATE VIEW v AS SELECT a, b, c * 2 AS d FROM test;
+SELECT b, d FROM v WHERE d > 4 ORDER BY d DESC;
+/* result:
+{"b": "baz", "d": 7.0}
+{"b": "bar", "d": 5.0}
+*/
+
+-- test: group by
+CREATE VIEW v AS SELECT a % 2 AS odd, COUNT(*) AS n FROM test GROUP BY a % 2;
+SELECT * FROM v WHERE odd = 1;
+/* result:
+{"odd": 1, "n": 2}
+*/
+
+-- test: join
+CREATE TABLE other(a int, label text);
+INSERT INTO other (a, label) VALUES (1, "one"), (3, "three");
+CREATE VIEW v AS SELECT a, label FROM other;
+SELECT test.b AS b, v.label AS label FROM test JOIN v ON test.a = v.a;
+/* result:
+{"b": "foo", "label": "one"}
+{"b": "baz", "label": "three"}
+*/
+
+-- test: view of a view
+CREATE VIEW v1 AS SELECT a, b FROM test WHERE a > 1;
+CREATE VIEW v2 AS SELECT b FROM v1 WHERE a < 3;
+SELECT * FROM v2;
+/* result:
+{"b": "bar"}
+*/
+
+-- test: with common table expression
+CREATE VIEW v AS WITH t AS (SELECT a FROM test WHERE a < 3) SELECT a FROM t;
+SELECT * FROM v;
+/* result:
+{"a": 1}
+{"a": 2}
+*/
+
+-- test: in subquery
+CREATE VIEW v AS SELECT a FROM test WHERE b = "bar";
+SELECT b FROM test WHERE a IN (SELECT a FROM v);
+/* result:
+{"b": "bar"}
+*/
+
+-- test: multiline
+CREATE VIEW v AS
+    SELECT a
+    FROM test
+    WHERE a = 1;
+SELECT sql FROM __genji_catalog WHERE name = "v";
+/* result:
+{
+  "sql": "CREATE VIEW v AS SELECT a\nFROM test\nWHERE a = 1"
+}
+*/
+
+-- test: IF NOT EXISTS
+CREATE VIEW v AS SELECT a FROM test;
+CREATE VIEW IF NOT EXISTS v AS SELECT b FROM test;
+SELECT * FROM v WHERE a = 1;
+/* result:
+{"a": 1}
+*/
+
+-- test: duplicate
+CREATE VIEW v AS SELECT a FROM test;
+CREATE VIEW v AS SELECT a FROM test;
+-- error:
+
+-- test: same name as a table
+CREATE VIEW test AS SELECT a FROM test;
+-- error:
+
+-- test: unknown table
+CREATE VIEW v AS SELECT a FROM unknown;
+-- error:
+
+-- test: params
+CREATE VIEW v AS SELECT a FROM test WHERE a = ?;
+-- error:
+
+-- test: not read-only
+CREATE SEQUENCE seq;
+CREATE VIEW v AS SELECT NEXT VALUE FOR seq AS n FROM test;
+-- error:
+
+-- test: insert into a view
+CREATE VIEW v AS SELECT a FROM test;
+INSERT INTO v (a) VALUES (10);
+-- error: cannot modify view v
+
+-- test: update a view
+CREATE VIEW v AS SELECT a FROM test;
+UPDATE v SET a = 10;
+-- error: cannot modify view v
+
+-- test: delete from a view
+CREATE VIEW v AS SELECT a FROM test;
+DELETE FROM v;
+-- error: cannot modify view v
+
+-- test: drop table with a view
+CREATE VIEW v AS SELECT a FROM test;
+DROP TABLE test;
+-- error:
+
+-- test: rename table with a view
+CREATE VIEW v AS SELECT a FROM test;
+ALTER TABLE test RENAME TO test2;
+-- error:
@@ -0,0 +1,124 @@
+-- setup:
+CREATE TABLE parent(id INT PRIMARY KEY);
+INSERT INTO parent (id) VALUES (1), (2);
+
+-- test: restrict
+CREATE TABLE child(a INT REFERENCES parent);
+INSERT INTO child (a) VALUES (1);
+DELETE FROM parent WHERE id = 1;
+-- error: FOREIGN KEY constraint "child_a_fkey" error: [a]
+
+-- test: restrict, not referenced
+CREATE TABLE child(a INT REFERENCES parent);
+INSERT INTO child (a) VALUES (1);
+DELETE FROM parent WHERE id = 2;
+SELECT * FROM parent;
+/* result:
+{
+  id: 1
+}
+*/
+
+-- test: cascade
+CREATE TABLE child(a INT REFERENCES parent ON DELETE CASCADE, b TEXT);
+INSERT INTO child (a, b) VALUES (1, "foo"), (2, "bar"), (1, "baz");
+DELETE FROM parent WHERE id = 1;
+SELECT * FROM child;
+/* result:
+{
+  a: 2,
+  b: "bar"
+}
+*/
+
+-- test: cascade without index
+CREATE TABLE child(id INT PRIMARY KEY, a INT UNIQUE REFERENCES parent ON DELETE CASCADE);
+ALTER TABLE child DROP CONSTRAINT child_a_unique;
+INSERT INTO child (id, a) VALUES (1, 1), (2, 2);
+DELETE FROM parent WHERE id = 2;
+SELECT * FROM child;
+/* result:
+{
+  id: 1,
+  a: 1
+}
+*/
+
+-- test: cascade to grandchildren
+CREATE TABLE child(id INT PRIMARY KEY, a INT REFERENCES parent ON DELETE CASCADE);
+CREATE TABLE grandchild(b INT REFERENCES child ON DELETE CASCADE);
+INSERT INTO child (id, a) VALUES (10, 1), (20, 2);
+INSERT INTO grandchild (b) VALUES (10), (20);
+DELETE FROM parent WHERE id = 1;
+SELECT * FROM grandchild;
+/* result:
+{
+  b: 20
+}
+*/
+
+-- test: cascade restricted by grandchildren
+CREATE TABLE child(id INT PRIMARY KEY, a INT REFERENCES parent ON DELETE CASCADE);
+CREATE TABLE grandchild(b INT REFERENCES child);
+INSERT INTO child (id, a) VALUES (10, 1);
+INSERT INTO grandchild (b) VALUES (10);
+DELETE FROM parent WHERE id = 1;
+-- error: FOREIGN KEY constraint "grandchild_b_fkey" error: [b]
+
+-- test: cascade in the same table
+CREATE TABLE tree(id INT PRIMARY KEY, parent_id INT REFERENCES tree ON DELETE CASCADE);
+INSERT INTO tree (id, parent_id) VALUES (1, null), (2, 1), (3, 2), (4, null);
+DELETE FROM tree WHERE id = 1;
+SELECT id, parent_id FROM tree;
+/* result:
+{
+  id: 4,
+  parent_id: null
+}
+*/
+
+-- test: set null
+CREATE TABLE child(a INT REFERENCES parent ON DELETE SET NULL, b TEXT);
+INSERT INTO child (a, b) VALUES (1, "foo"), (2, "bar");
+DELETE FROM parent WHERE id = 1;
+SELECT a, b FROM child ORDER BY b;
+/* result:
+{
+  a: 2,
+  b: "bar"
+}
+{
+  a: null,
+  b: "foo"
+}
+*/
+
+-- test: set null on not null field
+CREATE TABLE child(a INT NOT NULL REFERENCES parent ON DELETE SET NULL);
+INSERT INTO child (a) VALUES (1);
+DELETE FROM parent WHERE id = 1;
+-- error:
+
+-- test: cascade runs the triggers of the child table
+CREATE TABLE child(id INT PRIMARY KEY, a INT REFERENCES parent ON DELETE CASCADE);
+CREATE TABLE audit(op TEXT, id INT);
+CREATE TRIGGER t_before BEFORE DELETE ON child INSERT INTO audit (op, id) VALUES ("before", OLD.id);
+CREATE TRIGGER t_after AFTER DELETE ON child INSERT INTO audit (op, id) VALUES ("after", OLD.id);
+INSERT INTO child (id, a) VALUES (10, 1), (20, 2);
+DELETE FROM parent WHERE id = 1;
+SELECT op, id FROM audit;
+/* result:
+{"op": "before", "id": 10}
+{"op": "after", "id": 10}
+*/
+
+-- test: set null runs the update triggers of the child table
+CREATE TABLE child(id INT PRIMARY KEY, a INT REFERENCES parent ON DELETE SET NULL);
+CREATE TABLE audit(id INT, old_a INT, new_a INT);
+CREATE TRIGGER t AFTER UPDATE ON child INSERT INTO audit (id, old_a, new_a) VALUES (NEW.id, OLD.a, NEW.a);
+INSERT INTO child (id, a) VALUES (10, 1), (20, 2);
+DELETE FROM parent WHERE id = 1;
+SELECT id, old_a, new_a FROM audit;
+/* result:
+{"id": 10, "old_a": 1, "new_a": null}
+*/
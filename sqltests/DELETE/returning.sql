@@ -0,0 +1,57 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT, c TEXT);
+CREATE INDEX test_b_idx ON test (b);
+INSERT INTO test (a, b, c) VALUES (1, 10, "foo"), (2, 20, "bar"), (3, 30, "baz");
+
+-- test: wildcard
+DELETE FROM test WHERE a = 1 RETURNING *;
+/* result:
+{
+  a: 1,
+  b: 10,
+  c: "foo"
+}
+*/
+
+-- test: fields and pk
+DELETE FROM test WHERE b > 10 RETURNING pk(), c;
+/* result:
+{
+  "pk()": [2],
+  c: "bar"
+}
+{
+  "pk()": [3],
+  c: "baz"
+}
+*/
+
+-- test: documents are deleted
+DELETE FROM test WHERE b > 10 RETURNING a;
+SELECT a FROM test;
+/* result:
+{
+  a: 1
+}
+*/
+
+-- test: order by and limit
+DELETE FROM test ORDER BY b DESC LIMIT 2 RETURNING a, b;
+/* result:
+{
+  a: 3,
+  b: 30
+}
+{
+  a: 2,
+  b: 20
+}
+*/
+
+-- test: expressions
+DELETE FROM test WHERE a = 2 RETURNING b * 2 AS twice;
+/* result:
+{
+  twice: 40
+}
+*/
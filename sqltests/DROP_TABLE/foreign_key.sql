@@ -0,0 +1,27 @@
+-- setup:
+CREATE TABLE parent(id INT PRIMARY KEY);
+CREATE TABLE child(a INT REFERENCES parent);
+
+-- test: referenced table
+DROP TABLE parent;
+-- error:
+
+-- test: referencing table
+DROP TABLE child;
+DROP TABLE parent;
+SELECT COUNT(*) FROM __genji_catalog WHERE type = "table" AND (name = "parent" OR name = "child");
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
+
+-- test: self-referencing table
+CREATE TABLE tree(id INT PRIMARY KEY, parent_id INT REFERENCES tree);
+DROP TABLE tree;
+SELECT COUNT(*) FROM __genji_catalog WHERE name = "tree";
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
@@ -0,0 +1,33 @@
+-- setup:
+CREATE TABLE test(a int primary key);
+CREATE TABLE audit(a int);
+CREATE TRIGGER t AFTER INSERT ON test INSERT INTO audit (a) VALUES (NEW.a);
+
+-- test: basic
+DROP TRIGGER t;
+SELECT name FROM __genji_catalog WHERE type = "trigger";
+/* result:
+*/
+
+-- test: no longer fires
+DROP TRIGGER t;
+INSERT INTO test (a) VALUES (1);
+SELECT COUNT(*) AS n FROM audit;
+/* result:
+{"n": 0}
+*/
+
+-- test: IF EXISTS
+DROP TRIGGER IF EXISTS unknown;
+SELECT name FROM __genji_catalog WHERE type = "trigger";
+/* result:
+{"name": "t"}
+*/
+
+-- test: non-existing
+DROP TRIGGER unknown;
+-- error:
+
+-- test: table
+DROP TRIGGER test;
+-- error:
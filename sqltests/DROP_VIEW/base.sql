@@ -0,0 +1,46 @@
+-- setup:
+CREATE TABLE test(a int primary key);
+CREATE VIEW v AS SELECT a FROM test;
+
+-- test: basic
+DROP VIEW v;
+SELECT name FROM __genji_catalog WHERE type = "view";
+/* result:
+*/
+
+-- test: select after drop
+DROP VIEW v;
+SELECT * FROM v;
+-- error:
+
+-- test: IF EXISTS
+DROP VIEW IF EXISTS unknown;
+SELECT name FROM __genji_catalog WHERE type = "view";
+/* result:
+{"name": "v"}
+*/
+
+-- test: non-existing
+DROP VIEW unknown;
+-- error:
+
+-- test: table
+DROP VIEW test;
+-- error:
+
+-- test: drop table after dropping the view
+DROP VIEW v;
+DROP TABLE test;
+SELECT name FROM __genji_catalog WHERE name = "test";
+/* result:
+*/
+
+-- test: dependent view
+CREATE VIEW v2 AS SELECT a FROM v;
+DROP VIEW v;
+-- error:
+
+-- test: dependent view in subquery
+CREATE VIEW v2 AS SELECT a FROM test WHERE a IN (SELECT a FROM v);
+DROP VIEW v;
+-- error:
@@ -0,0 +1,55 @@
+-- setup:
+CREATE TABLE parent(id INT PRIMARY KEY, code TEXT UNIQUE);
+CREATE TABLE child(a INT REFERENCES parent, b TEXT REFERENCES parent(code));
+INSERT INTO parent (id, code) VALUES (1, "a"), (2, "b");
+
+-- test: existing reference
+INSERT INTO child (a, b) VALUES (1, "b");
+SELECT * FROM child;
+/* result:
+{
+  a: 1,
+  b: "b"
+}
+*/
+
+-- test: null reference
+INSERT INTO child (a) VALUES (null);
+SELECT a FROM child;
+/* result:
+{
+  a: null
+}
+*/
+
+-- test: converted reference
+INSERT INTO child (a) VALUES (2.0);
+SELECT * FROM child;
+/* result:
+{
+  a: 2
+}
+*/
+
+-- test: missing reference
+INSERT INTO child (a) VALUES (3);
+-- error: FOREIGN KEY constraint "child_a_fkey" error: [a]
+
+-- test: missing reference on unique path
+INSERT INTO child (b) VALUES ("c");
+-- error: FOREIGN KEY constraint "child_b_fkey" error: [b]
+
+-- test: reference inserted in the same statement
+CREATE TABLE tree(id INT PRIMARY KEY, parent_id INT REFERENCES tree);
+INSERT INTO tree (id, parent_id) VALUES (1, null), (2, 1);
+SELECT id, parent_id FROM tree;
+/* result:
+{
+  id: 1,
+  parent_id: null
+}
+{
+  id: 2,
+  parent_id: 1
+}
+*/
@@ -0,0 +1,180 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT UNIQUE, c INT DEFAULT 0);
+INSERT INTO test (a, b, c) VALUES (1, 10, 100), (2, 20, 200);
+
+-- test: do update, pk
+INSERT INTO test (a, b, c) VALUES (1, 30, 5) ON CONFLICT DO UPDATE SET c = c + EXCLUDED.c;
+SELECT * FROM test;
+/* result:
+{
+  a: 1,
+  b: 10,
+  c: 105
+}
+{
+  a: 2,
+  b: 20,
+  c: 200
+}
+*/
+
+-- test: do update, unique
+INSERT INTO test (a, b) VALUES (3, 20) ON CONFLICT (b) DO UPDATE SET c = c + 1;
+SELECT * FROM test;
+/* result:
+{
+  a: 1,
+  b: 10,
+  c: 100
+}
+{
+  a: 2,
+  b: 20,
+  c: 201
+}
+*/
+
+-- test: do update, counter
+CREATE TABLE counters(name TEXT PRIMARY KEY, n INT);
+INSERT INTO counters (name, n) VALUES ("foo", 1) ON CONFLICT (name) DO UPDATE SET n = n + EXCLUDED.n;
+INSERT INTO counters (name, n) VALUES ("foo", 1) ON CONFLICT (name) DO UPDATE SET n = n + EXCLUDED.n;
+INSERT INTO counters (name, n) VALUES ("foo", 1), ("bar", 1), ("foo", 1) ON CONFLICT (name) DO UPDATE SET n = n + EXCLUDED.n;
+SELECT * FROM counters;
+/* result:
+{
+  name: "bar",
+  n: 1
+}
+{
+  name: "foo",
+  n: 4
+}
+*/
+
+-- test: do update, returning
+INSERT INTO test (a, b, c) VALUES (1, 30, 5), (3, 30, 7) ON CONFLICT (a) DO UPDATE SET c = c + EXCLUDED.c RETURNING a, c;
+/* result:
+{
+  a: 3,
+  c: 7
+}
+*/
+
+-- test: do update, returning still updates the document
+INSERT INTO test (a, b, c) VALUES (1, 30, 5) ON CONFLICT (a) DO UPDATE SET c = c + EXCLUDED.c RETURNING a, c;
+SELECT a, c FROM test WHERE a = 1;
+/* result:
+{
+  a: 1,
+  c: 105
+}
+*/
+
+-- test: do update, multiple fields
+INSERT INTO test (a, b, c) VALUES (1, 30, 5) ON CONFLICT (a) DO UPDATE SET b = EXCLUDED.b, c = EXCLUDED.c;
+SELECT * FROM test WHERE a = 1;
+/* result:
+{
+  a: 1,
+  b: 30,
+  c: 5
+}
+*/
+
+-- test: do update, indexes are updated
+INSERT INTO test (a, b, c) VALUES (1, 30, 5) ON CONFLICT (a) DO UPDATE SET b = EXCLUDED.b;
+SELECT a FROM test WHERE b = 30;
+/* result:
+{
+  a: 1
+}
+*/
+
+-- test: do update, where
+INSERT INTO test (a, c) VALUES (1, 5), (2, 5) ON CONFLICT (a) DO UPDATE SET c = EXCLUDED.c WHERE c < 150;
+SELECT * FROM test;
+/* result:
+{
+  a: 1,
+  b: 10,
+  c: 5
+}
+{
+  a: 2,
+  b: 20,
+  c: 200
+}
+*/
+
+-- test: do update, conflict on another constraint
+INSERT INTO test (a, b) VALUES (3, 10) ON CONFLICT (a) DO UPDATE SET c = 0;
+-- error: UNIQUE constraint error: [b]
+
+-- test: do update, updated document conflicts
+INSERT INTO test (a) VALUES (1) ON CONFLICT (a) DO UPDATE SET b = 20;
+-- error: UNIQUE constraint error: [b]
+
+-- test: do update, check constraint
+CREATE TABLE other(a INT PRIMARY KEY, b INT CHECK (b < 10));
+INSERT INTO other (a, b) VALUES (1, 5);
+INSERT INTO other (a, b) VALUES (1, 5) ON CONFLICT DO UPDATE SET b = b + EXCLUDED.b;
+-- error: document violates check constraint "other_check"
+
+-- test: do nothing, with paths
+INSERT INTO test (a, b) VALUES (3, 10) ON CONFLICT (b) DO NOTHING;
+SELECT a FROM test;
+/* result:
+{
+  a: 1
+}
+{
+  a: 2
+}
+*/
+
+-- test: do nothing, conflict on another constraint
+INSERT INTO test (a, b) VALUES (3, 10) ON CONFLICT (a) DO NOTHING;
+-- error: UNIQUE constraint error: [b]
+
+-- test: paths without unique constraint
+INSERT INTO test (a, c) VALUES (1, 5) ON CONFLICT (c) DO NOTHING;
+-- error:
+
+-- test: do update, identical row with another unique field
+CREATE TABLE t(id INT PRIMARY KEY, n INT, u TEXT UNIQUE);
+INSERT INTO t VALUES (1, 1, 'a');
+INSERT INTO t VALUES (1, 0, 'a') ON CONFLICT (id) DO UPDATE SET n = n + 1;
+SELECT * FROM t;
+/* result:
+{
+  id: 1,
+  n: 2,
+  u: "a"
+}
+*/
+
+-- test: do nothing, identical row with another unique field
+CREATE TABLE t(id INT PRIMARY KEY, n INT, u TEXT UNIQUE);
+INSERT INTO t VALUES (1, 1, 'a');
+INSERT INTO t VALUES (1, 0, 'a') ON CONFLICT (id) DO NOTHING;
+SELECT * FROM t;
+/* result:
+{
+  id: 1,
+  n: 1,
+  u: "a"
+}
+*/
+
+-- test: do update, identical row targeting the other unique field
+CREATE TABLE t(id INT PRIMARY KEY, n INT, u TEXT UNIQUE);
+INSERT INTO t VALUES (1, 1, 'a');
+INSERT INTO t VALUES (1, 0, 'a') ON CONFLICT (u) DO UPDATE SET n = n + 1;
+SELECT * FROM t;
+/* result:
+{
+  id: 1,
+  n: 2,
+  u: "a"
+}
+*/
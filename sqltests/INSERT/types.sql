@@ -228,6 +228,21 @@ CREATE TABLE test_e (a CHARACTER(64) NOT NULL);
 INSERT INTO test_e {};
 -- error:
 
+-- test: timestamp / not null with type constraint
+CREATE TABLE test_e (a TIMESTAMP NOT NULL);
+INSERT INTO test_e {};
+-- error:
+
+-- test: timestamp / not null with non-respected type constraint
+CREATE TABLE test_e (a TIMESTAMP NOT NULL);
+INSERT INTO test_e {a: 42};
+-- error:
+
+-- test: timestamp / invalid text
+CREATE TABLE test_e (a TIMESTAMP);
+INSERT INTO test_e {a: 'foo'};
+-- error:
+
 -- test: Should fail if the fields cannot be converted to specified field constraints
 CREATE TABLE test (a DOUBLE);
 INSERT INTO test VALUES ([1]);
@@ -242,3 +257,48 @@ SELECT a FROM test;
   "a": 1
 }
 */
+
+-- test: Conversion to timestamp
+CREATE TABLE test (a TIMESTAMP, b DATE);
+INSERT INTO test (a, b) VALUES ('2023-03-15T10:20:30+02:00', '2023-03-15');
+SELECT a, b FROM test;
+/* result:
+{
+  "a": TIMESTAMP '2023-03-15T08:20:30Z',
+  "b": TIMESTAMP '2023-03-15T00:00:00Z'
+}
+*/
+
+-- test: Dates are truncated to midnight
+CREATE TABLE test (a DATE, b DATE);
+INSERT INTO test (a, b) VALUES ('2023-03-15T23:20:30+02:00', TIMESTAMP '2023-03-15T23:20:30+02:00');
+SELECT a, b FROM test;
+/* result:
+{
+  "a": TIMESTAMP '2023-03-15T00:00:00Z',
+  "b": TIMESTAMP '2023-03-15T00:00:00Z'
+}
+*/
+
+-- test: Conversion to decimal
+CREATE TABLE test (a DECIMAL(10, 2), b DECIMAL(10, 2), c DECIMAL(10, 2), d DECIMAL);
+INSERT INTO test (a, b, c, d) VALUES (10, 1.005, '3.1', '0.10');
+SELECT a, b, c, d FROM test;
+/* result:
+{
+  "a": DECIMAL '10.00',
+  "b": DECIMAL '1.01',
+  "c": DECIMAL '3.10',
+  "d": DECIMAL '0.1'
+}
+*/
+
+-- test: decimal / value too large for precision
+CREATE TABLE test_e (a DECIMAL(5, 2));
+INSERT INTO test_e {a: 1000};
+-- error:
+
+-- test: decimal / invalid text
+CREATE TABLE test_e (a DECIMAL);
+INSERT INTO test_e {a: 'foo'};
+-- error:
@@ -0,0 +1,40 @@
+-- test: uuid primary key
+CREATE TABLE test(id BLOB PRIMARY KEY DEFAULT uuid(), a INT);
+INSERT INTO test (a) VALUES (1), (2), (3);
+SELECT typeof(id) AS t, COUNT(*) AS c FROM test GROUP BY typeof(id);
+/* result:
+{"t": "blob", "c": 3}
+*/
+
+-- test: ulid primary keys follow insertion order
+CREATE TABLE test(id BLOB PRIMARY KEY DEFAULT ulid(), a INT);
+INSERT INTO test (a) VALUES (1), (2), (3);
+INSERT INTO test (a) VALUES (4);
+SELECT a FROM test;
+/* result:
+{"a": 1}
+{"a": 2}
+{"a": 3}
+{"a": 4}
+*/
+
+-- test: uuid as text
+CREATE TABLE test(id BLOB PRIMARY KEY, a INT);
+INSERT INTO test (id, a) VALUES ('f81d4fae-7dec-11d0-a765-00a0c91e6bf6', 1);
+SELECT uuid_text(id) AS id, a FROM test WHERE id = CAST('F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6' AS BLOB);
+/* result:
+{"id": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", "a": 1}
+*/
+
+-- test: ulid as text
+CREATE TABLE test(id BLOB PRIMARY KEY, a INT);
+INSERT INTO test (id, a) VALUES ('01ARZ3NDEKTSV4RRFFQ69G5FAV', 1);
+SELECT ulid_text(id) AS id, CAST(id AS TEXT) AS b64 FROM test;
+/* result:
+{"id": "01ARZ3NDEKTSV4RRFFQ69G5FAV", "b64": "AVY+OrXT1nZMYe+5kwK9Ww=="}
+*/
+
+-- test: invalid uuid
+CREATE TABLE test(id BLOB PRIMARY KEY, a INT);
+INSERT INTO test (id, a) VALUES ('f81d4fae-7dec-11d0-a765', 1);
+-- error:
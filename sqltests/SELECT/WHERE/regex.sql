@@ -0,0 +1,47 @@
+-- setup:
+CREATE TABLE test(id int PRIMARY KEY, name text);
+CREATE INDEX test_name ON test(name);
+INSERT INTO test (id, name) VALUES
+    (1, "apple"),
+    (2, "apricot"),
+    (3, "banana"),
+    (4, "aPple"),
+    (5, "apq"),
+    (6, "cherry");
+
+-- test: =~
+SELECT id FROM test WHERE name =~ 'an';
+/* result:
+{"id": 3}
+*/
+
+-- test: =~ with anchored prefix
+SELECT id FROM test WHERE name =~ '^ap';
+/* sorted-result:
+{"id": 1}
+{"id": 2}
+{"id": 5}
+*/
+
+-- test: =~ with anchored prefix and pattern
+SELECT id FROM test WHERE name =~ '^ap[a-q]$';
+/* sorted-result:
+{"id": 5}
+*/
+
+-- test: =~ case insensitive
+SELECT id FROM test WHERE name =~ '(?i)^apple$';
+/* sorted-result:
+{"id": 1}
+{"id": 4}
+*/
+
+-- test: !~
+SELECT id FROM test WHERE name !~ 'a';
+/* result:
+{"id": 6}
+*/
+
+-- test: invalid pattern
+SELECT id FROM test WHERE name =~ '[a';
+-- error:
@@ -18,3 +18,84 @@ SELECT a % 2 FROM test GROUP BY a % 2
 {"a % 2": 0}
 {"a % 2": 1}
 */
+
+-- test: GROUP BY multiple expressions
+SELECT a % 2, a > 2, COUNT(*) FROM test GROUP BY a % 2, a > 2
+/* result:
+{"a % 2": 0, "a > 2": false, "COUNT(*)": 1}
+{"a % 2": 0, "a > 2": true, "COUNT(*)": 1}
+{"a % 2": 1, "a > 2": false, "COUNT(*)": 1}
+{"a % 2": 1, "a > 2": true, "COUNT(*)": 2}
+*/
+
+-- test: GROUP BY array
+SELECT [a % 2, a > 2], COUNT(*) FROM test GROUP BY [a % 2, a > 2]
+/* result:
+{"[a % 2, a > 2]": [0, false], "COUNT(*)": 1}
+{"[a % 2, a > 2]": [0, true], "COUNT(*)": 1}
+{"[a % 2, a > 2]": [1, false], "COUNT(*)": 1}
+{"[a % 2, a > 2]": [1, true], "COUNT(*)": 2}
+*/
+
+-- test: HAVING
+SELECT a % 2, COUNT(*) FROM test GROUP BY a % 2 HAVING COUNT(*) > 2
+/* result:
+{"a % 2": 1, "COUNT(*)": 3}
+*/
+
+-- test: HAVING with aggregate not projected
+SELECT a % 2 FROM test GROUP BY a % 2 HAVING SUM(a) < 9 AND MIN(a) >= 1
+/* result:
+{"a % 2": 0}
+*/
+
+-- test: HAVING with GROUP BY expression
+SELECT a % 2, MAX(a) FROM test GROUP BY a % 2 HAVING a % 2 = 0
+/* result:
+{"a % 2": 0, "MAX(a)": 4}
+*/
+
+-- test: HAVING with multiple GROUP BY expressions
+SELECT a % 2, a > 2 FROM test GROUP BY a % 2, a > 2 HAVING a > 2 AND COUNT(*) = 1
+/* result:
+{"a % 2": 0, "a > 2": true}
+*/
+
+-- test: HAVING without GROUP BY
+SELECT COUNT(*) FROM test HAVING COUNT(*) > 10
+/* result:
+*/
+
+-- test: HAVING without GROUP BY with ungrouped field
+SELECT a FROM test HAVING COUNT(*) > 1
+-- error:
+
+-- test: HAVING without GROUP BY with wildcard
+SELECT * FROM test HAVING COUNT(*) > 1
+-- error:
+
+-- test: HAVING with ungrouped field
+SELECT a % 2 FROM test GROUP BY a % 2 HAVING a > 1
+-- error:
+
+-- test: HAVING with aggregate in function
+SELECT a % 2 FROM test GROUP BY a % 2 HAVING math.abs(SUM(a) - 10) > 2
+/* result:
+{"a % 2": 0}
+*/
+
+-- test: HAVING with aggregate in CAST
+SELECT a % 2 FROM test GROUP BY a % 2 HAVING CAST(SUM(a) AS TEXT) = '9'
+/* result:
+{"a % 2": 1}
+*/
+
+-- test: HAVING with GROUP BY expression in function
+SELECT a % 2 FROM test GROUP BY a % 2 HAVING math.abs(a % 2) = 1
+/* result:
+{"a % 2": 1}
+*/
+
+-- test: HAVING with ungrouped field in function
+SELECT a % 2 FROM test GROUP BY a % 2 HAVING math.abs(a) > 1
+-- error:
@@ -0,0 +1,69 @@
+-- setup:
+CREATE TABLE test(a int, b text);
+INSERT INTO test (a, b) VALUES (1, "x"), (5, null), (10, "z");
+INSERT INTO test (a) VALUES (null);
+
+-- test: searched CASE
+SELECT a, CASE WHEN a < 5 THEN "small" WHEN a < 10 THEN "medium" ELSE "big" END AS size FROM test;
+/* result:
+{"a": 1, "size": "small"}
+{"a": 5, "size": "medium"}
+{"a": 10, "size": "big"}
+{"a": null, "size": "big"}
+*/
+
+-- test: simple CASE
+SELECT CASE a WHEN 1 THEN "one" WHEN 10 THEN "ten" END AS n FROM test;
+/* result:
+{"n": "one"}
+{"n": null}
+{"n": "ten"}
+{"n": null}
+*/
+
+-- test: CASE in WHERE
+SELECT a FROM test WHERE CASE WHEN b IS NULL THEN false ELSE a > 1 END;
+/* result:
+{"a": 10}
+*/
+
+-- test: CASE with constant condition in WHERE
+SELECT a FROM test WHERE CASE WHEN 1 > 2 THEN a = 1 ELSE a = 5 END;
+/* result:
+{"a": 5}
+*/
+
+-- test: CASE in GROUP BY
+SELECT CASE WHEN a < 5 THEN "small" ELSE "big" END AS size, COUNT(*) AS n FROM test GROUP BY CASE WHEN a < 5 THEN "small" ELSE "big" END;
+/* result:
+{"size": "big", "n": 3}
+{"size": "small", "n": 1}
+*/
+
+-- test: COALESCE
+SELECT COALESCE(b, "none") AS b FROM test;
+/* result:
+{"b": "x"}
+{"b": "none"}
+{"b": "z"}
+{"b": "none"}
+*/
+
+-- test: IFNULL and NULLIF
+SELECT IFNULL(a, 0) AS a, NULLIF(b, "x") AS b FROM test;
+/* result:
+{"a": 1, "b": null}
+{"a": 5, "b": null}
+{"a": 10, "b": "z"}
+{"a": 0, "b": null}
+*/
+
+-- test: UPDATE with CASE
+UPDATE test SET b = CASE WHEN a > 1 THEN "many" ELSE b END;
+SELECT a, b FROM test;
+/* result:
+{"a": 1, "b": "x"}
+{"a": 5, "b": "many"}
+{"a": 10, "b": "many"}
+{"a": null, "b": null}
+*/
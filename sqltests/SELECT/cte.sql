@@ -0,0 +1,205 @@
+-- setup:
+CREATE TABLE categories(id int PRIMARY KEY, name text, parent int);
+INSERT INTO categories (id, name, parent) VALUES
+    (1, "root", null),
+    (2, "books", 1),
+    (3, "music", 1),
+    (4, "novels", 2),
+    (5, "poetry", 2),
+    (6, "sci-fi", 4),
+    (7, "other", null);
+
+-- test: simple
+WITH books AS (SELECT id, name FROM categories WHERE parent = 2)
+SELECT name FROM books;
+/* result:
+{"name": "novels"}
+{"name": "poetry"}
+*/
+
+-- test: referenced twice
+WITH c AS (SELECT id, parent FROM categories WHERE id < 4)
+SELECT a.id AS child, b.id AS parent FROM c AS a JOIN c AS b ON a.parent = b.id;
+/* result:
+{"child": 2, "parent": 1}
+{"child": 3, "parent": 1}
+*/
+
+-- test: multiple expressions
+WITH
+    roots AS (SELECT id FROM categories WHERE parent IS NULL),
+    children AS (SELECT id, parent FROM categories WHERE parent IS NOT NULL)
+SELECT COUNT(*) AS n FROM children JOIN roots ON children.parent = roots.id;
+/* result:
+{"n": 2}
+*/
+
+-- test: reference to a previous expression
+WITH
+    a AS (SELECT id, name FROM categories WHERE id > 2),
+    b AS (SELECT name FROM a WHERE id < 5)
+SELECT * FROM b;
+/* result:
+{"name": "music"}
+{"name": "novels"}
+*/
+
+-- test: shadows table
+WITH categories AS (SELECT id FROM categories WHERE id = 7)
+SELECT * FROM categories;
+/* result:
+{"id": 7}
+*/
+
+-- test: with subquery
+WITH leaves AS (SELECT id FROM categories WHERE id NOT IN (SELECT parent FROM categories WHERE parent IS NOT NULL))
+SELECT id FROM leaves;
+/* result:
+{"id": 3}
+{"id": 5}
+{"id": 6}
+{"id": 7}
+*/
+
+-- test: ORDER BY and LIMIT
+WITH c AS (SELECT id, name FROM categories)
+SELECT name FROM c ORDER BY name LIMIT 2;
+/* result:
+{"name": "books"}
+{"name": "music"}
+*/
+
+-- test: recursive
+WITH RECURSIVE tree AS (
+    SELECT id, name, 0 AS depth FROM categories WHERE id = 2
+    UNION ALL
+    SELECT c.id AS id, c.name AS name, t.depth + 1 AS depth FROM categories AS c JOIN tree AS t ON c.parent = t.id
+)
+SELECT * FROM tree;
+/* sorted-result:
+{"id": 2, "name": "books", "depth": 0}
+{"id": 4, "name": "novels", "depth": 1}
+{"id": 5, "name": "poetry", "depth": 1}
+{"id": 6, "name": "sci-fi", "depth": 2}
+*/
+
+-- test: recursive ancestors
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent FROM categories WHERE id = 6
+    UNION
+    SELECT c.id AS id, c.parent AS parent FROM categories AS c JOIN ancestors AS a ON c.id = a.parent
+)
+SELECT id FROM ancestors ORDER BY id;
+/* result:
+{"id": 1}
+{"id": 2}
+{"id": 4}
+{"id": 6}
+*/
+
+-- test: recursive without table
+WITH RECURSIVE n AS (
+    SELECT 1 AS i
+    UNION ALL
+    SELECT i + 1 FROM n WHERE i < 5
+)
+SELECT i FROM n;
+/* result:
+{"i": 1}
+{"i": 2}
+{"i": 3}
+{"i": 4}
+{"i": 5}
+*/
+
+-- test: recursive term without aliases
+WITH RECURSIVE tree AS (
+    SELECT id, name, 0 AS depth FROM categories WHERE id = 2
+    UNION ALL
+    SELECT c.id, c.name, t.depth + 1 FROM categories AS c JOIN tree AS t ON c.parent = t.id
+)
+SELECT * FROM tree;
+/* sorted-result:
+{"id": 2, "name": "books", "depth": 0}
+{"id": 4, "name": "novels", "depth": 1}
+{"id": 5, "name": "poetry", "depth": 1}
+{"id": 6, "name": "sci-fi", "depth": 2}
+*/
+
+-- test: recursive term with a different number of columns
+WITH RECURSIVE n AS (
+    SELECT 1 AS i
+    UNION ALL
+    SELECT i + 1, i FROM n WHERE i < 5
+)
+SELECT i FROM n;
+-- error: recursive term of "n" returns 2 columns, but its anchor returns 1
+
+-- test: recursive UNION stops on cycles
+WITH RECURSIVE n AS (
+    SELECT 0 AS i
+    UNION
+    SELECT (i + 1) % 3 AS i FROM n
+)
+SELECT i FROM n;
+/* result:
+{"i": 0}
+{"i": 1}
+{"i": 2}
+*/
+
+-- test: recursive UNION ALL with LIMIT
+WITH RECURSIVE n AS (
+    SELECT 1 AS i
+    UNION ALL
+    SELECT i * 2 AS i FROM n
+)
+SELECT i FROM n LIMIT 4;
+/* result:
+{"i": 1}
+{"i": 2}
+{"i": 4}
+{"i": 8}
+*/
+
+-- test: RECURSIVE without self reference
+WITH RECURSIVE c AS (SELECT id FROM categories WHERE id = 1)
+SELECT * FROM c;
+/* result:
+{"id": 1}
+*/
+
+-- test: not visible outside of the statement
+WITH c AS (SELECT id FROM categories)
+SELECT * FROM c;
+SELECT * FROM c;
+-- error:
+
+-- test: self reference without RECURSIVE
+WITH c AS (SELECT id FROM c)
+SELECT * FROM c;
+-- error:
+
+-- test: duplicate name
+WITH c AS (SELECT id FROM categories), c AS (SELECT id FROM categories)
+SELECT * FROM c;
+-- error:
+
+-- test: recursive reference in anchor
+WITH RECURSIVE n AS (
+    SELECT i FROM n
+    UNION ALL
+    SELECT 1 AS i
+)
+SELECT * FROM n;
+-- error:
+
+-- test: recursive without UNION
+WITH RECURSIVE n AS (SELECT i FROM n)
+SELECT * FROM n;
+-- error:
+
+-- test: must be followed by SELECT
+WITH c AS (SELECT id FROM categories)
+DELETE FROM categories;
+-- error:
@@ -0,0 +1,78 @@
+-- setup:
+CREATE TABLE invoices(id DECIMAL(6, 2) PRIMARY KEY, amount DECIMAL(10, 2) NOT NULL, label TEXT);
+CREATE INDEX invoices_amount_idx ON invoices(amount);
+INSERT INTO invoices (id, amount, label) VALUES
+    (1, 10.10, 'a'),
+    (2.5, '0.20', 'b'),
+    (-3, DECIMAL '-1000', 'c'),
+    (4.001, 99999999.994, 'd');
+
+-- test: order by
+SELECT id, amount FROM invoices ORDER BY amount;
+/* result:
+{"id": DECIMAL '-3.00', "amount": DECIMAL '-1000.00'}
+{"id": DECIMAL '2.50', "amount": DECIMAL '0.20'}
+{"id": DECIMAL '1.00', "amount": DECIMAL '10.10'}
+{"id": DECIMAL '4.00', "amount": DECIMAL '99999999.99'}
+*/
+
+-- test: order by primary key desc
+SELECT id FROM invoices ORDER BY id DESC;
+/* result:
+{"id": DECIMAL '4.00'}
+{"id": DECIMAL '2.50'}
+{"id": DECIMAL '1.00'}
+{"id": DECIMAL '-3.00'}
+*/
+
+-- test: primary key lookup
+SELECT label FROM invoices WHERE id = 2.5;
+/* result:
+{"label": "b"}
+*/
+
+-- test: primary key lookup with integer
+SELECT label FROM invoices WHERE id = 1;
+/* result:
+{"label": "a"}
+*/
+
+-- test: duplicate primary key
+INSERT INTO invoices (id, amount) VALUES (DECIMAL '1.000', 1);
+-- error:
+
+-- test: index range
+SELECT label FROM invoices WHERE amount > 0.2 AND amount <= DECIMAL '99999999.99';
+/* result:
+{"label": "a"}
+{"label": "d"}
+*/
+
+-- test: exact sum
+SELECT SUM(amount) AS s FROM invoices WHERE amount > 0;
+/* result:
+{"s": DECIMAL '100000010.29'}
+*/
+
+-- test: avg
+SELECT AVG(amount) AS a FROM invoices WHERE label IN ['a', 'b'];
+/* result:
+{"a": DECIMAL '5.15'}
+*/
+
+-- test: min and max
+SELECT MIN(amount) AS mi, MAX(amount) AS ma FROM invoices;
+/* result:
+{"mi": DECIMAL '-1000.00', "ma": DECIMAL '99999999.99'}
+*/
+
+-- test: update
+UPDATE invoices SET amount = amount * 1.1 WHERE id = 1;
+SELECT amount FROM invoices WHERE id = 1;
+/* result:
+{"amount": DECIMAL '11.11'}
+*/
+
+-- test: overflow
+UPDATE invoices SET amount = amount * 10 WHERE id = 4;
+-- error:
@@ -0,0 +1,94 @@
+-- setup:
+CREATE TABLE foo;
+CREATE TABLE bar;
+INSERT INTO foo (a,b) VALUES (1.0, 1.0), (2.0, 2.0), (2.0, 2.0), (3.0, 3.0);
+INSERT INTO bar (a,b) VALUES (2.0, 2.0), (3.0, 3.0), (3.0, 3.0), (4.0, 4.0);
+
+-- test: basic intersect
+SELECT * FROM foo
+INTERSECT
+SELECT * FROM bar;
+/* result:
+{"a": 2.0, "b": 2.0}
+{"a": 3.0, "b": 3.0}
+*/
+
+-- test: intersect all
+SELECT * FROM foo
+INTERSECT ALL
+SELECT * FROM bar;
+/* result:
+{"a": 2.0, "b": 2.0}
+{"a": 3.0, "b": 3.0}
+*/
+
+-- test: intersect all with duplicates in both
+SELECT a FROM foo
+INTERSECT ALL
+SELECT a FROM foo WHERE a >= 2;
+/* result:
+{"a": 2.0}
+{"a": 2.0}
+{"a": 3.0}
+*/
+
+-- test: intersect with projection
+SELECT a FROM foo
+INTERSECT
+SELECT b AS a FROM bar WHERE b > 2;
+/* result:
+{"a": 3.0}
+*/
+
+-- test: basic except
+SELECT * FROM foo
+EXCEPT
+SELECT * FROM bar;
+/* result:
+{"a": 1.0, "b": 1.0}
+*/
+
+-- test: except all
+SELECT a FROM foo
+EXCEPT ALL
+SELECT a FROM bar;
+/* result:
+{"a": 1.0}
+{"a": 2.0}
+*/
+
+-- test: except is not symmetric
+SELECT * FROM bar
+EXCEPT
+SELECT * FROM foo;
+/* result:
+{"a": 4.0, "b": 4.0}
+*/
+
+-- test: except everything
+SELECT * FROM foo
+EXCEPT
+SELECT * FROM foo;
+/* result:
+*/
+
+-- test: mixed compound operators
+SELECT * FROM foo
+UNION
+SELECT * FROM bar
+EXCEPT
+SELECT * FROM foo WHERE a < 3;
+/* result:
+{"a": 3.0, "b": 3.0}
+{"a": 4.0, "b": 4.0}
+*/
+
+-- test: with order by and limit
+SELECT a FROM foo
+EXCEPT ALL
+SELECT a FROM bar
+ORDER BY a DESC
+LIMIT 1;
+/* result:
+{"a": 2.0}
+*/
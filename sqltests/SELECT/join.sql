@@ -0,0 +1,125 @@
+-- setup:
+CREATE TABLE users(id int PRIMARY KEY, name text);
+CREATE TABLE orders(id int PRIMARY KEY, user_id int, total int);
+CREATE TABLE items(order_id int, sku text);
+INSERT INTO users (id, name) VALUES (1, "a"), (2, "b"), (3, "c");
+INSERT INTO orders (id, user_id, total) VALUES (10, 1, 100), (11, 1, 50), (12, 2, 20);
+INSERT INTO items (order_id, sku) VALUES (10, "x"), (10, "y"), (12, "z");
+
+-- test: inner join
+SELECT u.name, o.total FROM users AS u JOIN orders AS o ON u.id = o.user_id;
+/* result:
+{"u.name": "a", "o.total": 100}
+{"u.name": "a", "o.total": 50}
+{"u.name": "b", "o.total": 20}
+*/
+
+-- test: inner join without aliases
+SELECT users.name, orders.id FROM users INNER JOIN orders ON users.id = orders.user_id WHERE orders.total > 30;
+/* result:
+{"users.name": "a", "orders.id": 10}
+{"users.name": "a", "orders.id": 11}
+*/
+
+-- test: wildcard
+SELECT * FROM users u JOIN orders o ON u.id = o.user_id WHERE o.id = 12;
+/* result:
+{
+  "u": {"id": 2, "name": "b"},
+  "o": {"id": 12, "user_id": 2, "total": 20}
+}
+*/
+
+-- test: table alias without join
+SELECT u.name FROM users u WHERE u.id > 1;
+/* result:
+{"u.name": "b"}
+{"u.name": "c"}
+*/
+
+-- test: left join
+SELECT u.name, o.total FROM users u LEFT JOIN orders o ON u.id = o.user_id;
+/* result:
+{"u.name": "a", "o.total": 100}
+{"u.name": "a", "o.total": 50}
+{"u.name": "b", "o.total": 20}
+{"u.name": "c", "o.total": NULL}
+*/
+
+-- test: left join with wildcard
+SELECT * FROM users u LEFT OUTER JOIN orders o ON u.id = o.user_id WHERE u.id = 3;
+/* result:
+{"u": {"id": 3, "name": "c"}, "o": NULL}
+*/
+
+-- test: multiple joins
+SELECT u.name, i.sku FROM users u JOIN orders o ON u.id = o.user_id JOIN items i ON i.order_id = o.id;
+/* result:
+{"u.name": "a", "i.sku": "x"}
+{"u.name": "a", "i.sku": "y"}
+{"u.name": "b", "i.sku": "z"}
+*/
+
+-- test: join with aggregation
+SELECT COUNT(*), SUM(o.total) FROM users u JOIN orders o ON u.id = o.user_id;
+/* result:
+{"COUNT(*)": 3, "SUM(o.total)": 170}
+*/
+
+-- test: join with limit
+SELECT u.name, o.id FROM users u JOIN orders o ON u.id = o.user_id LIMIT 1;
+/* result:
+{"u.name": "a", "o.id": 10}
+*/
+
+-- test: join with index
+CREATE INDEX on orders(user_id);
+SELECT u.name, o.total FROM users u LEFT JOIN orders o ON o.user_id = u.id;
+/* result:
+{"u.name": "a", "o.total": 100}
+{"u.name": "a", "o.total": 50}
+{"u.name": "b", "o.total": 20}
+{"u.name": "c", "o.total": NULL}
+*/
+
+-- test: duplicate alias
+SELECT * FROM users u JOIN orders u ON u.id = u.user_id;
+-- error:
+
+-- test: unknown table
+SELECT * FROM users u JOIN foo ON u.id = foo.id;
+-- error:
+
+-- test: unqualified columns
+SELECT name, total, sku FROM users u JOIN orders o ON u.id = user_id JOIN items ON order_id = o.id;
+/* result:
+{"name": "a", "total": 100, "sku": "x"}
+{"name": "a", "total": 100, "sku": "y"}
+{"name": "b", "total": 20, "sku": "z"}
+*/
+
+-- test: unqualified column in left join
+SELECT u.id, total FROM users u LEFT JOIN orders o ON u.id = user_id WHERE total IS NULL;
+/* result:
+{"u.id": 3, "total": NULL}
+*/
+
+-- test: ambiguous column
+SELECT id FROM users u JOIN orders o ON u.id = o.user_id;
+-- error: ambiguous column "id", found in "u" and "o"
+
+-- test: quoted join keywords can be used as field names
+CREATE TABLE positions(id int PRIMARY KEY, `left` int, `inner` int);
+INSERT INTO positions (id, `left`, `inner`) VALUES (1, 10, 20);
+SELECT `left`, `inner` FROM positions;
+/* result:
+{"left": 10, "inner": 20}
+*/
+
+-- test: join keywords must be quoted
+CREATE TABLE positions(id int PRIMARY KEY, left int);
+-- error:
+
+-- test: join keywords cannot be used as aliases
+SELECT id FROM users outer;
+-- error:
@@ -0,0 +1,40 @@
+-- setup:
+CREATE TABLE test(
+    id INT PRIMARY KEY,
+    name TEXT CHECK (strings.trim(name) = name),
+    code TEXT DEFAULT strings.lpad('0', 3, '0')
+);
+INSERT INTO test (id, name) VALUES (1, "Foo Bar"), (2, "baz");
+INSERT INTO test (id, name, code) VALUES (3, "Qux", "42");
+
+-- test: projection
+SELECT id, strings.upper(name) AS name, strings.lpad(code, 4, '0') AS code FROM test;
+/* result:
+{"id": 1, "name": "FOO BAR", "code": "0000"}
+{"id": 2, "name": "BAZ", "code": "0000"}
+{"id": 3, "name": "QUX", "code": "0042"}
+*/
+
+-- test: default name
+SELECT strings.replace(name, ' ', '_') FROM test WHERE id = 1;
+/* result:
+{"strings.replace(name, \" \", \"_\")": "Foo_Bar"}
+*/
+
+-- test: where
+SELECT id FROM test WHERE strings.starts_with(strings.lower(name), 'b');
+/* result:
+{"id": 2}
+*/
+
+-- test: group by
+SELECT strings.lower(strings.substr(name, 1, 1)) AS initial, COUNT(*) AS n FROM test GROUP BY strings.lower(strings.substr(name, 1, 1));
+/* result:
+{"initial": "b", "n": 1}
+{"initial": "f", "n": 1}
+{"initial": "q", "n": 1}
+*/
+
+-- test: check constraint
+INSERT INTO test (id, name) VALUES (4, " foo ");
+-- error:
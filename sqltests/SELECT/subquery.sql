@@ -0,0 +1,171 @@
+-- setup:
+CREATE TABLE users(id int PRIMARY KEY, name text);
+CREATE TABLE orders(id int PRIMARY KEY, user_id int, total int);
+INSERT INTO users (id, name) VALUES (1, "a"), (2, "b"), (3, "c");
+INSERT INTO orders (id, user_id, total) VALUES (10, 1, 100), (11, 1, 50), (12, 2, 20);
+CREATE SEQUENCE seq;
+
+-- test: IN
+SELECT name FROM users WHERE id IN (SELECT user_id FROM orders);
+/* result:
+{"name": "a"}
+{"name": "b"}
+*/
+
+-- test: NOT IN
+SELECT name FROM users WHERE id NOT IN (SELECT user_id FROM orders WHERE total > 30);
+/* result:
+{"name": "b"}
+{"name": "c"}
+*/
+
+-- test: scalar
+SELECT name, (SELECT MAX(total) FROM orders) AS m FROM users WHERE id = 1;
+/* result:
+{"name": "a", "m": 100}
+*/
+
+-- test: scalar in WHERE
+SELECT id FROM orders WHERE total = (SELECT MIN(total) FROM orders);
+/* result:
+{"id": 12}
+*/
+
+-- test: scalar without result
+SELECT (SELECT total FROM orders WHERE id = 100) AS t;
+/* result:
+{"t": NULL}
+*/
+
+-- test: scalar with more than one document
+SELECT (SELECT total FROM orders) AS t;
+-- error:
+
+-- test: scalar with more than one field
+SELECT (SELECT * FROM orders WHERE id = 10) AS t;
+-- error:
+
+-- test: EXISTS
+SELECT EXISTS (SELECT * FROM orders WHERE total > 60) AS a, EXISTS (SELECT * FROM orders WHERE total > 600) AS b;
+/* result:
+{"a": true, "b": false}
+*/
+
+-- test: correlated EXISTS
+SELECT name FROM users WHERE EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id);
+/* result:
+{"name": "a"}
+{"name": "b"}
+*/
+
+-- test: correlated NOT EXISTS
+SELECT name FROM users WHERE NOT EXISTS (SELECT 1 FROM orders WHERE user_id = users.id);
+/* result:
+{"name": "c"}
+*/
+
+-- test: correlated NOT EXISTS with aliases
+SELECT u.name FROM users u WHERE NOT EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.id);
+/* result:
+{"u.name": "c"}
+*/
+
+-- test: correlated scalar
+SELECT name, (SELECT SUM(total) FROM orders WHERE user_id = users.id) AS total FROM users;
+/* result:
+{"name": "a", "total": 150}
+{"name": "b", "total": 20}
+{"name": "c", "total": NULL}
+*/
+
+-- test: nested subqueries
+SELECT name FROM users WHERE id IN (SELECT user_id FROM orders WHERE total IN (SELECT total FROM orders WHERE total < 60));
+/* result:
+{"name": "a"}
+{"name": "b"}
+*/
+
+-- test: UPDATE with subquery
+UPDATE users SET name = "big" WHERE id IN (SELECT user_id FROM orders WHERE total >= 100);
+SELECT name FROM users WHERE id = 1;
+/* result:
+{"name": "big"}
+*/
+
+-- test: DELETE with correlated subquery
+DELETE FROM users WHERE NOT EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id);
+SELECT id FROM users;
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: INSERT with subquery
+INSERT INTO orders (id, user_id, total) VALUES (13, 3, (SELECT MAX(total) FROM orders) + 1);
+SELECT total FROM orders WHERE id = 13;
+/* result:
+{"total": 101}
+*/
+
+-- test: unknown table
+SELECT name FROM users WHERE id IN (SELECT id FROM foo);
+-- error:
+
+-- test: uncorrelated subqueries are evaluated once
+SELECT id, (SELECT NEXT VALUE FOR seq) AS n FROM users;
+/* result:
+{"id": 1, "n": 1}
+{"id": 2, "n": 1}
+{"id": 3, "n": 1}
+*/
+
+-- test: subqueries with missing fields are evaluated once
+SELECT id, (SELECT NEXT VALUE FOR seq FROM orders WHERE id = 10 AND name IS NULL) AS n FROM users;
+/* result:
+{"id": 1, "n": 1}
+{"id": 2, "n": 1}
+{"id": 3, "n": 1}
+*/
+
+-- test: correlated subqueries are evaluated for each document
+SELECT id, (SELECT NEXT VALUE FOR seq FROM orders WHERE orders.id = 10 AND users.id > 0) AS n FROM users;
+/* result:
+{"id": 1, "n": 1}
+{"id": 2, "n": 2}
+{"id": 3, "n": 3}
+*/
+
+-- test: missing field is not read from the outer query
+CREATE TABLE a(id int PRIMARY KEY, tag text);
+CREATE TABLE b(id int PRIMARY KEY, ...);
+INSERT INTO a (id, tag) VALUES (1, 'x'), (2, 'y');
+INSERT INTO b (id, tag) VALUES (10, 'x');
+INSERT INTO b (id) VALUES (11);
+SELECT id FROM a WHERE tag IN (SELECT tag FROM b);
+/* result:
+{"id": 1}
+*/
+
+-- test: missing field in a scalar subquery is not read from the outer query
+CREATE TABLE a(id int PRIMARY KEY, tag text);
+CREATE TABLE b(id int PRIMARY KEY, ...);
+INSERT INTO a (id, tag) VALUES (1, 'x'), (2, 'y');
+INSERT INTO b (id, tag) VALUES (10, 'x');
+INSERT INTO b (id) VALUES (11);
+SELECT id, (SELECT COUNT(*) FROM b WHERE tag = 'y') AS c FROM a;
+/* result:
+{"id": 1, "c": 0}
+{"id": 2, "c": 0}
+*/
+
+-- test: qualified missing field reads from the outer query
+CREATE TABLE a(id int PRIMARY KEY, tag text);
+CREATE TABLE b(id int PRIMARY KEY, ...);
+INSERT INTO a (id, tag) VALUES (1, 'x'), (2, 'y');
+INSERT INTO b (id, tag) VALUES (10, 'x');
+INSERT INTO b (id) VALUES (11);
+SELECT id, (SELECT COUNT(*) FROM b WHERE a.tag = 'y') AS c FROM a;
+/* result:
+{"id": 1, "c": 0}
+{"id": 2, "c": 2}
+*/
@@ -0,0 +1,129 @@
+-- setup:
+CREATE TABLE events(id int PRIMARY KEY, at TIMESTAMP NOT NULL, name text);
+CREATE INDEX events_at_idx ON events(at);
+INSERT INTO events (id, at, name) VALUES
+    (1, TIMESTAMP '2023-03-15 10:20:30', 'a'),
+    (2, '2021-01-01T00:00:00Z', 'b'),
+    (3, DATE '1969-07-20', 'c'),
+    (4, '2023-03-15 10:20:30.000001', 'd');
+
+-- test: order by
+SELECT id, at FROM events ORDER BY at;
+/* result:
+{"id": 3, "at": TIMESTAMP '1969-07-20T00:00:00Z'}
+{"id": 2, "at": TIMESTAMP '2021-01-01T00:00:00Z'}
+{"id": 1, "at": TIMESTAMP '2023-03-15T10:20:30Z'}
+{"id": 4, "at": TIMESTAMP '2023-03-15T10:20:30.000001Z'}
+*/
+
+-- test: order by desc
+SELECT id FROM events ORDER BY at DESC;
+/* result:
+{"id": 4}
+{"id": 1}
+{"id": 2}
+{"id": 3}
+*/
+
+-- test: range
+SELECT id FROM events WHERE at >= TIMESTAMP '2021-01-01' AND at < DATE '2023-03-16';
+/* result:
+{"id": 2}
+{"id": 1}
+{"id": 4}
+*/
+
+-- test: equality
+SELECT id FROM events WHERE at = TIMESTAMP '2023-03-15T10:20:30Z';
+/* result:
+{"id": 1}
+*/
+
+-- test: text compared as a timestamp
+SELECT id FROM events WHERE at = '2023-03-15T10:20:30Z';
+/* result:
+{"id": 1}
+*/
+
+-- test: text range
+SELECT id FROM events WHERE at > '2020-01-01' AND at < '2023-03-15 10:20:30.000001';
+/* result:
+{"id": 2}
+{"id": 1}
+*/
+
+-- test: text range uses the index
+EXPLAIN SELECT id FROM events WHERE at > '2020-01-01';
+/* result:
+{
+  "plan": 'index.IndexOnlyScan("events_at_idx", [{"min": ["2020-01-01"], "exclusive": true}]) | docs.Project(id)'
+}
+*/
+
+-- test: text between
+SELECT id FROM events WHERE at BETWEEN '1969-07-20' AND '2021-01-01';
+/* result:
+{"id": 3}
+{"id": 2}
+*/
+
+-- test: invalid text
+SELECT id FROM events WHERE at = 'foo' OR at > 'foo';
+/* result:
+*/
+
+-- test: invalid text compared with untyped timestamps
+CREATE TABLE mixed(id int PRIMARY KEY, ...);
+INSERT INTO mixed (id, a) VALUES (1, TIMESTAMP '2023-03-15 10:20:30'), (2, 'hello');
+SELECT id FROM mixed WHERE a = 'hello';
+/* result:
+{"id": 2}
+*/
+
+-- test: invalid text not equal to timestamps
+CREATE TABLE mixed(id int PRIMARY KEY, ...);
+INSERT INTO mixed (id, a) VALUES (1, TIMESTAMP '2023-03-15 10:20:30'), (2, 'hello');
+SELECT id FROM mixed WHERE a != 'hello';
+/* result:
+{"id": 1}
+*/
+
+-- test: timestamp compared with stored text
+CREATE TABLE legacy(id int PRIMARY KEY, at text);
+INSERT INTO legacy (id, at) VALUES (1, '2023-03-15T10:20:30Z'), (2, '2021-01-01T00:00:00Z');
+SELECT id FROM legacy WHERE at = TIMESTAMP '2023-03-15 10:20:30';
+/* result:
+{"id": 1}
+*/
+
+-- test: functions
+SELECT id, time.extract('year', at) AS year, time.date_trunc('month', at) AS month FROM events WHERE id = 1;
+/* result:
+{"id": 1, "year": 2023, "month": TIMESTAMP '2023-03-01T00:00:00Z'}
+*/
+
+-- test: interval
+SELECT id FROM events WHERE at > time.sub(TIMESTAMP '2023-03-15 10:20:30', '1 year');
+/* result:
+{"id": 1}
+{"id": 4}
+*/
+
+-- test: intervals are texts
+SELECT id FROM events WHERE at > time.sub(TIMESTAMP '2023-03-15 10:20:30', INTERVAL '1 year');
+-- error:
+
+-- test: update
+UPDATE events SET at = time.add(at, '1 day') WHERE id = 3;
+SELECT at FROM events WHERE id = 3;
+/* result:
+{"at": TIMESTAMP '1969-07-21T00:00:00Z'}
+*/
+
+-- test: default
+CREATE TABLE logs(id int PRIMARY KEY, at TIMESTAMP DEFAULT time.now());
+INSERT INTO logs (id) VALUES (1);
+SELECT id, at > TIMESTAMP '2023-01-01' AS recent, typeof(at) AS type FROM logs;
+/* result:
+{"id": 1, "recent": true, "type": "timestamp"}
+*/
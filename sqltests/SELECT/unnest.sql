@@ -0,0 +1,75 @@
+-- setup:
+CREATE TABLE orders(id int PRIMARY KEY, ...);
+CREATE TABLE products(sku text PRIMARY KEY, name text);
+INSERT INTO orders (id, items) VALUES
+    (1, [{"sku": "a", "price": 10}, {"sku": "b", "price": 5}]),
+    (2, [{"sku": "a", "price": 12}]),
+    (3, []);
+INSERT INTO orders (id) VALUES (4);
+INSERT INTO products (sku, name) VALUES ("a", "apple"), ("b", "banana");
+
+-- test: unnest
+SELECT o.id, item.sku FROM orders o, UNNEST(o.items) AS item;
+/* result:
+{"o.id": 1, "item.sku": "a"}
+{"o.id": 1, "item.sku": "b"}
+{"o.id": 2, "item.sku": "a"}
+*/
+
+-- test: unqualified column
+SELECT id, item.sku FROM orders o, UNNEST(items) AS item WHERE id > 1;
+/* result:
+{"id": 2, "item.sku": "a"}
+*/
+
+-- test: wildcard
+SELECT * FROM orders, UNNEST(orders.items) item WHERE orders.id = 2;
+/* result:
+{
+  "orders": {"id": 2, "items": [{"sku": "a", "price": 12.0}]},
+  "item": {"sku": "a", "price": 12.0}
+}
+*/
+
+-- test: with offset
+SELECT o.id, i, item.sku FROM orders o, UNNEST(o.items) AS item WITH OFFSET AS i WHERE i > 0;
+/* result:
+{"o.id": 1, "i": 1, "item.sku": "b"}
+*/
+
+-- test: aggregate
+SELECT item.sku, SUM(item.price) AS total, COUNT(*) AS n FROM orders o, UNNEST(o.items) AS item GROUP BY item.sku;
+/* result:
+{"item.sku": "a", "total": 22.0, "n": 2}
+{"item.sku": "b", "total": 5.0, "n": 1}
+*/
+
+-- test: literal array
+SELECT o.id, n FROM orders o, UNNEST([1, 2]) AS n WHERE o.id = 1;
+/* result:
+{"o.id": 1, "n": 1}
+{"o.id": 1, "n": 2}
+*/
+
+-- test: join
+SELECT o.id, p.name FROM orders o, UNNEST(o.items) AS item JOIN products p ON p.sku = item.sku;
+/* result:
+{"o.id": 1, "p.name": "apple"}
+{"o.id": 1, "p.name": "banana"}
+{"o.id": 2, "p.name": "apple"}
+*/
+
+-- test: nested unnest
+SELECT x, y FROM orders o, UNNEST([1, 2]) AS x, UNNEST([x * 10]) AS y WHERE o.id = 3;
+/* result:
+{"x": 1, "y": 10}
+{"x": 2, "y": 20}
+*/
+
+-- test: duplicate alias
+SELECT * FROM orders o, UNNEST(o.items) AS o;
+-- error:
+
+-- test: not an array
+SELECT * FROM orders o, UNNEST(o.id) AS item;
+-- error:
@@ -0,0 +1,158 @@
+-- setup:
+CREATE TABLE sales(id int PRIMARY KEY, region text, amount int);
+INSERT INTO sales (id, region, amount) VALUES
+    (1, "eu", 10),
+    (2, "eu", 30),
+    (3, "eu", 30),
+    (4, "us", 20),
+    (5, "us", 5);
+
+-- test: ROW_NUMBER
+SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS n FROM sales;
+/* result:
+{"id": 1, "n": 1}
+{"id": 2, "n": 2}
+{"id": 3, "n": 3}
+{"id": 4, "n": 4}
+{"id": 5, "n": 5}
+*/
+
+-- test: ROW_NUMBER with PARTITION BY
+SELECT id, ROW_NUMBER() OVER (PARTITION BY region ORDER BY id) AS n FROM sales;
+/* result:
+{"id": 1, "n": 1}
+{"id": 2, "n": 2}
+{"id": 3, "n": 3}
+{"id": 4, "n": 1}
+{"id": 5, "n": 2}
+*/
+
+-- test: RANK and DENSE_RANK
+SELECT id, RANK() OVER (ORDER BY amount DESC) AS r, DENSE_RANK() OVER (ORDER BY amount DESC) AS d FROM sales;
+/* sorted-result:
+{"id": 1, "r": 4, "d": 3}
+{"id": 2, "r": 1, "d": 1}
+{"id": 3, "r": 1, "d": 1}
+{"id": 4, "r": 3, "d": 2}
+{"id": 5, "r": 5, "d": 4}
+*/
+
+-- test: LAG and LEAD
+SELECT id, LAG(amount) OVER (PARTITION BY region ORDER BY id) AS prev, LEAD(amount, 1, 0) OVER (PARTITION BY region ORDER BY id) AS nxt FROM sales;
+/* result:
+{"id": 1, "prev": NULL, "nxt": 30}
+{"id": 2, "prev": 10, "nxt": 30}
+{"id": 3, "prev": 30, "nxt": 0}
+{"id": 4, "prev": NULL, "nxt": 5}
+{"id": 5, "prev": 20, "nxt": 0}
+*/
+
+-- test: LAG with offset
+SELECT id, LAG(id, 2) OVER (ORDER BY id) AS prev FROM sales;
+/* result:
+{"id": 1, "prev": NULL}
+{"id": 2, "prev": NULL}
+{"id": 3, "prev": 1}
+{"id": 4, "prev": 2}
+{"id": 5, "prev": 3}
+*/
+
+-- test: FIRST_VALUE
+SELECT id, FIRST_VALUE(amount) OVER (PARTITION BY region ORDER BY id) AS first FROM sales;
+/* result:
+{"id": 1, "first": 10}
+{"id": 2, "first": 10}
+{"id": 3, "first": 10}
+{"id": 4, "first": 20}
+{"id": 5, "first": 20}
+*/
+
+-- test: running SUM
+SELECT id, SUM(amount) OVER (ORDER BY id) AS total FROM sales;
+/* result:
+{"id": 1, "total": 10}
+{"id": 2, "total": 40}
+{"id": 3, "total": 70}
+{"id": 4, "total": 90}
+{"id": 5, "total": 95}
+*/
+
+-- test: running SUM with peers
+SELECT id, SUM(amount) OVER (PARTITION BY region ORDER BY amount) AS total FROM sales WHERE region = "eu";
+/* sorted-result:
+{"id": 1, "total": 10}
+{"id": 2, "total": 70}
+{"id": 3, "total": 70}
+*/
+
+-- test: aggregates over the whole partition
+SELECT id, COUNT(*) OVER (PARTITION BY region) AS c, AVG(amount) OVER (PARTITION BY region) AS a, MIN(amount) OVER (PARTITION BY region) AS mi, MAX(amount) OVER (PARTITION BY region) AS ma FROM sales;
+/* sorted-result:
+{"id": 1, "c": 3, "a": 23.333333333333332, "mi": 10, "ma": 30}
+{"id": 2, "c": 3, "a": 23.333333333333332, "mi": 10, "ma": 30}
+{"id": 3, "c": 3, "a": 23.333333333333332, "mi": 10, "ma": 30}
+{"id": 4, "c": 2, "a": 12.5, "mi": 5, "ma": 20}
+{"id": 5, "c": 2, "a": 12.5, "mi": 5, "ma": 20}
+*/
+
+-- test: empty window
+SELECT id, COUNT(*) OVER () AS c FROM sales WHERE id < 3;
+/* result:
+{"id": 1, "c": 2}
+{"id": 2, "c": 2}
+*/
+
+-- test: window with ORDER BY
+SELECT id, ROW_NUMBER() OVER (PARTITION BY region ORDER BY amount) AS n FROM sales ORDER BY id DESC;
+/* result:
+{"id": 5, "n": 1}
+{"id": 4, "n": 2}
+{"id": 3, "n": 3}
+{"id": 2, "n": 2}
+{"id": 1, "n": 1}
+*/
+
+-- test: wildcard
+SELECT *, ROW_NUMBER() OVER (ORDER BY id) AS n FROM sales WHERE id = 1;
+/* result:
+{"id": 1, "region": "eu", "amount": 10, "n": 1}
+*/
+
+-- test: window function in expression
+SELECT id, ROW_NUMBER() OVER (ORDER BY id DESC) * 10 AS n FROM sales WHERE id > 3;
+/* sorted-result:
+{"id": 4, "n": 20}
+{"id": 5, "n": 10}
+*/
+
+-- test: different windows
+SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS a, ROW_NUMBER() OVER (ORDER BY amount DESC) AS b FROM sales ORDER BY id;
+/* result:
+{"id": 1, "a": 1, "b": 4}
+{"id": 2, "a": 2, "b": 2}
+{"id": 3, "a": 3, "b": 1}
+{"id": 4, "a": 4, "b": 3}
+{"id": 5, "a": 5, "b": 5}
+*/
+
+-- test: different windows with partitions
+SELECT id, RANK() OVER (PARTITION BY region ORDER BY amount) AS r, SUM(amount) OVER () AS total, COUNT(*) OVER (PARTITION BY region) AS c FROM sales ORDER BY id;
+/* result:
+{"id": 1, "r": 1, "total": 95, "c": 3}
+{"id": 2, "r": 2, "total": 95, "c": 3}
+{"id": 3, "r": 2, "total": 95, "c": 3}
+{"id": 4, "r": 2, "total": 95, "c": 2}
+{"id": 5, "r": 1, "total": 95, "c": 2}
+*/
+
+-- test: window function without OVER
+SELECT ROW_NUMBER() FROM sales;
+-- error:
+
+-- test: window function with GROUP BY
+SELECT region, SUM(amount) OVER (ORDER BY region) FROM sales GROUP BY region;
+-- error: window functions cannot be used with GROUP BY at line 1, char 62
+
+-- test: window function nested in expression with GROUP BY
+SELECT region, RANK() OVER (ORDER BY region) + 1 FROM sales GROUP BY region;
+-- error: window functions cannot be used with GROUP BY at line 1, char 61
@@ -0,0 +1,91 @@
+-- setup:
+CREATE TABLE parent(id INT PRIMARY KEY, code TEXT UNIQUE, b INT);
+INSERT INTO parent (id, code) VALUES (1, "a"), (2, "b");
+
+-- test: update child
+CREATE TABLE child(a INT REFERENCES parent);
+INSERT INTO child (a) VALUES (1);
+UPDATE child SET a = 2;
+SELECT * FROM child;
+/* result:
+{
+  a: 2
+}
+*/
+
+-- test: update child, missing reference
+CREATE TABLE child(a INT REFERENCES parent);
+INSERT INTO child (a) VALUES (1);
+UPDATE child SET a = 3;
+-- error: FOREIGN KEY constraint "child_a_fkey" error: [a]
+
+-- test: restrict
+CREATE TABLE child(a INT REFERENCES parent);
+INSERT INTO child (a) VALUES (1);
+UPDATE parent SET id = 3 WHERE id = 1;
+-- error: FOREIGN KEY constraint "child_a_fkey" error: [a]
+
+-- test: restrict, referenced paths not modified
+CREATE TABLE child(a INT REFERENCES parent);
+INSERT INTO child (a) VALUES (1);
+UPDATE parent SET b = 10;
+SELECT id, b FROM parent;
+/* result:
+{
+  id: 1,
+  b: 10
+}
+{
+  id: 2,
+  b: 10
+}
+*/
+
+-- test: cascade
+CREATE TABLE child(a INT REFERENCES parent ON UPDATE CASCADE, b TEXT REFERENCES parent(code) ON UPDATE CASCADE);
+INSERT INTO child (a, b) VALUES (1, "a"), (2, "b");
+UPDATE parent SET id = 3, code = "c" WHERE id = 1;
+SELECT * FROM child;
+/* result:
+{
+  a: 3,
+  b: "c"
+}
+{
+  a: 2,
+  b: "b"
+}
+*/
+
+-- test: cascade to primary key
+CREATE TABLE child(a INT PRIMARY KEY REFERENCES parent ON UPDATE CASCADE);
+INSERT INTO child (a) VALUES (1);
+UPDATE parent SET id = 3 WHERE id = 1;
+SELECT * FROM child;
+/* result:
+{
+  a: 3
+}
+*/
+
+-- test: set null
+CREATE TABLE child(a TEXT REFERENCES parent(code) ON UPDATE SET NULL);
+INSERT INTO child (a) VALUES ("a");
+UPDATE parent SET code = "c" WHERE id = 1;
+SELECT a FROM child;
+/* result:
+{
+  a: null
+}
+*/
+
+-- test: cascade runs the triggers of the child table
+CREATE TABLE child(id INT PRIMARY KEY, a INT REFERENCES parent ON UPDATE CASCADE);
+CREATE TABLE audit(id INT, old_a INT, new_a INT);
+CREATE TRIGGER t AFTER UPDATE ON child INSERT INTO audit (id, old_a, new_a) VALUES (NEW.id, OLD.a, NEW.a);
+INSERT INTO child (id, a) VALUES (10, 1), (20, 2);
+UPDATE parent SET id = 3 WHERE id = 1;
+SELECT id, old_a, new_a FROM audit;
+/* result:
+{"id": 10, "old_a": 1, "new_a": 3}
+*/
@@ -0,0 +1,74 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT, c TEXT);
+INSERT INTO test (a, b, c) VALUES (1, 10, "foo"), (2, 20, "bar");
+
+-- test: wildcard
+UPDATE test SET b = b + 1 WHERE a = 1 RETURNING *;
+/* result:
+{
+  a: 1,
+  b: 11,
+  c: "foo"
+}
+*/
+
+-- test: fields and pk
+UPDATE test SET c = "baz" RETURNING pk(), c;
+/* result:
+{
+  "pk()": [1],
+  c: "baz"
+}
+{
+  "pk()": [2],
+  c: "baz"
+}
+*/
+
+-- test: old values
+UPDATE test SET b = b * 2 RETURNING a, OLD.b AS old, b AS new;
+/* result:
+{
+  a: 1,
+  old: 10,
+  new: 20
+}
+{
+  a: 2,
+  old: 20,
+  new: 40
+}
+*/
+
+-- test: new values
+UPDATE test SET b = b * 2 WHERE a = 1 RETURNING NEW.b AS new, OLD.b AS old, NEW.c;
+/* result:
+{
+  new: 20,
+  old: 10,
+  "NEW.c": "foo"
+}
+*/
+
+-- test: modified primary key
+UPDATE test SET a = a + 10 WHERE a = 2 RETURNING pk(), OLD.a;
+/* result:
+{
+  "pk()": [12],
+  "OLD.a": 2
+}
+*/
+
+-- test: no match
+UPDATE test SET b = 0 WHERE a = 3 RETURNING *;
+/* result:
+*/
+
+-- test: unset
+UPDATE test UNSET c WHERE a = 1 RETURNING *;
+/* result:
+{
+  a: 1,
+  b: 10
+}
+*/
@@ -113,3 +113,49 @@ NULL
 
 > 1000000000000000000 * 1000000000000000000 * 1000000000000000000
 1000000000000000000000000000000000000000000000000000000
+
+-- test: decimal arithmetic
+> DECIMAL '0.1' + DECIMAL '0.2'
+DECIMAL '0.3'
+
+> DECIMAL '0.1' + DECIMAL '0.2' = DECIMAL '0.3'
+true
+
+> DECIMAL '1.50' + 1
+DECIMAL '2.50'
+
+> DECIMAL '1.5' - 2
+DECIMAL '-0.5'
+
+> DECIMAL '1.25' * 4
+DECIMAL '5.00'
+
+> DECIMAL '10' / 4
+DECIMAL '2.5'
+
+> DECIMAL '1' / 3
+DECIMAL '0.3333333333333333'
+
+> DECIMAL '1' / 0
+NULL
+
+> DECIMAL '10.5' % 3
+DECIMAL '1.5'
+
+> DECIMAL '1.5' + 1.5
+DECIMAL '3.0'
+
+> DECIMAL '0.1' * 1.5
+DECIMAL '0.15'
+
+> 1.5 - DECIMAL '0.1'
+DECIMAL '1.4'
+
+> DECIMAL '1.5' + NULL
+NULL
+
+> DECIMAL '1.50' = 1.5
+true
+
+> DECIMAL '2' > 1
+true
@@ -0,0 +1,48 @@
+-- test: searched
+> CASE WHEN 1 > 2 THEN 'a' WHEN 2 > 1 THEN 'b' ELSE 'c' END
+'b'
+
+> CASE WHEN false THEN 'a' ELSE 'c' END
+'c'
+
+> CASE WHEN NULL THEN 'a' ELSE 'c' END
+'c'
+
+> CASE WHEN false THEN 'a' END
+NULL
+
+> CASE WHEN 1 THEN 1 + 1 END
+2
+
+-- test: simple
+> CASE 1 WHEN 2 THEN 'two' WHEN 1 THEN 'one' END
+'one'
+
+> CASE 1 WHEN 1.0 THEN 'one' END
+'one'
+
+> CASE 3 WHEN 2 THEN 'two' WHEN 1 THEN 'one' ELSE 'other' END
+'other'
+
+> CASE NULL WHEN NULL THEN 'null' ELSE 'other' END
+'other'
+
+> CASE 'a' || 'b' WHEN 'ab' THEN true END
+true
+
+-- test: nested
+> CASE WHEN true THEN CASE 2 WHEN 2 THEN 'nested' END END
+'nested'
+
+-- test: errors
+! CASE END
+'found END, expected WHEN'
+
+! CASE WHEN 1 END
+'found END, expected THEN'
+
+! CASE WHEN 1 THEN 2
+'found EOF, expected END'
+
+! CASE WHEN 1 THEN 2 ELSE 3
+'found EOF, expected END'
@@ -111,6 +111,27 @@ false
 
 ! CAST ('{"a": 1' AS DOCUMENT)
 
+> CAST ('2023-03-15T10:20:30.5+02:00' AS TIMESTAMP)
+TIMESTAMP '2023-03-15T08:20:30.5Z'
+
+> CAST ('2023-03-15 10:20:30' AS TIMESTAMP)
+TIMESTAMP '2023-03-15T10:20:30Z'
+
+> CAST ('2023-03-15' AS TIMESTAMP)
+TIMESTAMP '2023-03-15T00:00:00Z'
+
+> CAST ('2023-03-15 10:20:30' AS DATE)
+TIMESTAMP '2023-03-15T00:00:00Z'
+
+> CAST (TIMESTAMP '2023-03-15T23:20:30+02:00' AS DATE)
+TIMESTAMP '2023-03-15T00:00:00Z'
+
+! CAST (10 AS DATE)
+'cannot cast integer as date'
+
+! CAST ('15/03/2023' AS TIMESTAMP)
+'cannot cast "15/03/2023" as timestamp'
+
 -- test: source(BLOB)
 > CAST ('\xAF' AS BLOB)
 '\xAF'
@@ -130,6 +151,67 @@ false
 ! CAST ('\xAF' AS DOCUMENT)
 'cannot cast blob as document'
 
+-- test: source(TIMESTAMP)
+> CAST (TIMESTAMP '2023-03-15 10:20:30' AS TIMESTAMP)
+TIMESTAMP '2023-03-15T10:20:30Z'
+
+> CAST (TIMESTAMP '2023-03-15 10:20:30.123' AS TEXT)
+'2023-03-15T10:20:30.123Z'
+
+! CAST (TIMESTAMP '2023-03-15' AS INTEGER)
+'cannot cast timestamp as integer'
+
+! CAST (TIMESTAMP '2023-03-15' AS BLOB)
+'cannot cast timestamp as blob'
+
+! CAST (1 AS TIMESTAMP)
+'cannot cast integer as timestamp'
+
+-- test: source(DECIMAL)
+> CAST (DECIMAL '12.50' AS DECIMAL)
+DECIMAL '12.50'
+
+> CAST (DECIMAL '12.50' AS INTEGER)
+12
+
+> CAST (DECIMAL '-12.5' AS DOUBLE)
+-12.5
+
+> CAST (DECIMAL '12.50' AS TEXT)
+'12.50'
+
+> CAST (12 AS DECIMAL)
+DECIMAL '12'
+
+> CAST (0.1 AS DECIMAL)
+DECIMAL '0.1'
+
+> CAST ('123.456' AS DECIMAL(5, 2))
+DECIMAL '123.46'
+
+> CAST (1 AS DECIMAL(5, 2))
+DECIMAL '1.00'
+
+! CAST (1000 AS DECIMAL(5, 2))
+'value 1000 does not fit in DECIMAL(5, 2)'
+
+! CAST ('foo' AS DECIMAL)
+
+> CAST ('1e3' AS DECIMAL)
+DECIMAL '1000'
+
+> CAST ('0e999999999' AS DECIMAL)
+DECIMAL '0'
+
+! CAST ('1e999999999' AS DECIMAL)
+'cannot cast "1e999999999" as decimal: decimal "1e999999999" exceeds the maximum precision of 1000'
+
+! CAST ('1e-999999999' AS DECIMAL)
+'cannot cast "1e-999999999" as decimal: decimal "1e-999999999" exceeds the maximum scale of 1000'
+
+! CAST (true AS DECIMAL)
+'cannot cast boolean as decimal'
+
 -- test: source(ARRAY)
 > CAST ([1] AS ARRAY)
 [1]
@@ -0,0 +1,34 @@
+-- test: =~
+> 'foo' =~ 'fo+'
+true
+
+> 'foo' =~ '^o'
+false
+
+> 'foobar' =~ '^foo.*r$'
+true
+
+> 'FOO' =~ '(?i)^foo$'
+true
+
+> 'foo' =~ 'f' || 'o'
+true
+
+> 1 =~ '1'
+NULL
+
+> 'foo' =~ NULL
+NULL
+
+! 'foo' =~ '('
+'error parsing regexp'
+
+-- test: !~
+> 'foo' !~ 'fo+'
+false
+
+> 'foo' !~ '^o'
+true
+
+> NULL !~ 'foo'
+NULL
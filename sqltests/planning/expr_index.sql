@@ -0,0 +1,59 @@
+-- setup:
+CREATE TABLE test(a text, b int, c int);
+
+CREATE INDEX test_lower_a ON test(strings.lower(a));
+
+CREATE INDEX test_b_c ON test(b, c * 2);
+
+INSERT INTO
+    test (a, b, c)
+VALUES
+    ('A', 1, 1),
+    ('b', 2, 2),
+    ('C', 3, 3);
+
+-- test: expression
+EXPLAIN SELECT * FROM test WHERE strings.lower(a) = 'a';
+/* result:
+{
+    "plan": 'index.Scan("test_lower_a", [{"min": ["a"], "exact": true}])'
+}
+*/
+
+-- test: expression on the right
+EXPLAIN SELECT * FROM test WHERE 'a' = strings.lower(a);
+/* result:
+{
+    "plan": 'index.Scan("test_lower_a", [{"min": ["a"], "exact": true}])'
+}
+*/
+
+-- test: different expression
+EXPLAIN SELECT * FROM test WHERE strings.upper(a) = 'A';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(strings.upper(a) = "A")'
+}
+*/
+
+-- test: composite
+EXPLAIN SELECT * FROM test WHERE b = 2 AND c * 2 > 3;
+/* result:
+{
+    "plan": 'index.Scan("test_b_c", [{"min": [2, 3], "exclusive": true}])'
+}
+*/
+
+-- test: composite results
+SELECT b FROM test WHERE b = 2 AND c * 2 = 4;
+/* result:
+{"b": 2}
+*/
+
+-- test: IN
+SELECT a FROM test WHERE strings.lower(a) IN ['a', 'c'];
+/* result:
+{"a": "A"}
+{"a": "C"}
+*/
+
@@ -0,0 +1,146 @@
+-- setup:
+CREATE TABLE test(id int PRIMARY KEY, a int, b text, c double, d DECIMAL(6, 2), e ARRAY, f (g int));
+
+CREATE INDEX test_a_b ON test(a, b);
+
+CREATE INDEX test_c_d ON test(c, d);
+
+CREATE INDEX test_e ON test(e[*]);
+
+CREATE INDEX test_f_g ON test(f.g);
+
+INSERT INTO
+    test (id, a, b, c, d, e, f)
+VALUES
+    (1, 10, 'x', 1.5, 1.10, [1, 2], {g: 1}),
+    (2, 20, 'y', 2.5, 2.20, [2, 3], {g: 2}),
+    (3, 30, NULL, 3.5, 3.30, [3, 4], {g: 3});
+
+-- test: covered projection and filter
+EXPLAIN SELECT a, b FROM test WHERE a = 10;
+/* result:
+{
+    "plan": 'index.IndexOnlyScan("test_a_b", [{"min": [10], "exact": true}]) | docs.Project(a, b)'
+}
+*/
+
+-- test: covered projection and filter results
+SELECT a, b FROM test WHERE a >= 20;
+/* result:
+{"a": 20, "b": "y"}
+{"a": 30, "b": null}
+*/
+
+-- test: primary key
+EXPLAIN SELECT id, b FROM test WHERE a = 10 AND b = 'x';
+/* result:
+{
+    "plan": 'index.IndexOnlyScan("test_a_b", [{"min": [10, "x"], "exact": true}]) | docs.Project(id, b)'
+}
+*/
+
+-- test: primary key results
+SELECT id, pk() AS k, typeof(id) AS t FROM test WHERE a > 10 AND a < 30;
+/* result:
+{"id": 2, "k": [2], "t": "integer"}
+*/
+
+-- test: remaining filter
+EXPLAIN SELECT a FROM test WHERE a > 10 AND b = 'y';
+/* result:
+{
+    "plan": 'index.IndexOnlyScan("test_a_b", [{"min": [10], "exclusive": true}]) | docs.Filter(b = "y") | docs.Project(a)'
+}
+*/
+
+-- test: path not in the index
+EXPLAIN SELECT a, c FROM test WHERE a = 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [10], "exact": true}]) | docs.Project(a, c)'
+}
+*/
+
+-- test: filter not in the index
+EXPLAIN SELECT a FROM test WHERE a = 10 AND c > 1;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [10], "exact": true}]) | docs.Filter(c > 1) | docs.Project(a)'
+}
+*/
+
+-- test: wildcard
+EXPLAIN SELECT * FROM test WHERE a = 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [10], "exact": true}])'
+}
+*/
+
+-- test: order by
+EXPLAIN SELECT b FROM test ORDER BY a DESC;
+/* result:
+{
+    "plan": 'index.IndexOnlyScanReverse("test_a_b") | docs.Project(b)'
+}
+*/
+
+-- test: order by results
+SELECT b FROM test ORDER BY a DESC LIMIT 2;
+/* result:
+{"b": null}
+{"b": "y"}
+*/
+
+-- test: aggregate
+EXPLAIN SELECT COUNT(*) AS n, MAX(b) AS m FROM test WHERE a > 10;
+/* result:
+{
+    "plan": 'index.IndexOnlyScan("test_a_b", [{"min": [10], "exclusive": true}]) | docs.GroupAggregate(NULL, COUNT(*), MAX(b)) | docs.Project(COUNT(*), MAX(b))'
+}
+*/
+
+-- test: aggregate results
+SELECT COUNT(*) AS n, MAX(b) AS m FROM test WHERE a > 10;
+/* result:
+{"n": 2, "m": "y"}
+*/
+
+-- test: decimals
+EXPLAIN SELECT c, d FROM test WHERE c > 2;
+/* result:
+{
+    "plan": 'index.IndexOnlyScan("test_c_d", [{"min": [2], "exclusive": true}]) | docs.Project(c, d)'
+}
+*/
+
+-- test: decimals are read from the table
+SELECT c, d FROM test WHERE c > 2;
+/* result:
+{"c": 2.5, "d": DECIMAL '2.20'}
+{"c": 3.5, "d": DECIMAL '3.30'}
+*/
+
+-- test: multikey index
+EXPLAIN SELECT e FROM test WHERE 2 IN e;
+/* result:
+{
+    "plan": 'index.Scan("test_e", [{"min": [2], "exact": true}]) | docs.Project(e)'
+}
+*/
+
+-- test: nested path
+EXPLAIN SELECT f.g FROM test WHERE f.g = 1;
+/* result:
+{
+    "plan": 'index.Scan("test_f_g", [{"min": [1], "exact": true}]) | docs.Project(f.g)'
+}
+*/
+
+-- test: update
+EXPLAIN UPDATE test SET b = 'z' WHERE a = 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [10], "exact": true}]) | paths.Set(b, "z") | table.Validate("test") | index.Delete("test_a_b") | index.Delete("test_c_d") | index.Delete("test_e") | index.Delete("test_f_g") | table.Replace("test") | index.Insert("test_a_b") | index.Insert("test_c_d") | index.Insert("test_e") | index.Insert("test_f_g") | discard()'
+}
+*/
@@ -0,0 +1,53 @@
+-- setup:
+CREATE TABLE foo(id int PRIMARY KEY, a int);
+CREATE TABLE bar(id int PRIMARY KEY, foo_id int, b int);
+CREATE TABLE baz(bar_id int, c int);
+CREATE INDEX bar_foo_id ON bar(foo_id);
+
+-- test: nested loop
+EXPLAIN SELECT * FROM foo JOIN baz ON foo.a = baz.c;
+/* result:
+{
+    "plan": 'table.Scan("foo") | docs.Alias("foo") | nestedLoopJoin("baz", table.Scan("baz"), foo.a = baz.c)'
+}
+*/
+
+-- test: left nested loop
+EXPLAIN SELECT * FROM foo f LEFT JOIN baz z ON f.a = z.c;
+/* result:
+{
+    "plan": 'table.Scan("foo") | docs.Alias("f") | nestedLoopLeftJoin("z", table.Scan("baz"), f.a = z.c)'
+}
+*/
+
+-- test: index lookup
+EXPLAIN SELECT * FROM foo f JOIN bar b ON f.id = b.foo_id;
+/* result:
+{
+    "plan": 'table.Scan("foo") | docs.Alias("f") | index.Join("bar_foo_id", "b", f.id, f.id = b.foo_id)'
+}
+*/
+
+-- test: left index lookup
+EXPLAIN SELECT * FROM foo f LEFT JOIN bar b ON b.foo_id = f.id AND b.b > 10;
+/* result:
+{
+    "plan": 'table.Scan("foo") | docs.Alias("f") | index.LeftJoin("bar_foo_id", "b", f.id, b.foo_id = f.id AND b.b > 10)'
+}
+*/
+
+-- test: primary key lookup
+EXPLAIN SELECT * FROM baz z JOIN bar b ON b.id = z.bar_id;
+/* result:
+{
+    "plan": 'table.Scan("baz") | docs.Alias("z") | nestedLoopJoin("b", table.Scan("bar", [{"min": [z.bar_id], "exact": true}]), b.id = z.bar_id)'
+}
+*/
+
+-- test: condition referencing the joined table on both sides
+EXPLAIN SELECT * FROM foo f JOIN bar b ON b.foo_id = b.id;
+/* result:
+{
+    "plan": 'table.Scan("foo") | docs.Alias("f") | nestedLoopJoin("b", table.Scan("bar"), b.foo_id = b.id)'
+}
+*/
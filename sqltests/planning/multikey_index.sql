@@ -0,0 +1,67 @@
+-- setup:
+CREATE TABLE test(a int, b ARRAY, c ARRAY);
+
+CREATE INDEX test_b ON test(b[*]);
+
+CREATE INDEX test_a_c ON test(a, c[*]);
+
+INSERT INTO
+    test (a, b, c)
+VALUES
+    (1, ['x', 'y'], [1, 2]),
+    (2, ['y', 'z'], [2, 3]),
+    (3, [], [3, 4]);
+
+-- test: membership
+EXPLAIN SELECT * FROM test WHERE 'y' IN b;
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": ["y"], "exact": true}])'
+}
+*/
+
+-- test: element comparison
+EXPLAIN SELECT * FROM test WHERE b[*] > 'x';
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": ["x"], "exclusive": true}])'
+}
+*/
+
+-- test: element list results
+SELECT a FROM test WHERE b[*] IN ['x', 'y'];
+/* result:
+{"a": 1}
+{"a": 2}
+*/
+
+-- test: composite
+EXPLAIN SELECT * FROM test WHERE a = 2 AND 3 IN c;
+/* result:
+{
+    "plan": 'index.Scan("test_a_c", [{"min": [2, 3], "exact": true}])'
+}
+*/
+
+-- test: composite results
+SELECT a FROM test WHERE a >= 2 AND c[*] BETWEEN 3 AND 4;
+/* result:
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: whole array
+EXPLAIN SELECT * FROM test WHERE b = ['x', 'y'];
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b = ["x", "y"])'
+}
+*/
+
+-- test: path of the array
+EXPLAIN SELECT * FROM test WHERE 'y' IN b[*];
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter("y" IN b[*])'
+}
+*/
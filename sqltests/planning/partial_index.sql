@@ -0,0 +1,125 @@
+-- setup:
+CREATE TABLE test(a int, b int, c bool);
+
+CREATE INDEX test_a ON test(a) WHERE c;
+
+CREATE INDEX test_b ON test(b) WHERE b > 10 AND c = false;
+
+INSERT INTO
+    test (a, b, c)
+VALUES
+    (1, 1, true),
+    (2, 20, false),
+    (3, 30, true),
+    (4, 40, false),
+    (5, 50, NULL);
+
+-- test: predicate not implied
+EXPLAIN SELECT * FROM test WHERE a = 1;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a = 1)'
+}
+*/
+
+-- test: predicate implied
+EXPLAIN SELECT * FROM test WHERE a = 1 AND c;
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": [1], "exact": true}]) | docs.Filter(c)'
+}
+*/
+
+-- test: predicate with multiple conditions implied
+EXPLAIN SELECT * FROM test WHERE c = false AND b = 20 AND b > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [20], "exact": true}]) | docs.Filter(c = false) | docs.Filter(b > 10)'
+}
+*/
+
+-- test: predicate partially implied
+EXPLAIN SELECT * FROM test WHERE b = 5 AND c = false;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b = 5) | docs.Filter(c = false)'
+}
+*/
+
+-- test: equality implies range
+EXPLAIN SELECT * FROM test WHERE b = 20 AND c = false;
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [20], "exact": true}]) | docs.Filter(c = false)'
+}
+*/
+
+-- test: tighter range implies range
+EXPLAIN SELECT * FROM test WHERE b > 25 AND c = false;
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [25], "exclusive": true}]) | docs.Filter(c = false)'
+}
+*/
+
+-- test: tighter inclusive range implies range
+EXPLAIN SELECT * FROM test WHERE b >= 11 AND c = false;
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [11]}]) | docs.Filter(c = false)'
+}
+*/
+
+-- test: looser range doesn't imply range
+EXPLAIN SELECT * FROM test WHERE b >= 10 AND c = false;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b >= 10) | docs.Filter(c = false)'
+}
+*/
+
+-- test: comparison with true implies boolean path
+EXPLAIN SELECT * FROM test WHERE a = 1 AND c = true;
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": [1], "exact": true}]) | docs.Filter(c = true)'
+}
+*/
+
+-- test: comparison with reversed operands implies boolean path
+EXPLAIN SELECT * FROM test WHERE a = 1 AND true = c;
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": [1], "exact": true}]) | docs.Filter(true = c)'
+}
+*/
+
+-- test: boolean field implies comparison with true
+CREATE INDEX test_c ON test(b) WHERE c = true;
+EXPLAIN SELECT * FROM test WHERE b = 1 AND c;
+/* result:
+{
+    "plan": 'index.Scan("test_c", [{"min": [1], "exact": true}]) | docs.Filter(c)'
+}
+*/
+
+-- test: implied range results
+SELECT a, b FROM test WHERE b > 25 AND c = false;
+/* result:
+{"a": 4, "b": 40}
+*/
+
+-- test: results
+SELECT a, b FROM test WHERE b > 10 AND c = false ORDER BY b;
+/* result:
+{"a": 2, "b": 20}
+{"a": 4, "b": 40}
+*/
+
+-- test: order by
+EXPLAIN SELECT a FROM test WHERE c ORDER BY a;
+/* result:
+{
+    "plan": 'index.Scan("test_a") | docs.Filter(c) | docs.Project(a)'
+}
+*/
@@ -24,3 +24,27 @@ EXPLAIN SELECT * FROM test WHERE 4 * 3 BETWEEN 3 + 6 AND 5 * 10;
     plan: "table.Scan(\"test\")"
 }
 */
+
+-- test: precalculate CASE branches
+EXPLAIN SELECT * FROM test WHERE CASE WHEN 1 > 2 THEN a WHEN b THEN c WHEN 2 > 1 THEN 1 + 1 WHEN d THEN e END;
+/* result:
+{
+    plan: "table.Scan(\"test\") | docs.Filter(CASE WHEN b THEN c ELSE 2 END)"
+}
+*/
+
+-- test: precalculate CASE with constant operand
+EXPLAIN SELECT * FROM test WHERE CASE 3 WHEN 1 THEN a WHEN 1 + 2 THEN b > 1 END;
+/* result:
+{
+    plan: "table.Scan(\"test\") | docs.Filter(b > 1)"
+}
+*/
+
+-- test: precalculate COALESCE
+EXPLAIN SELECT * FROM test WHERE COALESCE(NULL, a, 1 + 1, b);
+/* result:
+{
+    plan: "table.Scan(\"test\") | docs.Filter(COALESCE(a, 2))"
+}
+*/
@@ -0,0 +1,68 @@
+-- setup:
+CREATE TABLE test(a text, b int, c text);
+CREATE INDEX test_a ON test(a);
+CREATE INDEX test_b_c ON test(b, c);
+
+-- test: anchored prefix
+EXPLAIN SELECT * FROM test WHERE a =~ '^foo.*bar';
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": ["foo"], "max": ["fop"]}]) | docs.Filter(a =~ "^foo.*bar")'
+}
+*/
+
+-- test: anchored literal
+EXPLAIN SELECT * FROM test WHERE a =~ '^foo';
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": ["foo"], "max": ["fop"]}]) | docs.Filter(a =~ "^foo")'
+}
+*/
+
+-- test: composite index
+EXPLAIN SELECT * FROM test WHERE b = 1 AND c =~ '^foo';
+/* result:
+{
+    "plan": 'index.Scan("test_b_c", [{"min": [1, "foo"], "max": [1, "fop"]}]) | docs.Filter(c =~ "^foo")'
+}
+*/
+
+-- test: not anchored
+EXPLAIN SELECT * FROM test WHERE a =~ 'foo';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a =~ "foo")'
+}
+*/
+
+-- test: case insensitive
+EXPLAIN SELECT * FROM test WHERE a =~ '(?i)^foo';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a =~ "(?i)^foo")'
+}
+*/
+
+-- test: multiline
+EXPLAIN SELECT * FROM test WHERE a =~ '(?m)^foo';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a =~ "(?m)^foo")'
+}
+*/
+
+-- test: no literal prefix
+EXPLAIN SELECT * FROM test WHERE a =~ '^.*foo';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a =~ "^.*foo")'
+}
+*/
+
+-- test: !~
+EXPLAIN SELECT * FROM test WHERE a !~ '^foo';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a !~ "^foo")'
+}
+*/
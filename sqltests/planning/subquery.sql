@@ -0,0 +1,28 @@
+-- setup:
+CREATE TABLE foo(id int PRIMARY KEY, a int);
+CREATE TABLE bar(id int PRIMARY KEY, foo_id int);
+CREATE INDEX bar_foo_id ON bar(foo_id);
+
+-- test: IN
+EXPLAIN SELECT * FROM foo WHERE id IN (SELECT foo_id FROM bar WHERE foo_id > 10);
+/* result:
+{
+    "plan": 'table.Scan("foo") | docs.Filter(id IN (index.IndexOnlyScan("bar_foo_id", [{"min": [10], "exclusive": true}]) | docs.Project(foo_id)))'
+}
+*/
+
+-- test: EXISTS
+EXPLAIN SELECT * FROM foo WHERE EXISTS (SELECT * FROM bar WHERE id = 1);
+/* result:
+{
+    "plan": 'table.Scan("foo") | docs.Filter(EXISTS (table.Scan("bar", [{"min": [1], "exact": true}])))'
+}
+*/
+
+-- test: scalar
+EXPLAIN SELECT (SELECT MAX(a) FROM foo);
+/* result:
+{
+    "plan": 'docs.Project((table.Scan("foo") | docs.GroupAggregate(NULL, MAX(a)) | docs.Project(MAX(a))))'
+}
+*/
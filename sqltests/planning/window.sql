@@ -0,0 +1,35 @@
+-- setup:
+CREATE TABLE test(a int, b int, c int);
+CREATE INDEX test_a ON test(a);
+
+-- test: without partition nor order
+EXPLAIN SELECT ROW_NUMBER() OVER () FROM test;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Window(ROW_NUMBER() OVER ()) | docs.Project(ROW_NUMBER() OVER ())'
+}
+*/
+
+-- test: partition and order
+EXPLAIN SELECT a, RANK() OVER (PARTITION BY a ORDER BY b DESC), SUM(c) OVER (PARTITION BY a ORDER BY b DESC) FROM test;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.TempTreeSortReverse([a, b]) | docs.Window(RANK() OVER (PARTITION BY a ORDER BY b DESC), SUM(c) OVER (PARTITION BY a ORDER BY b DESC)) | docs.Project(a, RANK() OVER (PARTITION BY a ORDER BY b DESC), SUM(c) OVER (PARTITION BY a ORDER BY b DESC))'
+}
+*/
+
+-- test: different windows
+EXPLAIN SELECT a, ROW_NUMBER() OVER (ORDER BY a), RANK() OVER (PARTITION BY b ORDER BY c), COUNT(*) OVER () FROM test;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.TempTreeSort(a) | docs.Window(ROW_NUMBER() OVER (ORDER BY a)) | docs.TempTreeSort([b, c]) | docs.Window(RANK() OVER (PARTITION BY b ORDER BY c)) | docs.Window(COUNT(*) OVER ()) | docs.Project(a, ROW_NUMBER() OVER (ORDER BY a), RANK() OVER (PARTITION BY b ORDER BY c), COUNT(*) OVER ())'
+}
+*/
+
+-- test: window sorts are not replaced by indexes
+EXPLAIN SELECT a, ROW_NUMBER() OVER (ORDER BY a) FROM test WHERE b > 10 ORDER BY a;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b > 10) | docs.TempTreeSort(a) | docs.Window(ROW_NUMBER() OVER (ORDER BY a)) | docs.Project(a, ROW_NUMBER() OVER (ORDER BY a)) | docs.TempTreeSort(a)'
+}
+*/
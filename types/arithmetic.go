@@ -68,6 +68,11 @@ func calculateValues(a, b Value, operator byte) (res Value, err error) {
 	}
 
 	if a.Type().IsNumber() && b.Type().IsNumber() {
+		// decimals are exact, they take precedence over doubles
+		if a.Type() == DecimalValue || b.Type() == DecimalValue {
+			return calculateDecimals(a, b, operator)
+		}
+
 		if a.Type() == DoubleValue || b.Type() == DoubleValue {
 			return calculateFloats(a, b, operator)
 		}
@@ -183,10 +188,58 @@ func calculateFloats(a, b Value, operator byte) (res Value, err error) {
 	}
 }
 
+func calculateDecimals(a, b Value, operator byte) (res Value, err error) {
+	a, err = convertDoubleToDecimal(a)
+	if err != nil {
+		return nil, err
+	}
+	b, err = convertDoubleToDecimal(b)
+	if err != nil {
+		return nil, err
+	}
+
+	xa := convertNumberToDecimal(a)
+	xb := convertNumberToDecimal(b)
+
+	switch operator {
+	case '+':
+		return NewDecimalValue(xa.Add(xb)), nil
+	case '-':
+		return NewDecimalValue(xa.Sub(xb)), nil
+	case '*':
+		return NewDecimalValue(xa.Mul(xb)), nil
+	case '/':
+		if xb.Sign() == 0 {
+			return NewNullValue(), nil
+		}
+
+		return NewDecimalValue(xa.Quo(xb)), nil
+	case '%':
+		if xb.Sign() == 0 {
+			return NewNullValue(), nil
+		}
+
+		return NewDecimalValue(xa.Rem(xb)), nil
+	case '&', '|', '^':
+		ia, oka := xa.Int64()
+		ib, okb := xb.Int64()
+		if !oka || !okb {
+			return NewNullValue(), nil
+		}
+
+		return calculateIntegers(NewIntegerValue(ia), NewIntegerValue(ib), operator)
+	default:
+		panic(fmt.Sprintf("unknown operator %c", operator))
+	}
+}
+
 func convertNumberToInteger(v Value) Value {
 	switch v.Type() {
 	case IntegerValue:
 		return v
+	case DecimalValue:
+		i, _ := As[Decimal](v).Int64()
+		return NewIntegerValue(i)
 	default:
 		return NewIntegerValue(int64(As[float64](v)))
 	}
@@ -196,7 +249,34 @@ func convertNumberToDouble(v Value) Value {
 	switch v.Type() {
 	case DoubleValue:
 		return v
+	case DecimalValue:
+		return NewDoubleValue(As[Decimal](v).Float64())
 	default:
 		return NewDoubleValue(float64(As[int64](v)))
 	}
 }
+
+// convertNumberToDecimal converts an integer or a decimal to a decimal.
+func convertNumberToDecimal(v Value) Decimal {
+	switch v.Type() {
+	case DecimalValue:
+		return As[Decimal](v)
+	default:
+		return NewDecimalFromInt(As[int64](v))
+	}
+}
+
+// convertDoubleToDecimal converts a double to its shortest decimal representation.
+// Other values are returned as is.
+func convertDoubleToDecimal(v Value) (Value, error) {
+	if v.Type() != DoubleValue {
+		return v, nil
+	}
+
+	d, err := NewDecimalFromFloat(As[float64](v))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDecimalValue(d), nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"sort"
 	"strings"
+	"time"
 )
 
 type operator uint8
@@ -90,10 +91,20 @@ func compare(op operator, l, r Value) (bool, error) {
 	case l.Type() == IntegerValue && r.Type() == IntegerValue:
 		return compareIntegers(op, As[int64](l), As[int64](r)), nil
 
+	// compare decimals with integers or decimals exactly
+	case l.Type().IsNumber() && r.Type().IsNumber() &&
+		(l.Type() == DecimalValue || r.Type() == DecimalValue) &&
+		l.Type() != DoubleValue && r.Type() != DoubleValue:
+		return compareDecimals(op, convertNumberToDecimal(l), convertNumberToDecimal(r)), nil
+
 	// compare numbers together
 	case l.Type().IsNumber() && r.Type().IsNumber():
 		return compareNumbers(op, l, r), nil
 
+	// compare timestamps together
+	case l.Type() == TimestampValue && r.Type() == TimestampValue:
+		return compareTimestamps(op, As[time.Time](l), As[time.Time](r)), nil
+
 	// compare arrays together
 	case l.Type() == ArrayValue && r.Type() == ArrayValue:
 		return compareArrays(op, As[Array](l), As[Array](r))
@@ -168,6 +179,23 @@ func compareBlobs(op operator, l, r []byte) bool {
 	return false
 }
 
+func compareTimestamps(op operator, l, r time.Time) bool {
+	switch op {
+	case operatorEq:
+		return l.Equal(r)
+	case operatorGt:
+		return l.After(r)
+	case operatorGte:
+		return !l.Before(r)
+	case operatorLt:
+		return l.Before(r)
+	case operatorLte:
+		return !l.After(r)
+	}
+
+	return false
+}
+
 func compareIntegers(op operator, l, r int64) bool {
 	switch op {
 	case operatorEq:
@@ -185,6 +213,25 @@ func compareIntegers(op operator, l, r int64) bool {
 	return false
 }
 
+func compareDecimals(op operator, l, r Decimal) bool {
+	cmp := l.Cmp(r)
+
+	switch op {
+	case operatorEq:
+		return cmp == 0
+	case operatorGt:
+		return cmp > 0
+	case operatorGte:
+		return cmp >= 0
+	case operatorLt:
+		return cmp < 0
+	case operatorLte:
+		return cmp <= 0
+	}
+
+	return false
+}
+
 func compareNumbers(op operator, l, r Value) bool {
 	l = convertNumberToDouble(l)
 	r = convertNumberToDouble(r)
@@ -0,0 +1,339 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// divisionScale is the number of fractional digits computed when
+// dividing decimals whose quotient doesn't have a finite representation.
+const divisionScale = 16
+
+// MaxDecimalDigits is the maximum number of digits of a parsed decimal,
+// on either side of the decimal point.
+// It also bounds the precision of the DECIMAL type.
+const MaxDecimalDigits = 1000
+
+var bigTen = big.NewInt(10)
+
+// A Decimal is an exact decimal number of arbitrary precision.
+// Its value is equal to unscaled * 10^-scale.
+// Decimals are immutable, all the operations return new decimals.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns the decimal unscaled * 10^-scale.
+func NewDecimal(unscaled *big.Int, scale int32) Decimal {
+	u := new(big.Int)
+	if unscaled != nil && unscaled.Sign() != 0 {
+		u.Set(unscaled)
+	}
+	if scale < 0 {
+		if u.Sign() != 0 {
+			u.Mul(u, pow10(-int64(scale)))
+		}
+		scale = 0
+	}
+	return Decimal{unscaled: u, scale: scale}
+}
+
+// int returns the unscaled value of d, or zero for the zero Decimal.
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// NewDecimalFromInt returns the decimal representation of x.
+func NewDecimalFromInt(x int64) Decimal {
+	return NewDecimal(big.NewInt(x), 0)
+}
+
+// NewDecimalFromFloat returns the shortest decimal representation of x.
+func NewDecimalFromFloat(x float64) (Decimal, error) {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return Decimal{}, fmt.Errorf("cannot convert %v to decimal", x)
+	}
+
+	return ParseDecimal(strconv.FormatFloat(x, 'f', -1, 64))
+}
+
+// ParseDecimal parses a decimal number, with an optional exponent.
+// The scale of the decimal is the number of digits after the decimal point.
+// Examples: "10", "-1.50", "1.5e3".
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, int64(0)
+
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		exp, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		mantissa = s[:i]
+	}
+
+	var scale int64
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		scale = int64(len(mantissa) - i - 1)
+		mantissa = mantissa[:i] + mantissa[i+1:]
+	}
+
+	// only digits, with an optional sign, are allowed
+	digits := strings.TrimLeft(mantissa, "+-")
+	if len(mantissa)-len(digits) > 1 || digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	u, ok := new(big.Int).SetString(mantissa, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	// check the number of digits before scaling, to avoid
+	// computing huge powers of ten
+	scale -= exp
+	if scale > MaxDecimalDigits {
+		return Decimal{}, fmt.Errorf("decimal %q exceeds the maximum scale of %d", s, MaxDecimalDigits)
+	}
+	precision := int64(len(strings.TrimLeft(digits, "0")))
+	if scale < 0 && precision > 0 {
+		precision -= scale
+	}
+	if precision > MaxDecimalDigits {
+		return Decimal{}, fmt.Errorf("decimal %q exceeds the maximum precision of %d", s, MaxDecimalDigits)
+	}
+	if scale < 0 {
+		if u.Sign() != 0 {
+			u.Mul(u, pow10(-scale))
+		}
+		scale = 0
+	}
+
+	return NewDecimal(u, int32(scale)), nil
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(n), nil)
+}
+
+// Unscaled returns the unscaled value of d.
+func (d Decimal) Unscaled() *big.Int {
+	return new(big.Int).Set(d.int())
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return int(d.scale)
+}
+
+// Precision returns the total number of significant digits of d.
+func (d Decimal) Precision() int {
+	if d.int().Sign() == 0 {
+		return 1
+	}
+
+	return len(new(big.Int).Abs(d.int()).String())
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0 and +1 if d > 0.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return NewDecimal(new(big.Int).Neg(d.int()), d.scale)
+}
+
+// rescale returns the unscaled value of d expressed with the given scale.
+// The scale must be greater than or equal to the scale of d.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+
+	return new(big.Int).Mul(d.int(), pow10(int64(scale-d.scale)))
+}
+
+func maxScale(a, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+// Cmp compares d and other and returns -1 if d < other, 0 if d == other and +1 if d > other.
+// Decimals with different scales but the same value are equal.
+func (d Decimal) Cmp(other Decimal) int {
+	s := maxScale(d, other)
+	return d.rescale(s).Cmp(other.rescale(s))
+}
+
+// Add returns d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	s := maxScale(d, other)
+	return NewDecimal(new(big.Int).Add(d.rescale(s), other.rescale(s)), s)
+}
+
+// Sub returns d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	s := maxScale(d, other)
+	return NewDecimal(new(big.Int).Sub(d.rescale(s), other.rescale(s)), s)
+}
+
+// Mul returns d * other.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return NewDecimal(new(big.Int).Mul(d.int(), other.int()), d.scale+other.scale)
+}
+
+// Quo returns d / other, rounded half away from zero to divisionScale digits
+// after the largest scale of the operands. Trailing zeros beyond the
+// largest scale of the operands are removed.
+// It panics if other is zero.
+func (d Decimal) Quo(other Decimal) Decimal {
+	s := maxScale(d, other)
+
+	// d / other = (ud * 10^(s+divisionScale-sd+so) / uo) * 10^-(s+divisionScale)
+	num := new(big.Int).Mul(d.int(), pow10(int64(s+divisionScale-d.scale+other.scale)))
+	q := NewDecimal(roundQuo(num, other.int()), s+divisionScale)
+
+	return q.trim(s)
+}
+
+// Rem returns the remainder of the truncated division of d by other.
+// The sign of the result is the sign of d.
+// It panics if other is zero.
+func (d Decimal) Rem(other Decimal) Decimal {
+	s := maxScale(d, other)
+	return NewDecimal(new(big.Int).Rem(d.rescale(s), other.rescale(s)), s)
+}
+
+// Round returns d rounded half away from zero to the given number of
+// digits after the decimal point.
+func (d Decimal) Round(scale int) Decimal {
+	s := int32(scale)
+	if s >= d.scale {
+		return NewDecimal(d.rescale(s), s)
+	}
+
+	return NewDecimal(roundQuo(d.int(), pow10(int64(d.scale-s))), s)
+}
+
+// Truncate returns d truncated to the given number of digits after the decimal point.
+func (d Decimal) Truncate(scale int) Decimal {
+	s := int32(scale)
+	if s >= d.scale {
+		return NewDecimal(d.rescale(s), s)
+	}
+
+	return NewDecimal(new(big.Int).Quo(d.int(), pow10(int64(d.scale-s))), s)
+}
+
+// trim removes the trailing zeros of d, without going below the given scale.
+func (d Decimal) trim(scale int32) Decimal {
+	u := new(big.Int).Set(d.int())
+	s := d.scale
+	r := new(big.Int)
+	for s > scale {
+		q, m := new(big.Int).QuoRem(u, bigTen, r)
+		if m.Sign() != 0 {
+			break
+		}
+		u = q
+		s--
+	}
+
+	return NewDecimal(u, s)
+}
+
+// Normalize returns d without trailing zeros after the decimal point.
+func (d Decimal) Normalize() Decimal {
+	return d.trim(0)
+}
+
+// Fit rounds d to the given scale and ensures the result has no more
+// than precision significant digits.
+func (d Decimal) Fit(precision, scale int) (Decimal, error) {
+	r := d.Round(scale)
+	if r.Precision() > precision && r.Sign() != 0 {
+		return Decimal{}, fmt.Errorf("value %s does not fit in DECIMAL(%d, %d)", d, precision, scale)
+	}
+
+	return r, nil
+}
+
+// roundQuo returns x / y rounded half away from zero.
+func roundQuo(x, y *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// round away from zero if |2r| >= |y|
+	r.Abs(r)
+	r.Lsh(r, 1)
+	if r.CmpAbs(y) >= 0 {
+		if (x.Sign() < 0) != (y.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
+
+// Int64 returns the integer part of d.
+// It returns false if it doesn't fit in an int64.
+func (d Decimal) Int64() (int64, bool) {
+	i := d.Truncate(0).unscaled
+	if !i.IsInt64() {
+		return 0, false
+	}
+
+	return i.Int64(), true
+}
+
+// Float64 returns the nearest float64 value of d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Rat returns the value of d as a big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.int(), pow10(int64(d.scale)))
+}
+
+// String returns the representation of d, with exactly Scale digits
+// after the decimal point.
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.int()).String()
+
+	var sb strings.Builder
+	if d.int().Sign() < 0 {
+		sb.WriteByte('-')
+	}
+
+	if d.scale <= 0 {
+		sb.WriteString(s)
+		return sb.String()
+	}
+
+	scale := int(d.scale)
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+
+	sb.WriteString(s[:len(s)-scale])
+	sb.WriteByte('.')
+	sb.WriteString(s[len(s)-scale:])
+	return sb.String()
+}
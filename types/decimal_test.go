@@ -0,0 +1,162 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseDecimal(t testing.TB, s string) types.Decimal {
+	t.Helper()
+
+	d, err := types.ParseDecimal(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		fails    bool
+	}{
+		{"0", "0", false},
+		{"10", "10", false},
+		{"-1.50", "-1.50", false},
+		{"+1.5", "1.5", false},
+		{".5", "0.5", false},
+		{"1.5e3", "1500", false},
+		{"1.5E-3", "0.0015", false},
+		{"123456789012345678901234567890.123", "123456789012345678901234567890.123", false},
+		{"", "", true},
+		{"-", "", true},
+		{"1.2.3", "", true},
+		{"--1", "", true},
+		{"1e", "", true},
+		{"abc", "", true},
+		{"0e999999999", "0", false},
+		{"1e999", "1" + strings.Repeat("0", 999), false},
+		{"1e1000", "", true},
+		{"1e999999999", "", true},
+		{"1e-1000", "0." + strings.Repeat("0", 999) + "1", false},
+		{"1e-1001", "", true},
+		{"1e-999999999", "", true},
+		{strings.Repeat("9", 1001), "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			d, err := types.ParseDecimal(test.input)
+			if test.fails {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, d.String())
+		})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	tests := []struct {
+		a, b               string
+		add, sub, mul, quo string
+	}{
+		{"0.1", "0.2", "0.3", "-0.1", "0.02", "0.5"},
+		{"1.50", "2", "3.50", "-0.50", "3.00", "0.75"},
+		{"10", "3", "13", "7", "30", "3.3333333333333333"},
+		{"-7.5", "2.5", "-5.0", "-10.0", "-18.75", "-3.0"},
+		{"2", "3", "5", "-1", "6", "0.6666666666666667"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+" "+test.b, func(t *testing.T) {
+			a, b := mustParseDecimal(t, test.a), mustParseDecimal(t, test.b)
+
+			require.Equal(t, test.add, a.Add(b).String())
+			require.Equal(t, test.sub, a.Sub(b).String())
+			require.Equal(t, test.mul, a.Mul(b).String())
+			require.Equal(t, test.quo, a.Quo(b).String())
+		})
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		input           string
+		scale           int
+		round, truncate string
+	}{
+		{"1.005", 2, "1.01", "1.00"},
+		{"1.004", 2, "1.00", "1.00"},
+		{"-1.005", 2, "-1.01", "-1.00"},
+		{"2.5", 0, "3", "2"},
+		{"-2.5", 0, "-3", "-2"},
+		{"1.5", 3, "1.500", "1.500"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			d := mustParseDecimal(t, test.input)
+
+			require.Equal(t, test.round, d.Round(test.scale).String())
+			require.Equal(t, test.truncate, d.Truncate(test.scale).String())
+		})
+	}
+}
+
+func TestDecimalFit(t *testing.T) {
+	tests := []struct {
+		input            string
+		precision, scale int
+		expected         string
+		fails            bool
+	}{
+		{"123.456", 5, 2, "123.46", false},
+		{"0.001", 3, 2, "0.00", false},
+		{"999.995", 5, 2, "", true},
+		{"-12.5", 3, 1, "-12.5", false},
+		{"1234", 3, 0, "", true},
+		{"12", 4, 2, "12.00", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			d, err := mustParseDecimal(t, test.input).Fit(test.precision, test.scale)
+			if test.fails {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, d.String())
+		})
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	require.Equal(t, 0, mustParseDecimal(t, "1.50").Cmp(mustParseDecimal(t, "1.5")))
+	require.Equal(t, -1, mustParseDecimal(t, "-2").Cmp(mustParseDecimal(t, "1.5")))
+	require.Equal(t, 1, mustParseDecimal(t, "0.11").Cmp(mustParseDecimal(t, "0.1")))
+	require.Equal(t, 0, types.Decimal{}.Cmp(types.NewDecimalFromInt(0)))
+}
+
+func TestDecimalConversions(t *testing.T) {
+	d, err := types.NewDecimalFromFloat(0.1)
+	require.NoError(t, err)
+	require.Equal(t, "0.1", d.String())
+	require.Equal(t, 0.1, d.Float64())
+
+	i, ok := mustParseDecimal(t, "-12.9").Int64()
+	require.True(t, ok)
+	require.EqualValues(t, -12, i)
+
+	_, ok = mustParseDecimal(t, "1e30").Int64()
+	require.False(t, ok)
+
+	require.Equal(t, "12.5", mustParseDecimal(t, "12.5000").Normalize().String())
+	require.Equal(t, 4, mustParseDecimal(t, "-12.50").Precision())
+}
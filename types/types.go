@@ -31,6 +31,10 @@ const (
 
 	DoubleValue ValueType = 0xD0
 
+	DecimalValue ValueType = 0xD4
+
+	TimestampValue ValueType = 0xD8
+
 	TextValue ValueType = 0xDA
 
 	BlobValue ValueType = 0xE0
@@ -50,6 +54,10 @@ func (t ValueType) String() string {
 		return "integer"
 	case DoubleValue:
 		return "double"
+	case DecimalValue:
+		return "decimal"
+	case TimestampValue:
+		return "timestamp"
 	case BlobValue:
 		return "blob"
 	case TextValue:
@@ -63,9 +71,9 @@ func (t ValueType) String() string {
 	return "any"
 }
 
-// IsNumber returns true if t is either an integer of a float.
+// IsNumber returns true if t is either an integer, a float or a decimal.
 func (t ValueType) IsNumber() bool {
-	return t == IntegerValue || t == DoubleValue
+	return t == IntegerValue || t == DoubleValue || t == DecimalValue
 }
 
 // IsAny returns whether this is type is Any or a real type
@@ -8,6 +8,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/stringutil"
@@ -53,6 +54,23 @@ func NewDoubleValue(x float64) Value {
 	}
 }
 
+// NewDecimalValue encodes x and returns a value.
+func NewDecimalValue(x Decimal) Value {
+	return &value[Decimal]{
+		tp: DecimalValue,
+		v:  x,
+	}
+}
+
+// NewTimestampValue encodes x and returns a value.
+// Timestamps are stored in UTC with microsecond precision.
+func NewTimestampValue(x time.Time) Value {
+	return &value[time.Time]{
+		tp: TimestampValue,
+		v:  x.UTC().Truncate(time.Microsecond),
+	}
+}
+
 // NewBlobValue encodes x and returns a value.
 func NewBlobValue(x []byte) Value {
 	return &value[[]byte]{
@@ -148,6 +166,10 @@ func IsZeroValue(v Value) (bool, error) {
 		return As[int64](v) == int64(0), nil
 	case DoubleValue:
 		return As[float64](v) == float64(0), nil
+	case DecimalValue:
+		return As[Decimal](v).Sign() == 0, nil
+	case TimestampValue:
+		return As[time.Time](v).IsZero(), nil
 	case BlobValue:
 		return As[[]byte](v) == nil, nil
 	case TextValue:
@@ -238,6 +260,16 @@ func marshalText(dst *bytes.Buffer, v Value, prefix, indent string, depth int) e
 		}
 		dst.WriteString(strconv.FormatFloat(As[float64](v), fmt, prec, 64))
 		return nil
+	case DecimalValue:
+		dst.WriteString("DECIMAL '")
+		dst.WriteString(As[Decimal](v).String())
+		dst.WriteByte('\'')
+		return nil
+	case TimestampValue:
+		dst.WriteString("TIMESTAMP '")
+		dst.WriteString(As[time.Time](v).Format(time.RFC3339Nano))
+		dst.WriteByte('\'')
+		return nil
 	case TextValue:
 		dst.WriteString(strconv.Quote(As[string](v)))
 		return nil
@@ -317,6 +349,10 @@ func (v *value[T]) MarshalJSON() ([]byte, error) {
 	switch v.Type() {
 	case BooleanValue, IntegerValue, TextValue:
 		return v.MarshalText()
+	case DecimalValue:
+		return []byte(As[Decimal](v).String()), nil
+	case TimestampValue:
+		return []byte(strconv.Quote(As[time.Time](v).Format(time.RFC3339Nano))), nil
 	case NullValue:
 		return []byte("null"), nil
 	case DoubleValue:
@@ -14,7 +14,7 @@ import (
 )
 
 func TestValueMarshalText(t *testing.T) {
-	now := time.Now()
+	now := time.Now().UTC().Truncate(time.Microsecond)
 
 	tests := []struct {
 		name     string
@@ -36,7 +36,7 @@ func TestValueMarshalText(t *testing.T) {
 			"{a: 10, \"b c\": \"foo\", `\"d e\"`: \"foo\"}",
 		},
 		{"array", document.NewValueBuffer(types.NewIntegerValue(10), types.NewTextValue("foo")), `[10, "foo"]`},
-		{"time", now, `"` + now.Format(time.RFC3339Nano) + `"`},
+		{"time", now, `TIMESTAMP '` + now.Format(time.RFC3339Nano) + `'`},
 	}
 
 	for _, test := range tests {
@@ -46,18 +46,16 @@ func TestValueMarshalText(t *testing.T) {
 			data, err := v.MarshalText()
 			assert.NoError(t, err)
 			require.Equal(t, test.expected, string(data))
-			if test.name != "time" {
-				e := testutil.ParseExpr(t, string(data))
-				got, err := e.Eval(&environment.Environment{})
-				assert.NoError(t, err)
-				require.Equal(t, test.value, got.V())
-			}
+			e := testutil.ParseExpr(t, string(data))
+			got, err := e.Eval(&environment.Environment{})
+			assert.NoError(t, err)
+			require.Equal(t, test.value, got.V())
 		})
 	}
 }
 
 func TestMarshalTextIndent(t *testing.T) {
-	now := time.Now()
+	now := time.Now().UTC().Truncate(time.Microsecond)
 
 	tests := []struct {
 		name     string
@@ -89,7 +87,7 @@ func TestMarshalTextIndent(t *testing.T) {
   "foo"
 ]`,
 		},
-		{"time", now, `"` + now.Format(time.RFC3339Nano) + `"`},
+		{"time", now, `TIMESTAMP '` + now.Format(time.RFC3339Nano) + `'`},
 	}
 
 	for _, test := range tests {
@@ -99,12 +97,10 @@ func TestMarshalTextIndent(t *testing.T) {
 			data, err := types.MarshalTextIndent(v, "\n", "  ")
 			assert.NoError(t, err)
 			require.Equal(t, test.expected, string(data))
-			if test.name != "time" {
-				e := testutil.ParseExpr(t, string(data))
-				got, err := e.Eval(&environment.Environment{})
-				assert.NoError(t, err)
-				require.Equal(t, test.value, got.V())
-			}
+			e := testutil.ParseExpr(t, string(data))
+			got, err := e.Eval(&environment.Environment{})
+			assert.NoError(t, err)
+			require.Equal(t, test.value, got.V())
 		})
 	}
 }